@@ -111,12 +111,20 @@ func (client *Client) GetIPAddressList(id string) (addressList *IPAddressList, e
 
 // ListIPAddressLists retrieves all IP address lists associated with the specified network domain.
 func (client *Client) ListIPAddressLists(networkDomainID string) (addressLists *IPAddressLists, err error) {
+	return client.listIPAddressLists(networkDomainID, nil)
+}
+
+// listIPAddressLists retrieves a page of the IP address lists associated with the specified network domain (or the API's default page, if paging is nil).
+func (client *Client) listIPAddressLists(networkDomainID string, paging *Paging) (addressLists *IPAddressLists, err error) {
 	organizationID, err := client.getOrganizationID()
 	if err != nil {
 		return nil, err
 	}
 
 	requestURI := fmt.Sprintf("%s/network/ipAddressList?networkDomainId=%s", organizationID, networkDomainID)
+	if paging != nil {
+		requestURI += "&" + paging.toQueryParameters()
+	}
 	request, err := client.newRequestV22(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
@@ -144,6 +152,27 @@ func (client *Client) ListIPAddressLists(networkDomainID string) (addressLists *
 	return addressLists, err
 }
 
+// listAllIPAddressLists retrieves all IP address lists associated with the specified network domain (across all pages of results).
+func (client *Client) listAllIPAddressLists(networkDomainID string) (addressLists []IPAddressList, err error) {
+	paging := DefaultPaging()
+	for {
+		var page *IPAddressLists
+		page, err = client.listIPAddressLists(networkDomainID, paging)
+		if err != nil {
+			return nil, err
+		}
+
+		addressLists = append(addressLists, page.AddressLists...)
+		if page.IsLastPage() {
+			break
+		}
+
+		paging.Next()
+	}
+
+	return addressLists, nil
+}
+
 // CreateIPAddressList creates a new IP address list.
 // Returns the Id of the new IP address list.
 //
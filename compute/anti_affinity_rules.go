@@ -156,6 +156,27 @@ func (client *Client) ListServerAntiAffinityRules(networkDomainID string, paging
 	return rules, nil
 }
 
+// listAllServerAntiAffinityRules retrieves all server anti-affinity rules in the specified network domain (across all pages of results).
+func (client *Client) listAllServerAntiAffinityRules(networkDomainID string) (rules []ServerAntiAffinityRule, err error) {
+	paging := DefaultPaging()
+	for {
+		var page *ServerAntiAffinityRules
+		page, err = client.ListServerAntiAffinityRules(networkDomainID, paging)
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, page.Items...)
+		if page.IsLastPage() {
+			break
+		}
+
+		paging.Next()
+	}
+
+	return rules, nil
+}
+
 // CreateServerAntiAffinityRule creates an anti-affinity rule for the 2 specified servers.
 // server1Id is the Id of the first server.
 // server2Id is the Id of the second server.
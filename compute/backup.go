@@ -0,0 +1,543 @@
+package compute
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+)
+
+const (
+	// BackupServicePlanEssentials represents the "Essentials" Cloud Backup service plan.
+	BackupServicePlanEssentials = "Essentials"
+
+	// BackupServicePlanAdvanced represents the "Advanced" Cloud Backup service plan.
+	BackupServicePlanAdvanced = "Advanced"
+
+	// BackupServicePlanEnterprise represents the "Enterprise" Cloud Backup service plan.
+	BackupServicePlanEnterprise = "Enterprise"
+)
+
+const (
+	// BackupAlertTriggerOnFailure indicates that backup alerts should be sent when a backup job fails.
+	BackupAlertTriggerOnFailure = "ON_FAILURE"
+
+	// BackupAlertTriggerOnSuccess indicates that backup alerts should be sent when a backup job succeeds.
+	BackupAlertTriggerOnSuccess = "ON_SUCCESS"
+
+	// BackupAlertTriggerOnSuccessOrFailure indicates that backup alerts should be sent when a backup job completes.
+	BackupAlertTriggerOnSuccessOrFailure = "ON_SUCCESS_OR_FAILURE"
+)
+
+const (
+	// BackupClientStatusUnregistered indicates that a backup client has been added but the backup agent has not yet been installed and registered.
+	BackupClientStatusUnregistered = "Unregistered"
+
+	// BackupClientStatusActive indicates that a backup client is active.
+	BackupClientStatusActive = "Active"
+)
+
+// ServerBackupSummary represents summary backup information for a server (as returned with the server's details).
+type ServerBackupSummary struct {
+	// The server's Cloud Backup asset Id.
+	AssetID string `json:"assetId"`
+
+	// The server's Cloud Backup service plan.
+	ServicePlan string `json:"servicePlan"`
+
+	// The server's current backup state.
+	State string `json:"state"`
+}
+
+// IsBackupEnabled determines whether Cloud Backup is enabled for the server.
+func (server *Server) IsBackupEnabled() bool {
+	return server.Backup != nil
+}
+
+// ServerBackupDetails represents detailed Cloud Backup information for a server.
+type ServerBackupDetails struct {
+	XMLName xml.Name `xml:"BackupDetails"`
+
+	// The server's Cloud Backup asset Id.
+	AssetID string `xml:"assetId,attr"`
+
+	// The server's Cloud Backup service plan.
+	ServicePlan string `xml:"servicePlan,attr"`
+
+	// The server's current backup state.
+	State string `xml:"state,attr"`
+
+	// The server's backup clients.
+	Clients []BackupClientDetail `xml:"backupClient"`
+}
+
+// GetClient retrieves the backup client with the specified Id.
+// Returns nil if no backup client was found with the specified Id.
+func (details *ServerBackupDetails) GetClient(clientID string) *BackupClientDetail {
+	for index := range details.Clients {
+		if details.Clients[index].ID == clientID {
+			return &details.Clients[index]
+		}
+	}
+
+	return nil
+}
+
+// BackupClientDetail represents detailed information about a server's backup client.
+type BackupClientDetail struct {
+	// The backup client Id.
+	ID string `xml:"id,attr"`
+
+	// The backup client type (e.g. "FA.Linux").
+	Type string `xml:"type,attr"`
+
+	// Does the backup client back up a file system?
+	IsFileSystem bool `xml:"isFileSystem,attr"`
+
+	// The backup client's current status (e.g. BackupClientStatusUnregistered).
+	Status string `xml:"status,attr"`
+
+	// The backup client description.
+	Description string `xml:"description"`
+
+	// The name of the backup client's schedule policy.
+	SchedulePolicyName string `xml:"schedulePolicyName"`
+
+	// The name of the backup client's storage policy.
+	StoragePolicyName string `xml:"storagePolicyName"`
+
+	// The backup client's alerting configuration (if any).
+	Alerting *BackupClientAlerting `xml:"alerting"`
+
+	// The backup client's currently-running job (if any).
+	RunningJob *BackupJob `xml:"runningJob"`
+
+	// The URL from which the backup agent can be downloaded.
+	DownloadURL string `xml:"downloadUrl"`
+}
+
+// IsRegistered determines whether the backup agent for the backup client has been installed and registered.
+func (backupClient *BackupClientDetail) IsRegistered() bool {
+	return backupClient.Status != BackupClientStatusUnregistered
+}
+
+// BackupClientAlerting represents the alerting configuration for a backup client.
+type BackupClientAlerting struct {
+	// The condition that triggers alerts (e.g. BackupAlertTriggerOnFailure).
+	Trigger string `xml:"trigger,attr"`
+
+	// The e-mail addresses to which alerts are sent.
+	EmailAddresses []string `xml:"emailAddress"`
+}
+
+// BackupJob represents a backup job.
+type BackupJob struct {
+	// The job Id.
+	ID string `xml:"id,attr"`
+
+	// The job name.
+	Name string `xml:"name,attr"`
+
+	// The job status.
+	Status string `xml:"status,attr"`
+
+	// The percentage of the job that has been completed.
+	PercentageComplete int `xml:"percentageComplete,attr"`
+}
+
+// BackupClientType represents a type of backup client that can be added to a server.
+type BackupClientType struct {
+	// The backup client type (e.g. "FA.Linux").
+	Type string `xml:"type,attr"`
+
+	// Does the backup client back up a file system?
+	IsFileSystem bool `xml:"isFileSystem,attr"`
+
+	// The backup client type description.
+	Description string `xml:"description,attr"`
+}
+
+// BackupStoragePolicy represents a Cloud Backup storage policy.
+type BackupStoragePolicy struct {
+	// The storage policy name.
+	Name string `xml:"name,attr"`
+
+	// The number of days for which backups are retained.
+	RetentionPeriodInDays int `xml:"retentionPeriodInDays,attr"`
+
+	// The secondary location (if any) to which backups are replicated.
+	SecondaryLocation string `xml:"secondaryLocation,attr"`
+}
+
+// BackupSchedulePolicy represents a Cloud Backup schedule policy.
+type BackupSchedulePolicy struct {
+	// The schedule policy name.
+	Name string `xml:"name,attr"`
+
+	// The schedule policy description.
+	Description string `xml:"description,attr"`
+}
+
+// BackupClientConfiguration represents the configuration for a new (or modified) backup client.
+type BackupClientConfiguration struct {
+	// The backup client type (e.g. "FA.Linux"; ignored when modifying a backup client).
+	Type string
+
+	// The name of the backup client's storage policy.
+	StoragePolicyName string
+
+	// The name of the backup client's schedule policy.
+	SchedulePolicyName string
+
+	// The backup client's alerting configuration (optional).
+	Alerting *BackupClientAlerting
+}
+
+// AddedBackupClient represents the result of adding a backup client to a server.
+type AddedBackupClient struct {
+	// The new backup client's Id.
+	ID string
+
+	// The URL from which the backup agent can be downloaded.
+	DownloadURL string
+}
+
+// Response body when listing backup client types.
+type backupClientTypes struct {
+	XMLName xml.Name           `xml:"BackupClientTypes"`
+	Items   []BackupClientType `xml:"backupClientType"`
+}
+
+// Response body when listing backup storage policies.
+type backupStoragePolicies struct {
+	XMLName xml.Name              `xml:"BackupStoragePolicies"`
+	Items   []BackupStoragePolicy `xml:"storagePolicy"`
+}
+
+// Response body when listing backup schedule policies.
+type backupSchedulePolicies struct {
+	XMLName xml.Name               `xml:"BackupSchedulePolicies"`
+	Items   []BackupSchedulePolicy `xml:"schedulePolicy"`
+}
+
+// Request body when enabling Cloud Backup for a server.
+type newBackup struct {
+	XMLName     xml.Name `xml:"http://oec.api.opsource.net/schemas/backup NewBackup"`
+	ServicePlan string   `xml:"servicePlan,attr"`
+}
+
+// Request body when changing a server's Cloud Backup service plan.
+type modifyBackup struct {
+	XMLName     xml.Name `xml:"http://oec.api.opsource.net/schemas/backup ModifyBackup"`
+	ServicePlan string   `xml:"servicePlan,attr"`
+}
+
+// Request body when adding a backup client to a server.
+type newBackupClient struct {
+	XMLName            xml.Name              `xml:"http://oec.api.opsource.net/schemas/backup NewBackupClient"`
+	Type               string                `xml:"type"`
+	StoragePolicyName  string                `xml:"storagePolicyName"`
+	SchedulePolicyName string                `xml:"schedulePolicyName"`
+	Alerting           *BackupClientAlerting `xml:"alerting,omitempty"`
+}
+
+// Request body when modifying a server's backup client.
+type modifyBackupClient struct {
+	XMLName            xml.Name              `xml:"http://oec.api.opsource.net/schemas/backup ModifyBackupClient"`
+	StoragePolicyName  string                `xml:"storagePolicyName,omitempty"`
+	SchedulePolicyName string                `xml:"schedulePolicyName,omitempty"`
+	Alerting           *BackupClientAlerting `xml:"alerting,omitempty"`
+}
+
+// EnableServerBackup enables Cloud Backup for a server, using the specified service plan (e.g. BackupServicePlanEssentials).
+func (client *Client) EnableServerBackup(serverID string, servicePlan string) error {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return err
+	}
+
+	requestURI := fmt.Sprintf("%s/server/%s/backup", organizationID, serverID)
+
+	return client.executeBackupOperation(requestURI, http.MethodPost, &newBackup{
+		ServicePlan: servicePlan,
+	}, "enable backup for server '%s'", serverID)
+}
+
+// DisableServerBackup disables Cloud Backup for a server.
+//
+// The server's backup clients must be removed first.
+func (client *Client) DisableServerBackup(serverID string) error {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return err
+	}
+
+	requestURI := fmt.Sprintf("%s/server/%s/backup?disable", organizationID, serverID)
+
+	return client.executeBackupOperation(requestURI, http.MethodGet, nil, "disable backup for server '%s'", serverID)
+}
+
+// ChangeServerBackupServicePlan changes the Cloud Backup service plan for a server (e.g. to BackupServicePlanAdvanced).
+func (client *Client) ChangeServerBackupServicePlan(serverID string, servicePlan string) error {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return err
+	}
+
+	requestURI := fmt.Sprintf("%s/server/%s/backup/modify", organizationID, serverID)
+
+	return client.executeBackupOperation(requestURI, http.MethodPost, &modifyBackup{
+		ServicePlan: servicePlan,
+	}, "change backup service plan for server '%s'", serverID)
+}
+
+// GetServerBackupDetails retrieves detailed Cloud Backup information (including backup clients) for a server.
+// Returns nil if backup is not enabled for the server.
+func (client *Client) GetServerBackupDetails(serverID string) (details *ServerBackupDetails, err error) {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return nil, err
+	}
+
+	requestURI := fmt.Sprintf("%s/server/%s/backup/details", organizationID, serverID)
+	details = &ServerBackupDetails{}
+	found, err := client.getBackupResource(requestURI, details, "retrieve backup details for server '%s'", serverID)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	return details, nil
+}
+
+// ListServerBackupClientTypes retrieves the types of backup client that can be added to a server.
+func (client *Client) ListServerBackupClientTypes(serverID string) (clientTypes []BackupClientType, err error) {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return nil, err
+	}
+
+	requestURI := fmt.Sprintf("%s/server/%s/backup/client/type", organizationID, serverID)
+	response := &backupClientTypes{}
+	_, err = client.getBackupResource(requestURI, response, "list backup client types for server '%s'", serverID)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Items, nil
+}
+
+// ListServerBackupStoragePolicies retrieves the backup storage policies available to a server.
+func (client *Client) ListServerBackupStoragePolicies(serverID string) (storagePolicies []BackupStoragePolicy, err error) {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return nil, err
+	}
+
+	requestURI := fmt.Sprintf("%s/server/%s/backup/client/storagePolicy", organizationID, serverID)
+	response := &backupStoragePolicies{}
+	_, err = client.getBackupResource(requestURI, response, "list backup storage policies for server '%s'", serverID)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Items, nil
+}
+
+// ListServerBackupSchedulePolicies retrieves the backup schedule policies available to a server.
+func (client *Client) ListServerBackupSchedulePolicies(serverID string) (schedulePolicies []BackupSchedulePolicy, err error) {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return nil, err
+	}
+
+	requestURI := fmt.Sprintf("%s/server/%s/backup/client/schedulePolicy", organizationID, serverID)
+	response := &backupSchedulePolicies{}
+	_, err = client.getBackupResource(requestURI, response, "list backup schedule policies for server '%s'", serverID)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Items, nil
+}
+
+// AddServerBackupClient adds a backup client to a server.
+//
+// The backup agent must then be downloaded (from the returned download URL) and installed on the server.
+func (client *Client) AddServerBackupClient(serverID string, configuration BackupClientConfiguration) (addedClient *AddedBackupClient, err error) {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return nil, err
+	}
+
+	requestURI := fmt.Sprintf("%s/server/%s/backup/client", organizationID, serverID)
+	request, err := client.newRequestV1(requestURI, http.MethodPost, &newBackupClient{
+		Type:               configuration.Type,
+		StoragePolicyName:  configuration.StoragePolicyName,
+		SchedulePolicyName: configuration.SchedulePolicyName,
+		Alerting:           configuration.Alerting,
+	})
+	if err != nil {
+		return nil, err
+	}
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	apiResponse, err := readAPIResponseV1(responseBody, statusCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if apiResponse.Result != ResultSuccess {
+		return nil, apiResponse.ToError("Request to add backup client to server '%s' failed with status code %d (%s): %s", serverID, statusCode, apiResponse.ResultCode, apiResponse.Message)
+	}
+
+	clientID := apiResponse.GetAdditionalInformation("backupClient.id")
+	if clientID == nil {
+		return nil, apiResponse.ToError("Received an unexpected response (missing 'backupClient.id') with status code %d (%s): %s", statusCode, apiResponse.ResultCode, apiResponse.Message)
+	}
+
+	addedClient = &AddedBackupClient{
+		ID: *clientID,
+	}
+	downloadURL := apiResponse.GetAdditionalInformation("backupClient.downloadUrl")
+	if downloadURL != nil {
+		addedClient.DownloadURL = *downloadURL
+	}
+
+	return addedClient, nil
+}
+
+// ModifyServerBackupClient changes the storage policy, schedule policy, and / or alerting configuration for a server's backup client.
+//
+// configuration.Type is ignored.
+func (client *Client) ModifyServerBackupClient(serverID string, clientID string, configuration BackupClientConfiguration) error {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return err
+	}
+
+	requestURI := fmt.Sprintf("%s/server/%s/backup/client/%s/modify", organizationID, serverID, clientID)
+
+	return client.executeBackupOperation(requestURI, http.MethodPost, &modifyBackupClient{
+		StoragePolicyName:  configuration.StoragePolicyName,
+		SchedulePolicyName: configuration.SchedulePolicyName,
+		Alerting:           configuration.Alerting,
+	}, "modify backup client '%s'", clientID)
+}
+
+// RemoveServerBackupClient removes a backup client from a server.
+func (client *Client) RemoveServerBackupClient(serverID string, clientID string) error {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return err
+	}
+
+	requestURI := fmt.Sprintf("%s/server/%s/backup/client/%s?remove", organizationID, serverID, clientID)
+
+	return client.executeBackupOperation(requestURI, http.MethodGet, nil, "remove backup client '%s'", clientID)
+}
+
+// StartServerBackupJob initiates a backup job for a server's backup client.
+//
+// Use GetServerBackupDetails to monitor the progress of the job (BackupClientDetail.RunningJob).
+func (client *Client) StartServerBackupJob(serverID string, clientID string) error {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return err
+	}
+
+	requestURI := fmt.Sprintf("%s/server/%s/backup/client/%s?backup", organizationID, serverID, clientID)
+
+	return client.executeBackupOperation(requestURI, http.MethodGet, nil, "start backup job for backup client '%s'", clientID)
+}
+
+// CancelServerBackupJobs cancels all running backup jobs for a server's backup client.
+func (client *Client) CancelServerBackupJobs(serverID string, clientID string) error {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return err
+	}
+
+	requestURI := fmt.Sprintf("%s/server/%s/backup/client/%s?cancel", organizationID, serverID, clientID)
+
+	return client.executeBackupOperation(requestURI, http.MethodGet, nil, "cancel backup jobs for backup client '%s'", clientID)
+}
+
+// GetServerBackupJob retrieves the currently-running backup job (if any) for a server's backup client.
+// Returns nil if backup is not enabled for the server, the backup client does not exist, or no job is running.
+func (client *Client) GetServerBackupJob(serverID string, clientID string) (job *BackupJob, err error) {
+	details, err := client.GetServerBackupDetails(serverID)
+	if err != nil || details == nil {
+		return nil, err
+	}
+
+	backupClient := details.GetClient(clientID)
+	if backupClient == nil {
+		return nil, nil
+	}
+
+	return backupClient.RunningJob, nil
+}
+
+// Execute a Cloud Backup operation that returns an APIResponseV1.
+func (client *Client) executeBackupOperation(requestURI string, method string, body interface{}, operationDescriptionOrFormat string, formatArgs ...interface{}) error {
+	request, err := client.newRequestV1(requestURI, method, body)
+	if err != nil {
+		return err
+	}
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return err
+	}
+
+	apiResponse, err := readAPIResponseV1(responseBody, statusCode)
+	if err != nil {
+		return err
+	}
+
+	if apiResponse.Result != ResultSuccess {
+		operationDescription := fmt.Sprintf(operationDescriptionOrFormat, formatArgs...)
+
+		return apiResponse.ToError("Request to %s failed with status code %d (%s): %s", operationDescription, statusCode, apiResponse.ResultCode, apiResponse.Message)
+	}
+
+	return nil
+}
+
+// Retrieve a Cloud Backup resource (as XML).
+// Returns false if the resource was not found.
+func (client *Client) getBackupResource(requestURI string, resource interface{}, operationDescriptionOrFormat string, formatArgs ...interface{}) (found bool, err error) {
+	request, err := client.newRequestV1(requestURI, http.MethodGet, nil)
+	if err != nil {
+		return false, err
+	}
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return false, err
+	}
+
+	if statusCode != http.StatusOK {
+		var apiResponse *APIResponseV1
+
+		apiResponse, err = readAPIResponseV1(responseBody, statusCode)
+		if err != nil {
+			return false, err
+		}
+
+		if statusCode == http.StatusNotFound {
+			return false, nil // Not an error, but was not found.
+		}
+
+		operationDescription := fmt.Sprintf(operationDescriptionOrFormat, formatArgs...)
+
+		return false, apiResponse.ToError("Request to %s failed with status code %d (%s): %s", operationDescription, statusCode, apiResponse.ResultCode, apiResponse.Message)
+	}
+
+	err = xml.Unmarshal(responseBody, resource)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,166 @@
+package compute
+
+import (
+	"net/http"
+	"testing"
+)
+
+// Get server backup details (successful).
+func TestClient_GetServerBackupDetails_Success(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			details, err := client.GetServerBackupDetails("5a32d6e4-9707-4813-a269-56ab4d989f4d")
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.NotNil("ServerBackupDetails", details)
+			expect.EqualsString("ServerBackupDetails.AssetID", "d1d8c8e0-8d4a-4a70-a0e6-2c8d3a0f0c6b", details.AssetID)
+			expect.EqualsString("ServerBackupDetails.ServicePlan", BackupServicePlanEssentials, details.ServicePlan)
+			expect.EqualsInt("ServerBackupDetails.Clients.Length", 1, len(details.Clients))
+
+			backupClient := details.GetClient("30b1ff76-c76d-4d7c-b39d-3b72be0384c8")
+			expect.NotNil("BackupClient", backupClient)
+			expect.EqualsString("BackupClient.Type", "FA.Linux", backupClient.Type)
+			expect.IsTrue("BackupClient.IsFileSystem", backupClient.IsFileSystem)
+			expect.IsTrue("BackupClient.IsRegistered", backupClient.IsRegistered())
+			expect.EqualsString("BackupClient.StoragePolicyName", "14 Day Storage Policy", backupClient.StoragePolicyName)
+			expect.EqualsString("BackupClient.SchedulePolicyName", "12AM - 6AM", backupClient.SchedulePolicyName)
+			expect.EqualsString("BackupClient.DownloadURL", "https://backups-na.cloud-vpn.net/PCC/?assetId=d1d8c8e0&clientType=FA.Linux", backupClient.DownloadURL)
+
+			expect.NotNil("BackupClient.Alerting", backupClient.Alerting)
+			expect.EqualsString("BackupClient.Alerting.Trigger", BackupAlertTriggerOnFailure, backupClient.Alerting.Trigger)
+			expect.EqualsInt("BackupClient.Alerting.EmailAddresses.Length", 2, len(backupClient.Alerting.EmailAddresses))
+
+			expect.NotNil("BackupClient.RunningJob", backupClient.RunningJob)
+			expect.EqualsString("BackupClient.RunningJob.Status", "Running", backupClient.RunningJob.Status)
+			expect.EqualsInt("BackupClient.RunningJob.PercentageComplete", 42, backupClient.RunningJob.PercentageComplete)
+		},
+		Respond: testRespondOK(getServerBackupDetailsTestResponse),
+	})
+}
+
+// Get server backup details (backup not enabled).
+func TestClient_GetServerBackupDetails_NotFound(test *testing.T) {
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			details, err := client.GetServerBackupDetails("5a32d6e4-9707-4813-a269-56ab4d989f4d")
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			if details != nil {
+				test.Fatal("Expected nil ServerBackupDetails.")
+			}
+		},
+		Respond: testRespond(http.StatusNotFound, backupNotEnabledTestResponse),
+	})
+}
+
+// Enable server backup (failure).
+func TestClient_EnableServerBackup_Failure(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			err := client.EnableServerBackup("5a32d6e4-9707-4813-a269-56ab4d989f4d", BackupServicePlanEssentials)
+			expect.NotNil("EnableServerBackup error", err)
+
+			apiError, ok := err.(*APIError)
+			expect.IsTrue("EnableServerBackup error is an APIError", ok)
+			expect.EqualsString("APIError.Response.ResponseCode", "ERROR", apiError.Response.GetResponseCode())
+		},
+		Respond: testValidateXMLRequestAndRespond(http.StatusBadRequest, enableServerBackupFailureTestResponse, &newBackup{}, func(test *testing.T, requestBody interface{}) {
+			expect.EqualsString("NewBackup.ServicePlan", BackupServicePlanEssentials, requestBody.(*newBackup).ServicePlan)
+		}),
+	})
+}
+
+// Add server backup client (successful).
+func TestClient_AddServerBackupClient_Success(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			addedClient, err := client.AddServerBackupClient("5a32d6e4-9707-4813-a269-56ab4d989f4d", BackupClientConfiguration{
+				Type:               "FA.Linux",
+				StoragePolicyName:  "14 Day Storage Policy",
+				SchedulePolicyName: "12AM - 6AM",
+				Alerting: &BackupClientAlerting{
+					Trigger:        BackupAlertTriggerOnFailure,
+					EmailAddresses: []string{"ops@example.com"},
+				},
+			})
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.NotNil("AddedBackupClient", addedClient)
+			expect.EqualsString("AddedBackupClient.ID", "30b1ff76-c76d-4d7c-b39d-3b72be0384c8", addedClient.ID)
+			expect.EqualsString("AddedBackupClient.DownloadURL", "https://backups-na.cloud-vpn.net/PCC/?assetId=d1d8c8e0&clientType=FA.Linux", addedClient.DownloadURL)
+		},
+		Respond: testValidateXMLRequestAndRespondOK(addServerBackupClientTestResponse, &newBackupClient{}, func(test *testing.T, requestBody interface{}) {
+			request := requestBody.(*newBackupClient)
+			expect.EqualsString("NewBackupClient.Type", "FA.Linux", request.Type)
+			expect.EqualsString("NewBackupClient.StoragePolicyName", "14 Day Storage Policy", request.StoragePolicyName)
+			expect.EqualsString("NewBackupClient.SchedulePolicyName", "12AM - 6AM", request.SchedulePolicyName)
+			expect.NotNil("NewBackupClient.Alerting", request.Alerting)
+			expect.EqualsString("NewBackupClient.Alerting.Trigger", BackupAlertTriggerOnFailure, request.Alerting.Trigger)
+		}),
+	})
+}
+
+/*
+ * Test responses.
+ */
+
+const getServerBackupDetailsTestResponse = `
+	<BackupDetails xmlns="http://oec.api.opsource.net/schemas/backup" assetId="d1d8c8e0-8d4a-4a70-a0e6-2c8d3a0f0c6b" servicePlan="Essentials" state="NORMAL">
+		<backupClient id="30b1ff76-c76d-4d7c-b39d-3b72be0384c8" type="FA.Linux" isFileSystem="true" status="Active">
+			<description>Linux File system</description>
+			<schedulePolicyName>12AM - 6AM</schedulePolicyName>
+			<storagePolicyName>14 Day Storage Policy</storagePolicyName>
+			<alerting trigger="ON_FAILURE">
+				<emailAddress>ops@example.com</emailAddress>
+				<emailAddress>oncall@example.com</emailAddress>
+			</alerting>
+			<runningJob id="106882" name="Backup" status="Running" percentageComplete="42"/>
+			<downloadUrl>https://backups-na.cloud-vpn.net/PCC/?assetId=d1d8c8e0&amp;clientType=FA.Linux</downloadUrl>
+		</backupClient>
+	</BackupDetails>
+`
+
+const backupNotEnabledTestResponse = `
+	<Status>
+		<operation>Get Backup Details</operation>
+		<result>ERROR</result>
+		<resultDetail>Backup is not enabled for server '5a32d6e4-9707-4813-a269-56ab4d989f4d'.</resultDetail>
+		<resultCode>REASON_543</resultCode>
+	</Status>
+`
+
+const enableServerBackupFailureTestResponse = `
+	<Status>
+		<operation>Enable Backup for Server</operation>
+		<result>ERROR</result>
+		<resultDetail>Cloud backup is already enabled for Server '5a32d6e4-9707-4813-a269-56ab4d989f4d'.</resultDetail>
+		<resultCode>REASON_550</resultCode>
+	</Status>
+`
+
+const addServerBackupClientTestResponse = `
+	<Status>
+		<operation>Add Backup Client</operation>
+		<result>SUCCESS</result>
+		<resultDetail>Backup Client successfully added</resultDetail>
+		<resultCode>RESULT_0</resultCode>
+		<additionalInformation name="backupClient.id">
+			<value>30b1ff76-c76d-4d7c-b39d-3b72be0384c8</value>
+		</additionalInformation>
+		<additionalInformation name="backupClient.downloadUrl">
+			<value>https://backups-na.cloud-vpn.net/PCC/?assetId=d1d8c8e0&amp;clientType=FA.Linux</value>
+		</additionalInformation>
+	</Status>
+`
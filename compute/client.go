@@ -26,12 +26,15 @@ type Client struct {
 	httpClient               *http.Client
 	account                  *Account
 	isExtendedLoggingEnabled bool
+	credentialsLock          *sync.RWMutex
 }
 
 // NewClient creates a new cloud compute API client.
 // region is the cloud compute region identifier.
+//
+// If region is not one of the KnownRegions, its base address is assumed to be "https://api-<region>.dimensiondata.com" (use NewClientForRegion to reject unknown regions).
 func NewClient(region string, username string, password string) *Client {
-	baseAddress := fmt.Sprintf("https://api-%s.dimensiondata.com", region)
+	baseAddress := getRegionBaseAddress(region)
 
 	_, isExtendedLoggingEnabled := os.LookupEnv("DD_COMPUTE_EXTENDED_LOGGING")
 
@@ -45,9 +48,34 @@ func NewClient(region string, username string, password string) *Client {
 		&http.Client{},
 		nil,
 		isExtendedLoggingEnabled,
+		&sync.RWMutex{},
 	}
 }
 
+// NewClientForRegion creates a new cloud compute API client for one of the KnownRegions.
+// region is the cloud compute region identifier (e.g. "au").
+//
+// Returns an error if region is not one of the KnownRegions.
+func NewClientForRegion(region string, username string, password string) (*Client, error) {
+	err := ValidateRegion(region)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClient(region, username, password), nil
+}
+
+// NewClientWithCredentialsProvider creates a new cloud compute API client, using credentials from the specified CredentialsProvider.
+// region is the cloud compute region identifier.
+func NewClientWithCredentialsProvider(region string, credentialsProvider CredentialsProvider) (*Client, error) {
+	credentials, err := credentialsProvider.GetCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClient(region, credentials.Username, credentials.Password), nil
+}
+
 // Reset clears all cached data from the Client.
 func (client *Client) Reset() {
 	client.stateLock.Lock()
@@ -56,6 +84,46 @@ func (client *Client) Reset() {
 	client.account = nil
 }
 
+// Pre-cache account details for the client.
+func (client *Client) setAccount(account *Account) {
+	client.stateLock.Lock()
+	defer client.stateLock.Unlock()
+
+	client.account = account
+}
+
+// UpdateCredentials changes the credentials used by the Client.
+//
+// Cached data (such as account details) is cleared, since it may not apply to the new credentials.
+func (client *Client) UpdateCredentials(username string, password string) {
+	client.credentialsLock.Lock()
+	client.username = username
+	client.password = password
+	client.credentialsLock.Unlock()
+
+	client.Reset()
+}
+
+// RefreshCredentials retrieves credentials from the specified CredentialsProvider and uses them for subsequent requests (see UpdateCredentials).
+func (client *Client) RefreshCredentials(credentialsProvider CredentialsProvider) error {
+	credentials, err := credentialsProvider.GetCredentials()
+	if err != nil {
+		return err
+	}
+
+	client.UpdateCredentials(credentials.Username, credentials.Password)
+
+	return nil
+}
+
+// Get the credentials currently used by the client.
+func (client *Client) getCredentials() (username string, password string) {
+	client.credentialsLock.RLock()
+	defer client.credentialsLock.RUnlock()
+
+	return client.username, client.password
+}
+
 // EnableExtendedLogging enables logging of HTTP requests and responses.
 func (client *Client) EnableExtendedLogging() {
 	client.stateLock.Lock()
@@ -227,7 +295,7 @@ func (client *Client) newRequestV1(relativeURI string, method string, body inter
 		return nil, err
 	}
 
-	request.SetBasicAuth(client.username, client.password)
+	request.SetBasicAuth(client.getCredentials())
 	request.Header.Set("Accept", "text/xml")
 
 	if bodyReader != nil {
@@ -239,7 +307,18 @@ func (client *Client) newRequestV1(relativeURI string, method string, body inter
 
 // Create a basic request for the compute API (V2.2, JSON).
 func (client *Client) newRequestV22(relativeURI string, method string, body interface{}) (*http.Request, error) {
-	requestURI := fmt.Sprintf("%s/caas/2.2/%s", client.baseAddress, relativeURI)
+	return client.newRequestV2("2.2", relativeURI, method, body)
+}
+
+// Create a basic request for the compute API (V2.4, JSON).
+// Only used for operations that are not available in earlier versions of the API.
+func (client *Client) newRequestV24(relativeURI string, method string, body interface{}) (*http.Request, error) {
+	return client.newRequestV2("2.4", relativeURI, method, body)
+}
+
+// Create a basic request for the specified version of the compute API (V2.x, JSON).
+func (client *Client) newRequestV2(apiVersion string, relativeURI string, method string, body interface{}) (*http.Request, error) {
+	requestURI := fmt.Sprintf("%s/caas/%s/%s", client.baseAddress, apiVersion, relativeURI)
 
 	var (
 		request    *http.Request
@@ -257,7 +336,7 @@ func (client *Client) newRequestV22(relativeURI string, method string, body inte
 		return nil, err
 	}
 
-	request.SetBasicAuth(client.username, client.password)
+	request.SetBasicAuth(client.getCredentials())
 	request.Header.Add("Accept", "application/json")
 
 	if bodyReader != nil {
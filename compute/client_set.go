@@ -0,0 +1,170 @@
+package compute
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ClientSet manages a Client for each region, using the same credentials.
+//
+// Account details are only retrieved once, and are shared between all of the set's clients.
+type ClientSet struct {
+	username  string
+	password  string
+	stateLock *sync.Mutex
+	clients   map[string]*Client
+	account   *Account
+
+	// Creates a new Client for the specified region (overridden by tests).
+	newClient func(region string, username string, password string) *Client
+}
+
+// MultiRegionError is an error representing failures in one or more regions.
+type MultiRegionError struct {
+	// The errors (keyed by region Id).
+	Errors map[string]error
+}
+
+// Error returns the error message associated with the MultiRegionError.
+func (multiRegionError *MultiRegionError) Error() string {
+	regionIDs := make([]string, 0, len(multiRegionError.Errors))
+	for regionID := range multiRegionError.Errors {
+		regionIDs = append(regionIDs, regionID)
+	}
+	sort.Strings(regionIDs)
+
+	messages := make([]string, len(regionIDs))
+	for index, regionID := range regionIDs {
+		messages[index] = fmt.Sprintf("%s: %s", regionID, multiRegionError.Errors[regionID].Error())
+	}
+
+	return fmt.Sprintf("Operation failed in %d region(s): %s", len(regionIDs), strings.Join(messages, "; "))
+}
+
+var _ error = &MultiRegionError{}
+
+// NewClientSet creates a new ClientSet using the specified credentials.
+func NewClientSet(username string, password string) *ClientSet {
+	return &ClientSet{
+		username:  username,
+		password:  password,
+		stateLock: &sync.Mutex{},
+		clients:   make(map[string]*Client),
+		newClient: NewClient,
+	}
+}
+
+// GetClient retrieves (creating, if necessary) the Client for the specified region.
+//
+// Returns an error if region is not one of the KnownRegions.
+func (clientSet *ClientSet) GetClient(region string) (*Client, error) {
+	err := ValidateRegion(region)
+	if err != nil {
+		return nil, err
+	}
+	region = normalizeRegionID(region)
+
+	clientSet.stateLock.Lock()
+	client, ok := clientSet.clients[region]
+	account := clientSet.account
+	clientSet.stateLock.Unlock()
+
+	if ok {
+		return client, nil
+	}
+
+	// Account details are retrieved without holding the lock (so a slow region does not block lookups for other regions).
+	client = clientSet.newClient(region, clientSet.username, clientSet.password)
+	if account == nil {
+		account, err = client.GetAccount()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		client.setAccount(account)
+	}
+
+	clientSet.stateLock.Lock()
+	defer clientSet.stateLock.Unlock()
+
+	// Another caller may have created a client for this region (or retrieved the account details) in the meantime.
+	existingClient, ok := clientSet.clients[region]
+	if ok {
+		return existingClient, nil
+	}
+	if clientSet.account == nil {
+		clientSet.account = account
+	} else if clientSet.account != account {
+		client.setAccount(clientSet.account)
+	}
+	clientSet.clients[region] = client
+
+	return client, nil
+}
+
+// GetAccount retrieves the account details shared by the set's clients.
+// Returns nil if no client has been created yet.
+func (clientSet *ClientSet) GetAccount() *Account {
+	clientSet.stateLock.Lock()
+	defer clientSet.stateLock.Unlock()
+
+	return clientSet.account
+}
+
+// ForEachRegion concurrently calls the specified action with the Client for each of the specified regions (or all KnownRegions, if none are specified).
+//
+// Waits for all actions to complete; returns a *MultiRegionError if the action fails in one or more regions.
+func (clientSet *ClientSet) ForEachRegion(action func(region string, client *Client) error, regions ...string) error {
+	if len(regions) == 0 {
+		for _, knownRegion := range KnownRegions() {
+			regions = append(regions, knownRegion.ID)
+		}
+	}
+
+	var (
+		errorsLock = &sync.Mutex{}
+		errors     = make(map[string]error)
+		waitGroup  sync.WaitGroup
+	)
+	for _, region := range regions {
+		client, err := clientSet.GetClient(region)
+		if err != nil {
+			errorsLock.Lock()
+			errors[normalizeRegionID(region)] = err
+			errorsLock.Unlock()
+
+			continue
+		}
+
+		waitGroup.Add(1)
+		go func(region string, client *Client) {
+			defer waitGroup.Done()
+
+			err := action(region, client)
+			if err != nil {
+				errorsLock.Lock()
+				defer errorsLock.Unlock()
+
+				errors[region] = err
+			}
+		}(normalizeRegionID(region), client)
+	}
+	waitGroup.Wait()
+
+	if len(errors) > 0 {
+		return &MultiRegionError{Errors: errors}
+	}
+
+	return nil
+}
+
+// Reset discards all of the set's clients and clears the shared account details.
+func (clientSet *ClientSet) Reset() {
+	clientSet.stateLock.Lock()
+	defer clientSet.stateLock.Unlock()
+
+	clientSet.clients = make(map[string]*Client)
+	clientSet.account = nil
+}
@@ -0,0 +1,112 @@
+package compute
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// Region catalogue lookup and validation.
+func TestRegions(test *testing.T) {
+	expect := expect(test)
+
+	region := GetRegion("AU")
+	expect.NotNil("GetRegion(AU)", region)
+	expect.EqualsString("Region.BaseAddress", "https://api-au.dimensiondata.com", region.BaseAddress)
+
+	expect.IsTrue("IsKnownRegion(eu)", IsKnownRegion("eu"))
+	expect.IsFalse("IsKnownRegion(au1)", IsKnownRegion("au1"))
+	expect.IsTrue("ValidateRegion(mars) fails", ValidateRegion("mars") != nil)
+
+	regions := KnownRegions()
+	expect.IsTrue("KnownRegions is not empty", len(regions) > 0)
+	for index := 1; index < len(regions); index++ {
+		expect.IsTrue("KnownRegions is ordered by Id", regions[index-1].ID < regions[index].ID)
+	}
+
+	_, err := NewClientForRegion("mars", "user1", "password")
+	expect.IsTrue("NewClientForRegion(mars) fails", err != nil)
+}
+
+// Client set shares account details between regions.
+func TestClientSet_SharedAccount(test *testing.T) {
+	expect := expect(test)
+
+	var accountRequestCount int32
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		atomic.AddInt32(&accountRequestCount, 1)
+
+		writer.Header().Set("Content-Type", "text/xml")
+		writer.WriteHeader(http.StatusOK)
+
+		fmt.Fprintln(writer, accountTestResponse)
+	}))
+	defer testServer.Close()
+
+	clientSet := NewClientSet("user1", "password")
+	clientSet.newClient = func(region string, username string, password string) *Client {
+		client := NewClient(region, username, password)
+		client.setBaseAddress(testServer.URL)
+
+		return client
+	}
+
+	auClient, err := clientSet.GetClient("AU")
+	if err != nil {
+		test.Fatal(err)
+	}
+	euClient, err := clientSet.GetClient("eu")
+	if err != nil {
+		test.Fatal(err)
+	}
+	auClientAgain, err := clientSet.GetClient("au")
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.IsTrue("GetClient(au) returns the same client", auClient == auClientAgain)
+
+	auAccount, err := auClient.GetAccount()
+	if err != nil {
+		test.Fatal(err)
+	}
+	euAccount, err := euClient.GetAccount()
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.IsTrue("Account is shared", auAccount == euAccount)
+	expect.IsTrue("ClientSet.GetAccount", clientSet.GetAccount() == auAccount)
+	expect.EqualsInt("Account request count", 1, int(atomic.LoadInt32(&accountRequestCount)))
+
+	_, err = clientSet.GetClient("au1")
+	expect.IsTrue("GetClient(au1) fails", err != nil)
+}
+
+// Client set fans out across regions and collects errors.
+func TestClientSet_ForEachRegion(test *testing.T) {
+	expect := expect(test)
+
+	clientSet := NewClientSet("user1", "password")
+	clientSet.account = &Account{
+		OrganizationID: "dummy-organization-id",
+	}
+
+	var visitedCount int32
+	err := clientSet.ForEachRegion(func(region string, client *Client) error {
+		atomic.AddInt32(&visitedCount, 1)
+
+		if region == "eu" {
+			return fmt.Errorf("Simulated failure.")
+		}
+
+		return nil
+	}, "au", "EU", "na", "Mars")
+	expect.EqualsInt("Visited region count", 3, int(atomic.LoadInt32(&visitedCount)))
+
+	multiRegionError, ok := err.(*MultiRegionError)
+	expect.IsTrue("ForEachRegion error is a MultiRegionError", ok)
+	expect.EqualsInt("MultiRegionError.Errors size", 2, len(multiRegionError.Errors))
+	expect.NotNil("MultiRegionError.Errors[eu]", multiRegionError.Errors["eu"])
+	expect.NotNil("MultiRegionError.Errors[mars]", multiRegionError.Errors["mars"])
+}
@@ -23,14 +23,6 @@ func (client *Client) setBaseAddress(baseAddress string) error {
 	return nil
 }
 
-// Pre-cache account details for the client.
-func (client *Client) setAccount(account *Account) {
-	client.stateLock.Lock()
-	defer client.stateLock.Unlock()
-
-	client.account = account
-}
-
 func readRequestBodyAsString(request *http.Request) (string, error) {
 	if request.Body == nil {
 		return "", nil
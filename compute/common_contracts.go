@@ -1,6 +1,9 @@
 package compute
 
-import "fmt"
+import (
+	"fmt"
+	"net"
+)
 
 // NamedEntity represents a named Cloud Control entity.
 type NamedEntity interface {
@@ -35,6 +38,77 @@ func (network IPv4Range) ToDisplayString() string {
 	return fmt.Sprintf("%s/%d", network.BaseAddress, network.PrefixSize)
 }
 
+// ToIPNet converts the IPv4 range to a net.IPNet.
+func (network IPv4Range) ToIPNet() (*net.IPNet, error) {
+	baseAddress, err := parseIPv4Address(network.BaseAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if network.PrefixSize < 0 || network.PrefixSize > 32 {
+		return nil, fmt.Errorf("%d is not a valid IPv4 prefix size", network.PrefixSize)
+	}
+
+	mask := net.CIDRMask(network.PrefixSize, 32)
+
+	return &net.IPNet{
+		IP:   baseAddress.Mask(mask),
+		Mask: mask,
+	}, nil
+}
+
+// Contains determines whether the IPv4 range contains the specified address.
+func (network IPv4Range) Contains(address string) bool {
+	ipNet, err := network.ToIPNet()
+	if err != nil {
+		return false
+	}
+
+	ip, err := parseIPv4Address(address)
+	if err != nil {
+		return false
+	}
+
+	return ipNet.Contains(ip)
+}
+
+// GetNetworkAddress returns the IPv4 range's network address (the first address in the range).
+func (network IPv4Range) GetNetworkAddress() (string, error) {
+	ipNet, err := network.ToIPNet()
+	if err != nil {
+		return "", err
+	}
+
+	return ipNet.IP.String(), nil
+}
+
+// GetBroadcastAddress returns the IPv4 range's broadcast address (the last address in the range).
+func (network IPv4Range) GetBroadcastAddress() (string, error) {
+	ipNet, err := network.ToIPNet()
+	if err != nil {
+		return "", err
+	}
+
+	hostBits := uint(32 - network.PrefixSize)
+	broadcastAddress := ipv4ToUint32(ipNet.IP) | uint32((uint64(1)<<hostBits)-1)
+
+	return uint32ToIPv4(broadcastAddress).String(), nil
+}
+
+// Overlaps determines whether the IPv4 range overlaps with another IPv4 range.
+func (network IPv4Range) Overlaps(other IPv4Range) bool {
+	networkAddress, err := network.GetNetworkAddress()
+	if err != nil {
+		return false
+	}
+	otherNetworkAddress, err := other.GetNetworkAddress()
+	if err != nil {
+		return false
+	}
+
+	return network.Contains(otherNetworkAddress) || other.Contains(networkAddress)
+}
+
 // IPv6Range represents an IPv6 network (base address and prefix size)
 type IPv6Range struct {
 	// The network base address.
@@ -48,6 +122,26 @@ func (network IPv6Range) ToDisplayString() string {
 	return fmt.Sprintf("%s/%d", network.BaseAddress, network.PrefixSize)
 }
 
+// Contains determines whether the IPv6 range contains the specified address.
+func (network IPv6Range) Contains(address string) bool {
+	baseAddress := net.ParseIP(network.BaseAddress)
+	if baseAddress == nil || network.PrefixSize < 0 || network.PrefixSize > 128 {
+		return false
+	}
+
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+
+	ipNet := &net.IPNet{
+		IP:   baseAddress.Mask(net.CIDRMask(network.PrefixSize, 128)),
+		Mask: net.CIDRMask(network.PrefixSize, 128),
+	}
+
+	return ipNet.Contains(ip)
+}
+
 // OperatingSystem represents a well-known operating system for virtual machines.
 type OperatingSystem struct {
 	// The operating system Id.
@@ -90,6 +184,9 @@ type VirtualMachineNetworkAdapter struct {
 	VLANName           *string `json:"vlanName,omitempty"`
 	PrivateIPv4Address *string `json:"privateIpv4,omitempty"`
 	PrivateIPv6Address *string `json:"ipv6,omitempty"`
+	MACAddress         *string `json:"macAddress,omitempty"`
+	AdapterType        *string `json:"networkAdapter,omitempty"`
+	IsConnected        *bool   `json:"connected,omitempty"`
 	State              *string `json:"state,omitempty"`
 }
 
@@ -0,0 +1,246 @@
+package compute
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// EnvironmentVariableUsername is the name of the environment variable containing the CloudControl user name.
+	EnvironmentVariableUsername = "DD_COMPUTE_USER"
+
+	// EnvironmentVariablePassword is the name of the environment variable containing the CloudControl password.
+	EnvironmentVariablePassword = "DD_COMPUTE_PASSWORD"
+
+	// EnvironmentVariableProfile is the name of the environment variable containing the name of the credentials profile to use.
+	EnvironmentVariableProfile = "DD_COMPUTE_PROFILE"
+
+	// EnvironmentVariableCredentialsFile is the name of the environment variable containing the path of the credentials file to use.
+	EnvironmentVariableCredentialsFile = "DD_COMPUTE_CREDENTIALS_FILE"
+
+	// DefaultCredentialsProfile is the name of the credentials profile used if no profile is specified.
+	DefaultCredentialsProfile = "default"
+)
+
+// Credentials represents the credentials used to authenticate to the CloudControl API.
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// CredentialsProvider represents a source of credentials for the CloudControl API.
+type CredentialsProvider interface {
+	// GetCredentials retrieves credentials from the provider.
+	GetCredentials() (*Credentials, error)
+}
+
+// StaticCredentialsProvider is a CredentialsProvider that always supplies the same credentials.
+type StaticCredentialsProvider struct {
+	Credentials Credentials
+}
+
+// GetCredentials retrieves credentials from the provider.
+func (provider *StaticCredentialsProvider) GetCredentials() (*Credentials, error) {
+	credentials := provider.Credentials
+
+	return &credentials, nil
+}
+
+var _ CredentialsProvider = &StaticCredentialsProvider{}
+
+// EnvironmentCredentialsProvider is a CredentialsProvider that supplies credentials from environment variables.
+type EnvironmentCredentialsProvider struct {
+	// The name of the environment variable containing the user name.
+	UsernameVariable string
+
+	// The name of the environment variable containing the password.
+	PasswordVariable string
+}
+
+// NewEnvironmentCredentialsProvider creates a new EnvironmentCredentialsProvider that uses the default environment variables (EnvironmentVariableUsername and EnvironmentVariablePassword).
+func NewEnvironmentCredentialsProvider() *EnvironmentCredentialsProvider {
+	return &EnvironmentCredentialsProvider{
+		UsernameVariable: EnvironmentVariableUsername,
+		PasswordVariable: EnvironmentVariablePassword,
+	}
+}
+
+// GetCredentials retrieves credentials from the provider.
+func (provider *EnvironmentCredentialsProvider) GetCredentials() (*Credentials, error) {
+	username := os.Getenv(provider.UsernameVariable)
+	if len(username) == 0 {
+		return nil, fmt.Errorf("Environment variable '%s' is not set.", provider.UsernameVariable)
+	}
+
+	password := os.Getenv(provider.PasswordVariable)
+	if len(password) == 0 {
+		return nil, fmt.Errorf("Environment variable '%s' is not set.", provider.PasswordVariable)
+	}
+
+	return &Credentials{
+		Username: username,
+		Password: password,
+	}, nil
+}
+
+var _ CredentialsProvider = &EnvironmentCredentialsProvider{}
+
+// ProfileCredentialsProvider is a CredentialsProvider that supplies credentials from a named profile in a credentials file.
+//
+// The credentials file contains one section per profile, for example:
+//
+//	[default]
+//	username = user1
+//	password = password1
+//
+//	[production]
+//	username = user2
+//	password = password2
+//
+// Blank lines, and lines starting with "#" or ";", are ignored.
+type ProfileCredentialsProvider struct {
+	// The path of the credentials file.
+	FilePath string
+
+	// The name of the profile to use.
+	ProfileName string
+}
+
+// NewProfileCredentialsProvider creates a new ProfileCredentialsProvider for the specified profile.
+//
+// If profileName is empty, the profile named by EnvironmentVariableProfile is used (or DefaultCredentialsProfile, if that variable is not set).
+// The credentials file is named by EnvironmentVariableCredentialsFile (or, if that variable is not set, is "~/.ddcloud/credentials").
+func NewProfileCredentialsProvider(profileName string) *ProfileCredentialsProvider {
+	if len(profileName) == 0 {
+		profileName = os.Getenv(EnvironmentVariableProfile)
+	}
+	if len(profileName) == 0 {
+		profileName = DefaultCredentialsProfile
+	}
+
+	filePath := os.Getenv(EnvironmentVariableCredentialsFile)
+	if len(filePath) == 0 {
+		filePath = filepath.Join(getHomeDirectory(), ".ddcloud", "credentials")
+	}
+
+	return &ProfileCredentialsProvider{
+		FilePath:    filePath,
+		ProfileName: profileName,
+	}
+}
+
+// GetCredentials retrieves credentials from the provider.
+func (provider *ProfileCredentialsProvider) GetCredentials() (*Credentials, error) {
+	file, err := os.Open(provider.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to open credentials file '%s': %s", provider.FilePath, err.Error())
+	}
+	defer file.Close()
+
+	var (
+		credentials   *Credentials
+		inProfile     bool
+		currentLineNo int
+	)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		currentLineNo++
+
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			inProfile = strings.TrimSpace(line[1:len(line)-1]) == provider.ProfileName
+			if inProfile && credentials == nil {
+				credentials = &Credentials{}
+			}
+
+			continue
+		}
+
+		if !inProfile {
+			continue
+		}
+
+		separatorIndex := strings.Index(line, "=")
+		if separatorIndex == -1 {
+			return nil, fmt.Errorf("Invalid entry on line %d of credentials file '%s' (expected 'name = value').", currentLineNo, provider.FilePath)
+		}
+
+		name := strings.ToLower(strings.TrimSpace(line[:separatorIndex]))
+		value := strings.TrimSpace(line[separatorIndex+1:])
+		switch name {
+		case "username", "user":
+			credentials.Username = value
+		case "password":
+			credentials.Password = value
+		}
+	}
+	err = scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read credentials file '%s': %s", provider.FilePath, err.Error())
+	}
+
+	if credentials == nil {
+		return nil, fmt.Errorf("Profile '%s' was not found in credentials file '%s'.", provider.ProfileName, provider.FilePath)
+	}
+	if len(credentials.Username) == 0 || len(credentials.Password) == 0 {
+		return nil, fmt.Errorf("Profile '%s' in credentials file '%s' must specify both username and password.", provider.ProfileName, provider.FilePath)
+	}
+
+	return credentials, nil
+}
+
+var _ CredentialsProvider = &ProfileCredentialsProvider{}
+
+// ChainedCredentialsProvider is a CredentialsProvider that supplies credentials from the first of its providers that can supply them.
+type ChainedCredentialsProvider struct {
+	Providers []CredentialsProvider
+}
+
+// NewChainedCredentialsProvider creates a new ChainedCredentialsProvider that tries each of the specified providers (in order).
+func NewChainedCredentialsProvider(providers ...CredentialsProvider) *ChainedCredentialsProvider {
+	return &ChainedCredentialsProvider{
+		Providers: providers,
+	}
+}
+
+// NewDefaultCredentialsProvider creates a CredentialsProvider that tries environment variables, and then the credentials file (using the default profile).
+func NewDefaultCredentialsProvider() CredentialsProvider {
+	return NewChainedCredentialsProvider(
+		NewEnvironmentCredentialsProvider(),
+		NewProfileCredentialsProvider(""),
+	)
+}
+
+// GetCredentials retrieves credentials from the provider.
+func (provider *ChainedCredentialsProvider) GetCredentials() (*Credentials, error) {
+	var messages []string
+	for _, innerProvider := range provider.Providers {
+		credentials, err := innerProvider.GetCredentials()
+		if err == nil {
+			return credentials, nil
+		}
+
+		messages = append(messages, err.Error())
+	}
+
+	return nil, fmt.Errorf("No credentials were found (%s)", strings.Join(messages, " "))
+}
+
+var _ CredentialsProvider = &ChainedCredentialsProvider{}
+
+// Get the current user's home directory.
+func getHomeDirectory() string {
+	homeDirectory := os.Getenv("HOME")
+	if len(homeDirectory) == 0 {
+		homeDirectory = os.Getenv("USERPROFILE") // Windows
+	}
+
+	return homeDirectory
+}
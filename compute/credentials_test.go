@@ -0,0 +1,150 @@
+package compute
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+// Credentials from environment variables.
+func TestEnvironmentCredentialsProvider(test *testing.T) {
+	expect := expect(test)
+
+	provider := &EnvironmentCredentialsProvider{
+		UsernameVariable: "DD_COMPUTE_TEST_USER",
+		PasswordVariable: "DD_COMPUTE_TEST_PASSWORD",
+	}
+	os.Setenv("DD_COMPUTE_TEST_USER", "user1")
+	os.Unsetenv("DD_COMPUTE_TEST_PASSWORD")
+	defer os.Unsetenv("DD_COMPUTE_TEST_USER")
+
+	_, err := provider.GetCredentials()
+	expect.IsTrue("GetCredentials fails when password is not set", err != nil)
+
+	os.Setenv("DD_COMPUTE_TEST_PASSWORD", "password1")
+	defer os.Unsetenv("DD_COMPUTE_TEST_PASSWORD")
+
+	credentials, err := provider.GetCredentials()
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.EqualsString("Credentials.Username", "user1", credentials.Username)
+	expect.EqualsString("Credentials.Password", "password1", credentials.Password)
+}
+
+// Credentials from a named profile in a credentials file.
+func TestProfileCredentialsProvider(test *testing.T) {
+	expect := expect(test)
+
+	credentialsFile, err := ioutil.TempFile("", "ddcloud-credentials")
+	if err != nil {
+		test.Fatal(err)
+	}
+	defer os.Remove(credentialsFile.Name())
+
+	fmt.Fprint(credentialsFile, credentialsFileTestContent)
+	credentialsFile.Close()
+
+	provider := &ProfileCredentialsProvider{
+		FilePath:    credentialsFile.Name(),
+		ProfileName: "production",
+	}
+	credentials, err := provider.GetCredentials()
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.EqualsString("Credentials.Username", "user2", credentials.Username)
+	expect.EqualsString("Credentials.Password", "pass = word2", credentials.Password)
+
+	provider.ProfileName = "staging"
+	_, err = provider.GetCredentials()
+	expect.IsTrue("GetCredentials fails for unknown profile", err != nil)
+
+	provider.ProfileName = "incomplete"
+	_, err = provider.GetCredentials()
+	expect.IsTrue("GetCredentials fails for incomplete profile", err != nil)
+}
+
+// Chained credentials provider uses the first provider that succeeds.
+func TestChainedCredentialsProvider(test *testing.T) {
+	expect := expect(test)
+
+	provider := NewChainedCredentialsProvider(
+		&EnvironmentCredentialsProvider{
+			UsernameVariable: "DD_COMPUTE_TEST_MISSING_USER",
+			PasswordVariable: "DD_COMPUTE_TEST_MISSING_PASSWORD",
+		},
+		&StaticCredentialsProvider{
+			Credentials: Credentials{Username: "user3", Password: "password3"},
+		},
+	)
+	credentials, err := provider.GetCredentials()
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.EqualsString("Credentials.Username", "user3", credentials.Username)
+
+	_, err = NewChainedCredentialsProvider().GetCredentials()
+	expect.IsTrue("GetCredentials fails for empty chain", err != nil)
+}
+
+// Rotating credentials on a live client.
+func TestClient_RefreshCredentials(test *testing.T) {
+	expect := expect(test)
+
+	var lastUsername string
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		lastUsername, _, _ = request.BasicAuth()
+
+		writer.Header().Set("Content-Type", "text/xml")
+		writer.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(writer, accountTestResponse)
+	}))
+	defer testServer.Close()
+
+	client, err := NewClientWithCredentialsProvider("au", &StaticCredentialsProvider{
+		Credentials: Credentials{Username: "user1", Password: "password1"},
+	})
+	if err != nil {
+		test.Fatal(err)
+	}
+	client.setBaseAddress(testServer.URL)
+
+	_, err = client.GetAccount()
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.EqualsString("Request.Username", "user1", lastUsername)
+
+	err = client.RefreshCredentials(&StaticCredentialsProvider{
+		Credentials: Credentials{Username: "user2", Password: "password2"},
+	})
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	// Cached account details must have been cleared.
+	_, err = client.GetAccount()
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.EqualsString("Request.Username", "user2", lastUsername)
+}
+
+const credentialsFileTestContent = `
+# Test credentials
+[default]
+username = user1
+password = password1
+
+[production]
+username = user2
+password = pass = word2
+
+[incomplete]
+username = user3
+`
@@ -0,0 +1,336 @@
+package compute
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	// DatacenterPropertyMaxVLANsPerNetworkDomain is the name of the networking property representing the maximum number of VLANs in a network domain.
+	DatacenterPropertyMaxVLANsPerNetworkDomain = "MAX_VLANS_PER_NETWORK_DOMAIN"
+
+	// DatacenterPropertyMinVLANPrefixSize is the name of the networking property representing the smallest permitted VLAN prefix size (i.e. the largest VLAN).
+	DatacenterPropertyMinVLANPrefixSize = "MIN_VLAN_PREFIX_SIZE"
+
+	// DatacenterPropertyMaxVLANPrefixSize is the name of the networking property representing the largest permitted VLAN prefix size (i.e. the smallest VLAN).
+	DatacenterPropertyMaxVLANPrefixSize = "MAX_VLAN_PREFIX_SIZE"
+
+	// DatacenterPropertyMinDiskSizeGB is the name of the hypervisor property representing the minimum size (in GB) of a server disk.
+	DatacenterPropertyMinDiskSizeGB = "MIN_DISK_SIZE_GB"
+
+	// DatacenterPropertyMaxDiskSizeGB is the name of the hypervisor property representing the maximum size (in GB) of a server disk.
+	DatacenterPropertyMaxDiskSizeGB = "MAX_DISK_SIZE_GB"
+
+	// DatacenterPropertyMaxCPUCount is the name of the hypervisor property representing the maximum number of CPUs for a server.
+	DatacenterPropertyMaxCPUCount = "MAX_CPU_COUNT"
+
+	// DatacenterPropertyMaxMemoryGB is the name of the hypervisor property representing the maximum memory (in GB) for a server.
+	DatacenterPropertyMaxMemoryGB = "MAX_MEMORY_GB"
+)
+
+// Datacenter represents a CloudControl datacenter (MCP).
+type Datacenter struct {
+	// The datacenter Id (e.g. "AU9").
+	ID string `json:"id"`
+
+	// The datacenter display name.
+	DisplayName string `json:"displayName"`
+
+	// The city where the datacenter is located.
+	City string `json:"city"`
+
+	// The state where the datacenter is located.
+	State string `json:"state"`
+
+	// The country where the datacenter is located.
+	Country string `json:"country"`
+
+	// The URL of the datacenter's VPN endpoint.
+	VPNURL string `json:"vpnUrl"`
+
+	// The host name of the datacenter's FTPS endpoint (for image import / export).
+	FTPSHost string `json:"ftpsHost"`
+
+	// The datacenter's networking capabilities.
+	Networking DatacenterNetworking `json:"networking"`
+
+	// The datacenter's hypervisor capabilities.
+	Hypervisor DatacenterHypervisor `json:"hypervisor"`
+
+	// The datacenter's Cloud Backup capabilities (nil if Cloud Backup is not available).
+	Backup *DatacenterService `json:"backup,omitempty"`
+
+	// The datacenter's server monitoring capabilities (nil if monitoring is not available).
+	Monitoring *DatacenterService `json:"monitoring,omitempty"`
+}
+
+// DatacenterNetworking represents the networking capabilities of a datacenter.
+type DatacenterNetworking struct {
+	// The networking type.
+	Type string `json:"type"`
+
+	// The networking maintenance status.
+	MaintenanceStatus string `json:"maintenanceStatus"`
+
+	// The types of network domain supported by the datacenter.
+	NetworkDomainTypes []NetworkDomainType `json:"networkDomainType"`
+
+	// Additional networking properties (e.g. DatacenterPropertyMaxVLANsPerNetworkDomain).
+	Properties DatacenterProperties `json:"property"`
+}
+
+// DatacenterHypervisor represents the hypervisor capabilities of a datacenter.
+type DatacenterHypervisor struct {
+	// The hypervisor type (e.g. "VMWARE").
+	Type string `json:"type"`
+
+	// The hypervisor maintenance status.
+	MaintenanceStatus string `json:"maintenanceStatus"`
+
+	// The disk speeds supported by the datacenter.
+	DiskSpeeds []DatacenterSpeed `json:"diskSpeed"`
+
+	// The CPU speeds supported by the datacenter.
+	CPUSpeeds []DatacenterSpeed `json:"cpuSpeed"`
+
+	// Additional hypervisor properties (e.g. DatacenterPropertyMaxCPUCount).
+	Properties DatacenterProperties `json:"property"`
+}
+
+// DatacenterSpeed represents a disk or CPU speed supported by a datacenter.
+type DatacenterSpeed struct {
+	// The speed Id (e.g. ServerDiskSpeedStandard).
+	ID string `json:"id"`
+
+	// The speed display name.
+	DisplayName string `json:"displayName"`
+
+	// The speed abbreviation.
+	Abbreviation string `json:"abbreviation"`
+
+	// The speed description.
+	Description string `json:"description"`
+
+	// Is this the default speed?
+	IsDefault bool `json:"default"`
+
+	// Is this speed currently available?
+	IsAvailable bool `json:"available"`
+}
+
+// DatacenterService represents an optional service (such as Cloud Backup or monitoring) available in a datacenter.
+type DatacenterService struct {
+	// The service type.
+	Type string `json:"type"`
+
+	// The service maintenance status.
+	MaintenanceStatus string `json:"maintenanceStatus"`
+
+	// The names of the service plans available in the datacenter.
+	ServicePlans []string `json:"servicePlan"`
+}
+
+// DatacenterProperty represents a named property of a datacenter capability.
+type DatacenterProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// DatacenterProperties represents a set of named properties of a datacenter capability.
+type DatacenterProperties []DatacenterProperty
+
+// Get retrieves the value of the property with the specified name.
+// Returns nil if no property is found with the specified name.
+func (properties DatacenterProperties) Get(name string) *string {
+	for _, property := range properties {
+		if property.Name == name {
+			value := property.Value
+
+			return &value
+		}
+	}
+
+	return nil
+}
+
+// GetInt retrieves the value of the property with the specified name as an integer.
+// Returns nil if no property is found with the specified name (or if its value is not an integer).
+func (properties DatacenterProperties) GetInt(name string) *int {
+	value := properties.Get(name)
+	if value == nil {
+		return nil
+	}
+
+	intValue, err := strconv.Atoi(*value)
+	if err != nil {
+		return nil
+	}
+
+	return &intValue
+}
+
+// Datacenters represents a page of Datacenter results.
+type Datacenters struct {
+	Items []Datacenter `json:"datacenter"`
+
+	PagedResult
+}
+
+// SupportsDiskSpeed determines whether the datacenter supports (and has available) the specified disk speed.
+func (datacenter *Datacenter) SupportsDiskSpeed(speed string) bool {
+	return isSpeedAvailable(datacenter.Hypervisor.DiskSpeeds, speed)
+}
+
+// SupportsCPUSpeed determines whether the datacenter supports (and has available) the specified CPU speed.
+func (datacenter *Datacenter) SupportsCPUSpeed(speed string) bool {
+	return isSpeedAvailable(datacenter.Hypervisor.CPUSpeeds, speed)
+}
+
+// SupportsNetworkDomainType determines whether the datacenter supports the specified type of network domain.
+func (datacenter *Datacenter) SupportsNetworkDomainType(networkDomainType NetworkDomainType) bool {
+	for _, supportedType := range datacenter.Networking.NetworkDomainTypes {
+		if supportedType == networkDomainType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsBackupAvailable determines whether Cloud Backup is available in the datacenter.
+func (datacenter *Datacenter) IsBackupAvailable() bool {
+	return datacenter.Backup != nil
+}
+
+// IsMonitoringAvailable determines whether server monitoring is available in the datacenter.
+func (datacenter *Datacenter) IsMonitoringAvailable() bool {
+	return datacenter.Monitoring != nil
+}
+
+// GetMaxVLANsPerNetworkDomain retrieves the maximum number of VLANs in a network domain for the datacenter.
+// Returns nil if the datacenter does not specify a limit.
+func (datacenter *Datacenter) GetMaxVLANsPerNetworkDomain() *int {
+	return datacenter.Networking.Properties.GetInt(DatacenterPropertyMaxVLANsPerNetworkDomain)
+}
+
+// ValidateServerDeploymentConfiguration performs validation of a ServerDeploymentConfiguration against the datacenter's capabilities (disk and CPU speeds, and hypervisor limits).
+//
+// Returns a *ValidationError if any problems are detected.
+func (datacenter *Datacenter) ValidateServerDeploymentConfiguration(config ServerDeploymentConfiguration) error {
+	validationError := &ValidationError{
+		Target: fmt.Sprintf("Deployment configuration for server '%s' in datacenter '%s'", config.Name, datacenter.ID),
+	}
+
+	if len(config.CPU.Speed) > 0 && !datacenter.SupportsCPUSpeed(config.CPU.Speed) {
+		validationError.addMessage("CPU speed '%s' is not available in datacenter '%s'", config.CPU.Speed, datacenter.ID)
+	}
+
+	maxCPUCount := datacenter.Hypervisor.Properties.GetInt(DatacenterPropertyMaxCPUCount)
+	if maxCPUCount != nil && config.CPU.Count > *maxCPUCount {
+		validationError.addMessage("CPU count %d exceeds the maximum (%d) for datacenter '%s'", config.CPU.Count, *maxCPUCount, datacenter.ID)
+	}
+
+	maxMemoryGB := datacenter.Hypervisor.Properties.GetInt(DatacenterPropertyMaxMemoryGB)
+	if maxMemoryGB != nil && config.MemoryGB > *maxMemoryGB {
+		validationError.addMessage("memory size %dGB exceeds the maximum (%dGB) for datacenter '%s'", config.MemoryGB, *maxMemoryGB, datacenter.ID)
+	}
+
+	minDiskSizeGB := datacenter.Hypervisor.Properties.GetInt(DatacenterPropertyMinDiskSizeGB)
+	maxDiskSizeGB := datacenter.Hypervisor.Properties.GetInt(DatacenterPropertyMaxDiskSizeGB)
+	for _, disk := range config.Disks {
+		if !datacenter.SupportsDiskSpeed(disk.Speed) {
+			validationError.addMessage("disk with SCSI unit Id %d has speed '%s', which is not available in datacenter '%s'", disk.SCSIUnitID, disk.Speed, datacenter.ID)
+		}
+
+		// A size of 0 means "use the image default".
+		if disk.SizeGB == 0 {
+			continue
+		}
+		if minDiskSizeGB != nil && disk.SizeGB < *minDiskSizeGB {
+			validationError.addMessage("disk with SCSI unit Id %d is smaller (%dGB) than the minimum (%dGB) for datacenter '%s'", disk.SCSIUnitID, disk.SizeGB, *minDiskSizeGB, datacenter.ID)
+		}
+		if maxDiskSizeGB != nil && disk.SizeGB > *maxDiskSizeGB {
+			validationError.addMessage("disk with SCSI unit Id %d is larger (%dGB) than the maximum (%dGB) for datacenter '%s'", disk.SCSIUnitID, disk.SizeGB, *maxDiskSizeGB, datacenter.ID)
+		}
+	}
+
+	return validationError.toError()
+}
+
+// GetDatacenter retrieves the datacenter with the specified Id.
+// Returns nil if no datacenter is found with the specified Id.
+func (client *Client) GetDatacenter(id string) (datacenter *Datacenter, err error) {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return nil, err
+	}
+
+	requestURI := fmt.Sprintf("%s/infrastructure/datacenter?id=%s", organizationID, url.QueryEscape(id))
+	datacenters, err := client.listDatacenters(requestURI)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(datacenters.Items) == 0 {
+		return nil, nil
+	}
+
+	return &datacenters.Items[0], nil
+}
+
+// ListDatacenters retrieves a page of the datacenters available to the organisation.
+func (client *Client) ListDatacenters(paging *Paging) (datacenters *Datacenters, err error) {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return nil, err
+	}
+
+	requestURI := fmt.Sprintf("%s/infrastructure/datacenter?%s", organizationID, paging.EnsurePaging().toQueryParameters())
+
+	return client.listDatacenters(requestURI)
+}
+
+// Retrieve a page of datacenters.
+func (client *Client) listDatacenters(requestURI string) (datacenters *Datacenters, err error) {
+	request, err := client.newRequestV22(requestURI, http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode != http.StatusOK {
+		var apiResponse *APIResponseV2
+
+		apiResponse, err = readAPIResponseAsJSON(responseBody, statusCode)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, apiResponse.ToError("Request to list datacenters failed with status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
+	}
+
+	datacenters = &Datacenters{}
+	err = json.Unmarshal(responseBody, datacenters)
+	if err != nil {
+		return nil, err
+	}
+
+	return datacenters, nil
+}
+
+// Determine whether the specified speed is present (and available) in the list of speeds.
+func isSpeedAvailable(speeds []DatacenterSpeed, speedID string) bool {
+	for _, speed := range speeds {
+		if speed.ID == speedID {
+			return speed.IsAvailable
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,135 @@
+package compute
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// Get datacenter by Id (successful).
+func TestClient_GetDatacenter_Success(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			datacenter, err := client.GetDatacenter("AU9")
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.NotNil("Datacenter", datacenter)
+			expect.EqualsString("Datacenter.ID", "AU9", datacenter.ID)
+			expect.EqualsString("Datacenter.DisplayName", "Australia - Sydney - MCP 2.0", datacenter.DisplayName)
+			expect.EqualsString("Datacenter.City", "Sydney", datacenter.City)
+			expect.EqualsString("Datacenter.Hypervisor.Type", "VMWARE", datacenter.Hypervisor.Type)
+
+			expect.IsTrue("Datacenter.SupportsDiskSpeed(STANDARD)", datacenter.SupportsDiskSpeed(ServerDiskSpeedStandard))
+			expect.IsFalse("Datacenter.SupportsDiskSpeed(ECONOMY)", datacenter.SupportsDiskSpeed(ServerDiskSpeedEconomy)) // Present but unavailable.
+			expect.IsTrue("Datacenter.SupportsCPUSpeed(HIGHPERFORMANCE)", datacenter.SupportsCPUSpeed(ServerCPUSpeedHighPerformance))
+			expect.IsTrue("Datacenter.SupportsNetworkDomainType(ADVANCED)", datacenter.SupportsNetworkDomainType("ADVANCED"))
+			expect.IsFalse("Datacenter.SupportsNetworkDomainType(ENTERPRISE)", datacenter.SupportsNetworkDomainType("ENTERPRISE"))
+			expect.IsTrue("Datacenter.IsBackupAvailable", datacenter.IsBackupAvailable())
+			expect.IsFalse("Datacenter.IsMonitoringAvailable", datacenter.IsMonitoringAvailable())
+
+			maxVLANs := datacenter.GetMaxVLANsPerNetworkDomain()
+			expect.NotNil("Datacenter.GetMaxVLANsPerNetworkDomain", maxVLANs)
+			expect.EqualsInt("Datacenter.GetMaxVLANsPerNetworkDomain", 50, *maxVLANs)
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			expect.EqualsString("Request.URL.Path", "/caas/2.2/my-organization-id/infrastructure/datacenter", request.URL.Path)
+			expect.EqualsString("Request.URL.Query.id", "AU9", request.URL.Query().Get("id"))
+
+			return http.StatusOK, getDatacenterTestResponse
+		},
+	})
+}
+
+// Validate server deployment configuration against datacenter capabilities.
+func TestDatacenter_ValidateServerDeploymentConfiguration(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			datacenter, err := client.GetDatacenter("AU9")
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			config := createValidServerDeploymentConfiguration()
+			err = datacenter.ValidateServerDeploymentConfiguration(config)
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			config.Disks = append(config.Disks,
+				VirtualMachineDisk{SCSIUnitID: 2, SizeGB: 2000, Speed: ServerDiskSpeedEconomy},
+			)
+			err = datacenter.ValidateServerDeploymentConfiguration(config)
+			expect.NotNil("ValidateServerDeploymentConfiguration error", err)
+
+			validationError := err.(*ValidationError)
+			expect.EqualsInt("ValidationError.Messages size", 2, len(validationError.Messages))
+			expect.IsTrue("Disk speed message", strings.Contains(validationError.Messages[0], "speed 'ECONOMY'"))
+			expect.IsTrue("Disk size message", strings.Contains(validationError.Messages[1], "larger (2000GB)"))
+		},
+		Respond: testRespondOK(getDatacenterTestResponse),
+	})
+}
+
+/*
+ * Test responses.
+ */
+
+const getDatacenterTestResponse = `
+	{
+		"datacenter": [
+			{
+				"id": "AU9",
+				"displayName": "Australia - Sydney - MCP 2.0",
+				"city": "Sydney",
+				"state": "NSW",
+				"country": "AU",
+				"vpnUrl": "https://au9.cloud-vpn.net",
+				"ftpsHost": "ftps-au9.cloud-vpn.net",
+				"networking": {
+					"type": "2",
+					"maintenanceStatus": "NORMAL",
+					"networkDomainType": [ "ESSENTIALS", "ADVANCED" ],
+					"property": [
+						{ "name": "MAX_VLANS_PER_NETWORK_DOMAIN", "value": "50" },
+						{ "name": "MIN_VLAN_PREFIX_SIZE", "value": "16" },
+						{ "name": "MAX_VLAN_PREFIX_SIZE", "value": "29" }
+					]
+				},
+				"hypervisor": {
+					"type": "VMWARE",
+					"maintenanceStatus": "NORMAL",
+					"diskSpeed": [
+						{ "id": "STANDARD", "displayName": "Standard", "abbreviation": "S", "description": "Standard Speed", "default": true, "available": true },
+						{ "id": "HIGHPERFORMANCE", "displayName": "High Performance", "abbreviation": "H", "description": "High Performance", "default": false, "available": true },
+						{ "id": "ECONOMY", "displayName": "Economy", "abbreviation": "E", "description": "Economy Speed", "default": false, "available": false }
+					],
+					"cpuSpeed": [
+						{ "id": "STANDARD", "displayName": "Standard", "description": "Standard CPU", "default": true, "available": true },
+						{ "id": "HIGHPERFORMANCE", "displayName": "High Performance", "description": "High Performance CPU", "default": false, "available": true }
+					],
+					"property": [
+						{ "name": "MIN_DISK_SIZE_GB", "value": "10" },
+						{ "name": "MAX_DISK_SIZE_GB", "value": "1000" },
+						{ "name": "MAX_CPU_COUNT", "value": "32" },
+						{ "name": "MAX_MEMORY_GB", "value": "256" }
+					]
+				},
+				"backup": {
+					"type": "COMMVAULT",
+					"maintenanceStatus": "NORMAL",
+					"servicePlan": [ "Essentials", "Advanced", "Enterprise" ]
+				}
+			}
+		],
+		"pageNumber": 1,
+		"pageCount": 1,
+		"totalCount": 1,
+		"pageSize": 50
+	}
+`
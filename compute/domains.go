@@ -6,6 +6,32 @@ import (
 	"net/http"
 )
 
+// NetworkDomainType represents the type (service plan) of a network domain.
+type NetworkDomainType string
+
+const (
+	// NetworkDomainTypeEssentials represents an Essentials network domain (which does not support load-balancing).
+	NetworkDomainTypeEssentials NetworkDomainType = "ESSENTIALS"
+
+	// NetworkDomainTypeAdvanced represents an Advanced network domain (which supports load-balancing).
+	NetworkDomainTypeAdvanced NetworkDomainType = "ADVANCED"
+)
+
+// IsValid determines whether the network domain type is one of the well-known network domain types.
+func (networkDomainType NetworkDomainType) IsValid() bool {
+	switch networkDomainType {
+	case NetworkDomainTypeEssentials, NetworkDomainTypeAdvanced:
+		return true
+	default:
+		return false
+	}
+}
+
+// SupportsLoadBalancing determines whether network domains of this type support load-balancing (VIP nodes, pools, and virtual listeners).
+func (networkDomainType NetworkDomainType) SupportsLoadBalancing() bool {
+	return networkDomainType == NetworkDomainTypeAdvanced
+}
+
 // NetworkDomain represents a compute network domain.
 type NetworkDomain struct {
 	// The network domain Id.
@@ -18,7 +44,7 @@ type NetworkDomain struct {
 	Description string `json:"description"`
 
 	// The network domain type.
-	Type string `json:"type"`
+	Type NetworkDomainType `json:"type"`
 
 	// Network domain's NAT IPv4 address.
 	NatIPv4Address string `json:"snatIpv4Address"`
@@ -90,7 +116,7 @@ type deployNetworkDomain struct {
 	Description string `json:"description"`
 
 	// The network domain type.
-	Type string `json:"type"`
+	Type NetworkDomainType `json:"type"`
 
 	// The Id of the data centre in which the network domain is located.
 	DatacenterID string `json:"datacenterId"`
@@ -108,7 +134,7 @@ type editNetworkDomain struct {
 	Description *string `json:"description,omitempty"`
 
 	// The network domain type (optional).
-	Type *string `json:"type,omitempty"`
+	Type *NetworkDomainType `json:"type,omitempty"`
 }
 
 // Request body for deleting a compute network domain.
@@ -159,6 +185,27 @@ func (client *Client) ListNetworkDomains(paging *Paging) (domains *NetworkDomain
 	return domains, nil
 }
 
+// listAllNetworkDomains retrieves all network domains (across all pages of results).
+func (client *Client) listAllNetworkDomains() (domains []NetworkDomain, err error) {
+	paging := DefaultPaging()
+	for {
+		var page *NetworkDomains
+		page, err = client.ListNetworkDomains(paging)
+		if err != nil {
+			return nil, err
+		}
+
+		domains = append(domains, page.Domains...)
+		if page.IsLastPage() {
+			break
+		}
+
+		paging.Next()
+	}
+
+	return domains, nil
+}
+
 // GetNetworkDomain retrieves the network domain with the specified Id.
 // id is the Id of the network domain to retrieve.
 // Returns nil if no network domain is found with the specified Id.
@@ -253,7 +300,7 @@ func (client *Client) GetNetworkDomainByName(name string, dataCenterID string) (
 
 // DeployNetworkDomain deploys a new network domain.
 // Returns the Id of the new network domain.
-func (client *Client) DeployNetworkDomain(name string, description string, plan string, datacenter string) (networkDomainID string, err error) {
+func (client *Client) DeployNetworkDomain(name string, description string, plan NetworkDomainType, datacenter string) (networkDomainID string, err error) {
 	organizationID, err := client.getOrganizationID()
 	if err != nil {
 		return "", err
@@ -292,7 +339,7 @@ func (client *Client) DeployNetworkDomain(name string, description string, plan
 // EditNetworkDomain updates an existing network domain.
 // Pass an empty string for any field to retain its existing value.
 // Returns an error if the operation was not successful.
-func (client *Client) EditNetworkDomain(id string, name *string, description *string, plan *string) (err error) {
+func (client *Client) EditNetworkDomain(id string, name *string, description *string, plan *NetworkDomainType) (err error) {
 	organizationID, err := client.getOrganizationID()
 	if err != nil {
 		return err
@@ -316,7 +363,7 @@ func (client *Client) EditNetworkDomain(id string, name *string, description *st
 	}
 
 	if apiResponse.ResponseCode != ResponseCodeOK {
-		return apiResponse.ToError("Request to edit VLAN failed with unexpected status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
+		return apiResponse.ToError("Request to edit network domain failed with unexpected status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
 	}
 
 	return nil
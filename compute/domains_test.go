@@ -78,7 +78,7 @@ func TestClient_EditNetworkDomain_Success(test *testing.T) {
 
 	name := "A Network Domain"
 	description := "This is a network domain"
-	plan := "ESSENTIALS"
+	plan := NetworkDomainTypeEssentials
 
 	err := client.EditNetworkDomain("f14a871f-9a25-470c-aef8-51e13202e1aa", &name, &description, &plan)
 	if err != nil {
@@ -241,7 +241,7 @@ func verifyNetworkDomainTestResponse(test *testing.T, networkDomain *NetworkDoma
 	expect.NotNil("NetworkDomain", networkDomain)
 	expect.EqualsString("NetworkDomain.ID", "8cdfd607-f429-4df6-9352-162cfc0891be", networkDomain.ID)
 	expect.EqualsString("NetworkDomain.Name", "Development Network Domain", networkDomain.Name)
-	expect.EqualsString("NetworkDomain.Type", "ESSENTIALS", networkDomain.Type)
+	expect.EqualsString("NetworkDomain.Type", "ESSENTIALS", string(networkDomain.Type))
 	expect.EqualsString("NetworkDomain.State", "NORMAL", networkDomain.State)
 	expect.EqualsString("NetworkDomain.NatIPv4Address", "165.180.9.252", networkDomain.NatIPv4Address)
 	expect.EqualsString("NetworkDomain.DatacenterID", "NA9", networkDomain.DatacenterID)
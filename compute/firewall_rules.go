@@ -609,6 +609,27 @@ func (client *Client) ListFirewallRules(networkDomainID string, paging *Paging)
 	return rules, err
 }
 
+// listAllFirewallRules retrieves all firewall rules in the specified network domain (across all pages of results).
+func (client *Client) listAllFirewallRules(networkDomainID string) (rules []FirewallRule, err error) {
+	paging := DefaultPaging()
+	for {
+		var page *FirewallRules
+		page, err = client.ListFirewallRules(networkDomainID, paging)
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, page.Rules...)
+		if page.IsLastPage() {
+			break
+		}
+
+		paging.Next()
+	}
+
+	return rules, nil
+}
+
 // CreateFirewallRule creates a new firewall rule.
 func (client *Client) CreateFirewallRule(configuration FirewallRuleConfiguration) (firewallRuleID string, err error) {
 	organizationID, err := client.getOrganizationID()
@@ -63,3 +63,24 @@ func (client *Client) ListDefaultHealthMonitors(networkDomainID string, paging *
 
 	return healthMonitors, nil
 }
+
+// listAllDefaultHealthMonitors retrieves all default load-balancing health monitors in the specified network domain (across all pages of results).
+func (client *Client) listAllDefaultHealthMonitors(networkDomainID string) (healthMonitors []HealthMonitor, err error) {
+	paging := DefaultPaging()
+	for {
+		var page *HealthMonitors
+		page, err = client.ListDefaultHealthMonitors(networkDomainID, paging)
+		if err != nil {
+			return nil, err
+		}
+
+		healthMonitors = append(healthMonitors, page.Items...)
+		if page.IsLastPage() {
+			break
+		}
+
+		paging.Next()
+	}
+
+	return healthMonitors, nil
+}
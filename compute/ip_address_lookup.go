@@ -0,0 +1,301 @@
+package compute
+
+import (
+	"fmt"
+	"net"
+)
+
+// Well-known roles for an IP address found by FindIPAddressUsages.
+const (
+	// IPAddressRoleServerNetworkAdapter represents an address assigned to a server's network adapter.
+	IPAddressRoleServerNetworkAdapter = "SERVER_NETWORK_ADAPTER"
+
+	// IPAddressRoleNATInternal represents the internal (private) address of a NAT rule.
+	IPAddressRoleNATInternal = "NAT_INTERNAL"
+
+	// IPAddressRoleNATExternal represents the external (public) address of a NAT rule.
+	IPAddressRoleNATExternal = "NAT_EXTERNAL"
+
+	// IPAddressRoleVIPNode represents the address of a VIP node.
+	IPAddressRoleVIPNode = "VIP_NODE"
+
+	// IPAddressRoleVirtualListener represents the address of a virtual listener.
+	IPAddressRoleVirtualListener = "VIRTUAL_LISTENER"
+
+	// IPAddressRoleReservedPrivate represents a reserved private IPv4 (or IPv6) address on a VLAN.
+	IPAddressRoleReservedPrivate = "RESERVED_PRIVATE"
+
+	// IPAddressRoleReservedPublic represents a reserved public IPv4 address (i.e. one in use by a NAT rule or virtual listener).
+	IPAddressRoleReservedPublic = "RESERVED_PUBLIC"
+
+	// IPAddressRoleVLANGateway represents a VLAN's IPv4 or IPv6 gateway address.
+	IPAddressRoleVLANGateway = "VLAN_GATEWAY"
+
+	// IPAddressRolePublicIPBlock represents an address that belongs to a public IPv4 address block.
+	IPAddressRolePublicIPBlock = "PUBLIC_IP_BLOCK"
+)
+
+// IPAddressUsage represents a resource that uses (or owns) an IP address.
+type IPAddressUsage struct {
+	// The IP address.
+	Address string
+
+	// The role the address plays for the resource (e.g. IPAddressRoleNATExternal).
+	Role string
+
+	// The kind of resource that uses the address (e.g. ResourceGraphKindServer).
+	ResourceKind string
+
+	// The Id of the resource that uses the address.
+	ResourceID string
+
+	// The name of the resource that uses the address (or, for resources without a name, a description of the resource).
+	ResourceName string
+
+	// The Id of the network domain where the resource is located.
+	NetworkDomainID string
+}
+
+// FindIPAddressUsagesInNetworkDomain finds every resource in the specified network domain that uses the specified IPv4 or IPv6 address.
+//
+// This includes server network adapters, NAT rules (internal and external addresses), VIP nodes, virtual listeners,
+// reserved private and public addresses, VLAN gateways, and public IP blocks that contain the address.
+func (client *Client) FindIPAddressUsagesInNetworkDomain(networkDomainID string, address string) ([]IPAddressUsage, error) {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return nil, fmt.Errorf("'%s' is not a valid IP address.", address)
+	}
+
+	finder := &ipAddressUsageFinder{
+		client:          client,
+		networkDomainID: networkDomainID,
+		address:         ip.String(),
+		isIPv4:          ip.To4() != nil,
+	}
+
+	steps := []func() error{
+		finder.findServers,
+		finder.findNATRules,
+		finder.findVIPNodes,
+		finder.findVirtualListeners,
+		finder.findVLANs,
+		finder.findPublicIPBlocks,
+	}
+	for _, step := range steps {
+		err := step()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return finder.usages, nil
+}
+
+// FindIPAddressUsages finds every resource (in every network domain in the organisation) that uses the specified IPv4 or IPv6 address.
+//
+// See FindIPAddressUsagesInNetworkDomain for the types of resource that are searched.
+func (client *Client) FindIPAddressUsages(address string) ([]IPAddressUsage, error) {
+	networkDomains, err := client.listAllNetworkDomains()
+	if err != nil {
+		return nil, err
+	}
+
+	var usages []IPAddressUsage
+	for _, networkDomain := range networkDomains {
+		var networkDomainUsages []IPAddressUsage
+		networkDomainUsages, err = client.FindIPAddressUsagesInNetworkDomain(networkDomain.ID, address)
+		if err != nil {
+			return nil, err
+		}
+
+		usages = append(usages, networkDomainUsages...)
+	}
+
+	return usages, nil
+}
+
+// Finds the resources in a network domain that use an IP address.
+type ipAddressUsageFinder struct {
+	client          *Client
+	networkDomainID string
+
+	// The target address (in canonical form).
+	address string
+	isIPv4  bool
+
+	usages []IPAddressUsage
+}
+
+// Determine whether the specified address is the target address.
+func (finder *ipAddressUsageFinder) matches(address string) bool {
+	ip := net.ParseIP(address)
+
+	return ip != nil && ip.String() == finder.address
+}
+
+// Determine whether the specified (optional) address is the target address.
+func (finder *ipAddressUsageFinder) matchesPtr(address *string) bool {
+	return address != nil && finder.matches(*address)
+}
+
+// Record a resource that uses the target address.
+func (finder *ipAddressUsageFinder) found(role string, resourceKind string, resourceID string, resourceName string) {
+	finder.usages = append(finder.usages, IPAddressUsage{
+		Address:         finder.address,
+		Role:            role,
+		ResourceKind:    resourceKind,
+		ResourceID:      resourceID,
+		ResourceName:    resourceName,
+		NetworkDomainID: finder.networkDomainID,
+	})
+}
+
+func (finder *ipAddressUsageFinder) findServers() error {
+	servers, err := finder.client.listAllServersInNetworkDomain(finder.networkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, server := range servers {
+		networkAdapters := append(
+			[]VirtualMachineNetworkAdapter{server.Network.PrimaryAdapter},
+			server.Network.AdditionalNetworkAdapters...,
+		)
+		for _, networkAdapter := range networkAdapters {
+			if finder.matchesPtr(networkAdapter.PrivateIPv4Address) || finder.matchesPtr(networkAdapter.PrivateIPv6Address) {
+				finder.found(IPAddressRoleServerNetworkAdapter, ResourceGraphKindServer, server.ID,
+					fmt.Sprintf("%s (network adapter '%s')", server.Name, networkAdapter.GetID()),
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (finder *ipAddressUsageFinder) findNATRules() error {
+	if !finder.isIPv4 {
+		return nil // NAT only applies to IPv4.
+	}
+
+	rules, err := finder.client.listAllNATRules(finder.networkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		ruleName := fmt.Sprintf("%s -> %s", rule.ExternalIPAddress, rule.InternalIPAddress)
+		if finder.matches(rule.InternalIPAddress) {
+			finder.found(IPAddressRoleNATInternal, ResourceGraphKindNATRule, rule.ID, ruleName)
+		}
+		if finder.matches(rule.ExternalIPAddress) {
+			finder.found(IPAddressRoleNATExternal, ResourceGraphKindNATRule, rule.ID, ruleName)
+		}
+	}
+
+	return nil
+}
+
+func (finder *ipAddressUsageFinder) findVIPNodes() error {
+	nodes, err := finder.client.listAllVIPNodesInNetworkDomain(finder.networkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, node := range nodes {
+		if finder.matches(node.IPv4Address) || finder.matches(node.IPv6Address) {
+			finder.found(IPAddressRoleVIPNode, ResourceGraphKindVIPNode, node.ID, node.Name)
+		}
+	}
+
+	return nil
+}
+
+func (finder *ipAddressUsageFinder) findVirtualListeners() error {
+	listeners, err := finder.client.listAllVirtualListenersInNetworkDomain(finder.networkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, listener := range listeners {
+		if finder.matches(listener.ListenerIPAddress) {
+			finder.found(IPAddressRoleVirtualListener, ResourceGraphKindVirtualListener, listener.ID, listener.Name)
+		}
+	}
+
+	return nil
+}
+
+func (finder *ipAddressUsageFinder) findVLANs() error {
+	vlans, err := finder.client.listAllVLANs(finder.networkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, vlan := range vlans {
+		if finder.matches(vlan.IPv4GatewayAddress) || finder.matches(vlan.IPv6GatewayAddress) {
+			finder.found(IPAddressRoleVLANGateway, ResourceGraphKindVLAN, vlan.ID, vlan.Name)
+		}
+
+		// Only look for reservations on VLANs whose network contains the address.
+		if finder.isIPv4 && vlan.IPv4Range.Contains(finder.address) {
+			var reservedAddresses []ReservedPrivateIPv4Address
+			reservedAddresses, err = finder.client.listAllReservedPrivateIPv4Addresses(vlan.ID)
+			if err != nil {
+				return err
+			}
+			for _, reservedAddress := range reservedAddresses {
+				if finder.matches(reservedAddress.Address) {
+					finder.found(IPAddressRoleReservedPrivate, ResourceGraphKindVLAN, vlan.ID, vlan.Name)
+				}
+			}
+		} else if !finder.isIPv4 && vlan.IPv6Range.Contains(finder.address) {
+			var reservedAddresses []ReservedIPv6Address
+			reservedAddresses, err = finder.client.listAllReservedIPv6Addresses(vlan.ID)
+			if err != nil {
+				return err
+			}
+			for _, reservedAddress := range reservedAddresses {
+				if finder.matches(reservedAddress.Address) {
+					finder.found(IPAddressRoleReservedPrivate, ResourceGraphKindVLAN, vlan.ID, vlan.Name)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (finder *ipAddressUsageFinder) findPublicIPBlocks() error {
+	if !finder.isIPv4 {
+		return nil // Public IP blocks only contain IPv4 addresses.
+	}
+
+	blocks, err := finder.client.listAllPublicIPBlocks(finder.networkDomainID)
+	if err != nil {
+		return err
+	}
+
+	var matchingBlocks []PublicIPBlock
+	for _, block := range blocks {
+		if ipv4BlockContains(block.BaseIP, block.Size, finder.address) {
+			finder.found(IPAddressRolePublicIPBlock, ResourceGraphKindPublicIPBlock, block.ID, fmt.Sprintf("%s (%d)", block.BaseIP, block.Size))
+			matchingBlocks = append(matchingBlocks, block)
+		}
+	}
+	if len(matchingBlocks) == 0 {
+		return nil // Reserved public addresses always belong to a public IP block.
+	}
+
+	reservedPublicIPs, err := finder.client.listAllReservedPublicIPAddresses(finder.networkDomainID)
+	if err != nil {
+		return err
+	}
+	for _, reservedPublicIP := range reservedPublicIPs {
+		if finder.matches(reservedPublicIP.Address) {
+			finder.found(IPAddressRoleReservedPublic, ResourceGraphKindPublicIPBlock, reservedPublicIP.IPBlockID, reservedPublicIP.Address)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package compute
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// Find IP address usages in network domain (private address used by server, NAT rule, and VIP node).
+func TestClient_FindIPAddressUsagesInNetworkDomain_PrivateAddress(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			usages, err := client.FindIPAddressUsagesInNetworkDomain("484174a2-ae74-4658-9e56-50fc90e086cf", "10.0.3.17")
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.EqualsInt("Usages.Length", 3, len(usages))
+			expect.EqualsString("Usages[0].Role", IPAddressRoleServerNetworkAdapter, usages[0].Role)
+			expect.EqualsString("Usages[0].ResourceID", "5a32d6e4-9707-4813-a269-56ab4d989f4d", usages[0].ResourceID)
+			expect.EqualsString("Usages[1].Role", IPAddressRoleNATInternal, usages[1].Role)
+			expect.EqualsString("Usages[1].ResourceID", "2169a38e-5692-497e-a22a-701a838a6539", usages[1].ResourceID)
+			expect.EqualsString("Usages[2].Role", IPAddressRoleVIPNode, usages[2].Role)
+			expect.EqualsString("Usages[2].ResourceID", "34de6ed6-46a4-4dae-a753-2f8d3840c6f9", usages[2].ResourceID)
+
+			for _, usage := range usages {
+				expect.EqualsString("Usage.NetworkDomainID", "484174a2-ae74-4658-9e56-50fc90e086cf", usage.NetworkDomainID)
+			}
+		},
+		Respond: testRespondToIPAddressLookupRequest(test),
+	})
+}
+
+// Find IP address usages in network domain (reserved private address and VLAN gateway).
+func TestClient_FindIPAddressUsagesInNetworkDomain_VLANAddresses(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			usages, err := client.FindIPAddressUsagesInNetworkDomain("484174a2-ae74-4658-9e56-50fc90e086cf", "10.0.3.11")
+			if err != nil {
+				test.Fatal(err)
+			}
+			expect.EqualsInt("Usages.Length (reserved)", 1, len(usages))
+			expect.EqualsString("Usages[0].Role (reserved)", IPAddressRoleReservedPrivate, usages[0].Role)
+			expect.EqualsString("Usages[0].ResourceKind (reserved)", ResourceGraphKindVLAN, usages[0].ResourceKind)
+
+			usages, err = client.FindIPAddressUsagesInNetworkDomain("484174a2-ae74-4658-9e56-50fc90e086cf", "10.0.3.1")
+			if err != nil {
+				test.Fatal(err)
+			}
+			expect.EqualsInt("Usages.Length (gateway)", 1, len(usages))
+			expect.EqualsString("Usages[0].Role (gateway)", IPAddressRoleVLANGateway, usages[0].Role)
+			expect.EqualsString("Usages[0].ResourceName (gateway)", "Production VLAN", usages[0].ResourceName)
+		},
+		Respond: testRespondToIPAddressLookupRequest(test),
+	})
+}
+
+// Find IP address usages in network domain (public addresses).
+func TestClient_FindIPAddressUsagesInNetworkDomain_PublicAddress(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			usages, err := client.FindIPAddressUsagesInNetworkDomain("484174a2-ae74-4658-9e56-50fc90e086cf", "165.180.12.12")
+			if err != nil {
+				test.Fatal(err)
+			}
+			expect.EqualsInt("Usages.Length (NAT)", 3, len(usages))
+			expect.EqualsString("Usages[0].Role (NAT)", IPAddressRoleNATExternal, usages[0].Role)
+			expect.EqualsString("Usages[1].Role (NAT)", IPAddressRolePublicIPBlock, usages[1].Role)
+			expect.EqualsString("Usages[1].ResourceID (NAT)", "4487241a-f0ca-11e3-9315-d4bed9b167ba", usages[1].ResourceID)
+			expect.EqualsString("Usages[2].Role (NAT)", IPAddressRoleReservedPublic, usages[2].Role)
+
+			usages, err = client.FindIPAddressUsagesInNetworkDomain("484174a2-ae74-4658-9e56-50fc90e086cf", "165.180.12.13")
+			if err != nil {
+				test.Fatal(err)
+			}
+			expect.EqualsInt("Usages.Length (listener)", 3, len(usages))
+			expect.EqualsString("Usages[0].Role (listener)", IPAddressRoleVirtualListener, usages[0].Role)
+			expect.EqualsString("Usages[0].ResourceID (listener)", "6115469d-a8bb-445b-bb23-d23b5283f2b9", usages[0].ResourceID)
+			expect.EqualsString("Usages[1].Role (listener)", IPAddressRolePublicIPBlock, usages[1].Role)
+			expect.EqualsString("Usages[2].Role (listener)", IPAddressRoleReservedPublic, usages[2].Role)
+
+			usages, err = client.FindIPAddressUsagesInNetworkDomain("484174a2-ae74-4658-9e56-50fc90e086cf", "168.128.1.1")
+			if err != nil {
+				test.Fatal(err)
+			}
+			expect.EqualsInt("Usages.Length (unused)", 0, len(usages))
+		},
+		Respond: testRespondToIPAddressLookupRequest(test),
+	})
+}
+
+// Find IP address usages (invalid address).
+func TestClient_FindIPAddressUsagesInNetworkDomain_InvalidAddress(test *testing.T) {
+	expect := expect(test)
+
+	client := NewClient("au1", "user1", "password")
+	_, err := client.FindIPAddressUsagesInNetworkDomain("484174a2-ae74-4658-9e56-50fc90e086cf", "10.0.3")
+	expect.NotNil("FindIPAddressUsagesInNetworkDomain error", err)
+	expect.IsTrue("Error message mentions address", strings.Contains(err.Error(), "'10.0.3'"))
+}
+
+// Respond to requests made when looking up IP address usages.
+func testRespondToIPAddressLookupRequest(test *testing.T) ClientTestResponder {
+	return func(test *testing.T, request *http.Request) (int, string) {
+		if strings.HasSuffix(request.URL.Path, "/network/reservedPublicIpv4Address") {
+			return http.StatusOK, `
+				{
+					"ip": [
+						{ "ipBlockId": "4487241a-f0ca-11e3-9315-d4bed9b167ba", "datacenterId": "AU9", "networkDomainId": "484174a2-ae74-4658-9e56-50fc90e086cf", "value": "165.180.12.12" },
+						{ "ipBlockId": "4487241a-f0ca-11e3-9315-d4bed9b167ba", "datacenterId": "AU9", "networkDomainId": "484174a2-ae74-4658-9e56-50fc90e086cf", "value": "165.180.12.13" }
+					],
+					"pageNumber": 1, "pageCount": 2, "totalCount": 2, "pageSize": 250
+				}
+			`
+		}
+
+		for pathSuffix, response := range exportNetworkDomainTestResponses {
+			if strings.HasSuffix(request.URL.Path, pathSuffix) {
+				return http.StatusOK, response
+			}
+		}
+
+		test.Fatalf("Unexpected request: %s %s", request.Method, request.URL.Path)
+
+		return http.StatusNotFound, ""
+	}
+}
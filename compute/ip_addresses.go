@@ -0,0 +1,62 @@
+package compute
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+)
+
+// parseIPv4Address parses the specified IPv4 address.
+func parseIPv4Address(address string) (net.IP, error) {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return nil, fmt.Errorf("'%s' is not a valid IP address", address)
+	}
+
+	ipv4 := ip.To4()
+	if ipv4 == nil {
+		return nil, fmt.Errorf("'%s' is not a valid IPv4 address", address)
+	}
+
+	return ipv4, nil
+}
+
+// validateIPv6Address ensures that the specified address is a valid IPv6 address.
+func validateIPv6Address(address string) error {
+	ip := net.ParseIP(address)
+	if ip == nil || ip.To4() != nil {
+		return fmt.Errorf("'%s' is not a valid IPv6 address", address)
+	}
+
+	return nil
+}
+
+// ipv4ToUint32 converts an IPv4 address to its numeric representation.
+func ipv4ToUint32(ip net.IP) uint32 {
+	return binary.BigEndian.Uint32(ip.To4())
+}
+
+// uint32ToIPv4 converts the numeric representation of an IPv4 address to an IP address.
+func uint32ToIPv4(value uint32) net.IP {
+	ip := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(ip, value)
+
+	return ip
+}
+
+// ipv4BlockContains determines whether the block of IPv4 addresses (of the specified size) starting at baseIP contains the specified address.
+func ipv4BlockContains(baseIP string, size int, address string) bool {
+	baseAddress, err := parseIPv4Address(baseIP)
+	if err != nil {
+		return false
+	}
+	ip, err := parseIPv4Address(address)
+	if err != nil {
+		return false
+	}
+
+	numericBaseAddress := uint64(ipv4ToUint32(baseAddress))
+	numericAddress := uint64(ipv4ToUint32(ip))
+
+	return numericAddress >= numericBaseAddress && numericAddress < numericBaseAddress+uint64(size)
+}
@@ -157,6 +157,27 @@ func (client *Client) ListPublicIPBlocks(networkDomainID string, paging *Paging)
 	return blocks, err
 }
 
+// listAllPublicIPBlocks retrieves all public IPv4 address blocks allocated to the specified network domain (across all pages of results).
+func (client *Client) listAllPublicIPBlocks(networkDomainID string) (blocks []PublicIPBlock, err error) {
+	paging := DefaultPaging()
+	for {
+		var page *PublicIPBlocks
+		page, err = client.ListPublicIPBlocks(networkDomainID, paging)
+		if err != nil {
+			return nil, err
+		}
+
+		blocks = append(blocks, page.Blocks...)
+		if page.IsLastPage() {
+			break
+		}
+
+		paging.Next()
+	}
+
+	return blocks, nil
+}
+
 // AddPublicIPBlock adds a new block of public IPv4 addresses to the specified network domain.
 func (client *Client) AddPublicIPBlock(networkDomainID string) (blockID string, err error) {
 	organizationID, err := client.getOrganizationID()
@@ -258,3 +279,24 @@ func (client *Client) ListReservedPublicIPAddresses(networkDomainID string, pagi
 
 	return reservedPublicIPs, err
 }
+
+// listAllReservedPublicIPAddresses retrieves all reserved public IPv4 addresses in the specified network domain (across all pages of results).
+func (client *Client) listAllReservedPublicIPAddresses(networkDomainID string) (reservedPublicIPs []ReservedPublicIP, err error) {
+	paging := DefaultPaging()
+	for {
+		var page *ReservedPublicIPs
+		page, err = client.ListReservedPublicIPAddresses(networkDomainID, paging)
+		if err != nil {
+			return nil, err
+		}
+
+		reservedPublicIPs = append(reservedPublicIPs, page.IPs...)
+		if page.IsLastPage() {
+			break
+		}
+
+		paging.Next()
+	}
+
+	return reservedPublicIPs, nil
+}
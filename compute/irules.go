@@ -73,3 +73,24 @@ func (client *Client) ListDefaultIRules(networkDomainID string, paging *Paging)
 
 	return irules, nil
 }
+
+// listAllDefaultIRules retrieves all default load-balancing iRules in the specified network domain (across all pages of results).
+func (client *Client) listAllDefaultIRules(networkDomainID string) (iRules []IRule, err error) {
+	paging := DefaultPaging()
+	for {
+		var page *IRules
+		page, err = client.ListDefaultIRules(networkDomainID, paging)
+		if err != nil {
+			return nil, err
+		}
+
+		iRules = append(iRules, page.Items...)
+		if page.IsLastPage() {
+			break
+		}
+
+		paging.Next()
+	}
+
+	return iRules, nil
+}
@@ -0,0 +1,504 @@
+package compute
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// Default connection limits for the VIP nodes and virtual listener created by ProvisionLoadBalancedService.
+const (
+	defaultLoadBalancedServiceNodeConnectionLimit         = 20000
+	defaultLoadBalancedServiceNodeConnectionRateLimit     = 2000
+	defaultLoadBalancedServiceListenerConnectionLimit     = 25000
+	defaultLoadBalancedServiceListenerConnectionRateLimit = 2000
+)
+
+// LoadBalancedServiceBackend represents a backend (VIP node and pool member) in a load-balanced service.
+type LoadBalancedServiceBackend struct {
+	// The name of the VIP node for the backend.
+	Name string
+
+	// The backend's private IPv4 address.
+	IPv4Address string
+
+	// The port on the backend that traffic is forwarded to (if not specified, the service's port is used).
+	Port *int
+}
+
+// LoadBalancedServiceConfiguration represents the configuration for a load-balanced service.
+type LoadBalancedServiceConfiguration struct {
+	// The service name (used as the name of the VIP pool and virtual listener).
+	Name string
+
+	// The service description.
+	Description string
+
+	// The Id of the network domain where the service will be created.
+	NetworkDomainID string
+
+	// The service's backends.
+	Backends []LoadBalancedServiceBackend
+
+	// The port that the virtual listener listens on.
+	Port int
+
+	// The virtual listener protocol (e.g. VirtualListenerStandardProtocolHTTP).
+	Protocol string
+
+	// The VIP pool's load-balancing method (if not specified, LoadBalanceMethodRoundRobin is used).
+	LoadBalanceMethod string
+
+	// The names of the (default) health monitors for the VIP pool (e.g. "CCDEFAULT.Http").
+	HealthMonitors []string
+
+	// The name of the (default) persistence profile for the virtual listener, if any (e.g. "CCDEFAULT.SourceAddress").
+	PersistenceProfile string
+
+	// The virtual listener's IPv4 address (if not specified, a public IPv4 address is allocated using AllocatePublicIPv4Address).
+	ListenerIPAddress string
+
+	// The amount of time to wait for each resource to be deployed.
+	Timeout time.Duration
+}
+
+// Validate checks that the load-balanced service configuration is valid.
+func (configuration *LoadBalancedServiceConfiguration) Validate() error {
+	if configuration.Name == "" {
+		return fmt.Errorf("A name must be specified for the load-balanced service.")
+	}
+	if configuration.NetworkDomainID == "" {
+		return fmt.Errorf("A network domain must be specified for load-balanced service '%s'.", configuration.Name)
+	}
+	if len(configuration.Backends) == 0 {
+		return fmt.Errorf("Load-balanced service '%s' must have at least one backend.", configuration.Name)
+	}
+	if configuration.Port < 1 || configuration.Port > 65535 {
+		return fmt.Errorf("Invalid port %d for load-balanced service '%s' (must be between 1 and 65535).", configuration.Port, configuration.Name)
+	}
+	if configuration.Protocol == "" {
+		return fmt.Errorf("A protocol must be specified for load-balanced service '%s'.", configuration.Name)
+	}
+
+	backendNames := make(map[string]bool)
+	for _, backend := range configuration.Backends {
+		if backend.Name == "" {
+			return fmt.Errorf("Backend '%s' of load-balanced service '%s' does not have a name.", backend.IPv4Address, configuration.Name)
+		}
+		if backendNames[backend.Name] {
+			return fmt.Errorf("Load-balanced service '%s' has more than one backend named '%s'.", configuration.Name, backend.Name)
+		}
+		backendNames[backend.Name] = true
+
+		_, err := parseIPv4Address(backend.IPv4Address)
+		if err != nil {
+			return fmt.Errorf("Backend '%s' of load-balanced service '%s' has an invalid IPv4 address: %s", backend.Name, configuration.Name, err)
+		}
+		if backend.Port != nil && (*backend.Port < 1 || *backend.Port > 65535) {
+			return fmt.Errorf("Backend '%s' of load-balanced service '%s' has invalid port %d (must be between 1 and 65535).", backend.Name, configuration.Name, *backend.Port)
+		}
+	}
+
+	return nil
+}
+
+// LoadBalancedService represents the resources that make up a load-balanced service.
+type LoadBalancedService struct {
+	// The service name.
+	Name string
+
+	// The Id of the network domain where the service is located.
+	NetworkDomainID string
+
+	// The Ids of the VIP nodes for the service's backends (in the same order as the backends).
+	NodeIDs []string
+
+	// The Ids of the VIP nodes that were created for the service (nodes that already existed for a backend's address are reused, and are not deleted by TeardownLoadBalancedService).
+	CreatedNodeIDs []string
+
+	// The VIP pool Id.
+	PoolID string
+
+	// The Ids of the VIP pool members (in the same order as the backends).
+	PoolMemberIDs []string
+
+	// The virtual listener Id.
+	ListenerID string
+
+	// The virtual listener's IPv4 address.
+	ListenerIPAddress string
+
+	// The Id of the public IP block that was added to provide the listener's IPv4 address (empty if no block was added).
+	CreatedPublicIPBlockID string
+}
+
+// LoadBalancedServiceError is an error representing the failure of a step in ProvisionLoadBalancedService.
+type LoadBalancedServiceError struct {
+	// The resources that were created before the failure.
+	Service *LoadBalancedService
+
+	// A description of the step that failed.
+	FailedStep string
+
+	// The error that caused the step to fail.
+	Cause error
+
+	// Were the resources created before the failure deleted?
+	RolledBack bool
+
+	// Errors encountered while deleting the resources created before the failure (if any).
+	RollbackErrors []error
+}
+
+// Error returns the error message associated with the LoadBalancedServiceError.
+func (serviceError *LoadBalancedServiceError) Error() string {
+	message := fmt.Sprintf("Provisioning of load-balanced service '%s' failed at step '%s': %s",
+		serviceError.Service.Name,
+		serviceError.FailedStep,
+		serviceError.Cause.Error(),
+	)
+	if serviceError.RolledBack {
+		message += "; all created resources were deleted"
+	} else if len(serviceError.RollbackErrors) > 0 {
+		rollbackErrors := make([]string, len(serviceError.RollbackErrors))
+		for index, rollbackError := range serviceError.RollbackErrors {
+			rollbackErrors[index] = rollbackError.Error()
+		}
+
+		message += fmt.Sprintf("; rollback failed: %s", strings.Join(rollbackErrors, "; "))
+	}
+
+	return message
+}
+
+var _ error = &LoadBalancedServiceError{}
+
+// ProvisionLoadBalancedService creates a load-balanced service.
+//
+// A VIP node is created for each backend (unless one already exists for the backend's address), followed by the VIP pool, a pool member for each node, and finally the virtual listener
+// (if no listener address is specified, a public IPv4 address is allocated for it). ProvisionLoadBalancedService waits for each resource to be deployed before creating the next.
+//
+// If any step fails, the resources that were already created are deleted (in reverse order), and a *LoadBalancedServiceError is returned.
+func (client *Client) ProvisionLoadBalancedService(configuration LoadBalancedServiceConfiguration) (*LoadBalancedService, error) {
+	err := configuration.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	provisioner := &loadBalancedServiceProvisioner{
+		client:        client,
+		configuration: configuration,
+		service: &LoadBalancedService{
+			Name:            configuration.Name,
+			NetworkDomainID: configuration.NetworkDomainID,
+		},
+	}
+
+	// Order matters here; each step uses the resources created by the previous ones.
+	steps := []struct {
+		description string
+		apply       func() error
+	}{
+		{"Create VIP nodes", provisioner.createVIPNodes},
+		{"Create VIP pool", provisioner.createVIPPool},
+		{"Add VIP pool members", provisioner.addVIPPoolMembers},
+		{"Create virtual listener", provisioner.createVirtualListener},
+	}
+	for _, step := range steps {
+		log.Printf("%s...", step.description)
+
+		err = step.apply()
+		if err != nil {
+			serviceError := &LoadBalancedServiceError{
+				Service:    provisioner.service,
+				FailedStep: step.description,
+				Cause:      err,
+			}
+			serviceError.RollbackErrors = provisioner.rollBack()
+			serviceError.RolledBack = len(serviceError.RollbackErrors) == 0
+
+			return provisioner.service, serviceError
+		}
+	}
+
+	return provisioner.service, nil
+}
+
+// TeardownLoadBalancedService deletes the resources that make up a load-balanced service (in reverse order of creation).
+//
+// VIP nodes that were not created by ProvisionLoadBalancedService are left in place, as are resources that have already been deleted.
+// A public IP block added for the listener's address is only removed if none of its addresses are still reserved.
+func (client *Client) TeardownLoadBalancedService(service *LoadBalancedService) error {
+	if service.ListenerID != "" {
+		log.Printf("Delete virtual listener '%s'...", service.ListenerID)
+		err := client.DeleteVirtualListener(service.ListenerID)
+		if err != nil && !isResourceNotFoundError(err) {
+			return err
+		}
+	}
+
+	for _, memberID := range service.PoolMemberIDs {
+		log.Printf("Remove VIP pool member '%s'...", memberID)
+		err := client.RemoveVIPPoolMember(memberID)
+		if err != nil && !isResourceNotFoundError(err) {
+			return err
+		}
+	}
+
+	if service.PoolID != "" {
+		log.Printf("Delete VIP pool '%s'...", service.PoolID)
+		err := client.DeleteVIPPool(service.PoolID)
+		if err != nil && !isResourceNotFoundError(err) {
+			return err
+		}
+	}
+
+	for _, nodeID := range service.CreatedNodeIDs {
+		log.Printf("Delete VIP node '%s'...", nodeID)
+		err := client.DeleteVIPNode(nodeID)
+		if err != nil && !isResourceNotFoundError(err) {
+			return err
+		}
+	}
+
+	if service.CreatedPublicIPBlockID != "" {
+		log.Printf("Remove public IP block '%s' (if unused)...", service.CreatedPublicIPBlockID)
+		_, err := client.RemovePublicIPBlockIfUnused(service.CreatedPublicIPBlockID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Provisions the resources for a load-balanced service.
+type loadBalancedServiceProvisioner struct {
+	client        *Client
+	configuration LoadBalancedServiceConfiguration
+	service       *LoadBalancedService
+
+	// Functions that delete the resources created so far (in the order they were created).
+	rollbackActions []func() error
+}
+
+// Record a function that deletes a newly-created resource.
+func (provisioner *loadBalancedServiceProvisioner) created(remove func() error) {
+	provisioner.rollbackActions = append(provisioner.rollbackActions, remove)
+}
+
+// Delete the resources created so far (in reverse order of creation).
+func (provisioner *loadBalancedServiceProvisioner) rollBack() (rollbackErrors []error) {
+	for index := len(provisioner.rollbackActions) - 1; index >= 0; index-- {
+		err := provisioner.rollbackActions[index]()
+		if err != nil && !isResourceNotFoundError(err) {
+			rollbackErrors = append(rollbackErrors, err)
+		}
+	}
+
+	return
+}
+
+// Wait for a newly-created resource to be deployed (if it is not already).
+func (provisioner *loadBalancedServiceProvisioner) waitForDeploy(resourceType ResourceType, id string) error {
+	resource, err := provisioner.client.GetResource(id, resourceType)
+	if err != nil {
+		return err
+	}
+	if !resource.IsDeleted() && resource.GetState() == ResourceStatusNormal {
+		return nil
+	}
+
+	_, err = provisioner.client.WaitForDeploy(resourceType, id, provisioner.configuration.Timeout)
+
+	return err
+}
+
+func (provisioner *loadBalancedServiceProvisioner) createVIPNodes() error {
+	client := provisioner.client
+	configuration := provisioner.configuration
+
+	existingNodes, err := client.listAllVIPNodesInNetworkDomain(configuration.NetworkDomainID)
+	if err != nil {
+		return err
+	}
+	existingNodeIDs := make(map[string]string)
+	for _, existingNode := range existingNodes {
+		existingNodeIDs[existingNode.IPv4Address] = existingNode.ID
+	}
+
+	for _, backend := range configuration.Backends {
+		if nodeID, ok := existingNodeIDs[backend.IPv4Address]; ok {
+			log.Printf("Using existing VIP node '%s' for backend '%s' (%s).", nodeID, backend.Name, backend.IPv4Address)
+			provisioner.service.NodeIDs = append(provisioner.service.NodeIDs, nodeID)
+
+			continue
+		}
+
+		nodeID, err := client.CreateVIPNode(NewVIPNodeConfiguration{
+			Name:                backend.Name,
+			Description:         configuration.Description,
+			IPv4Address:         backend.IPv4Address,
+			Status:              VIPNodeStatusEnabled,
+			ConnectionLimit:     defaultLoadBalancedServiceNodeConnectionLimit,
+			ConnectionRateLimit: defaultLoadBalancedServiceNodeConnectionRateLimit,
+			NetworkDomainID:     configuration.NetworkDomainID,
+		})
+		if err != nil {
+			return err
+		}
+		provisioner.service.NodeIDs = append(provisioner.service.NodeIDs, nodeID)
+		provisioner.service.CreatedNodeIDs = append(provisioner.service.CreatedNodeIDs, nodeID)
+		provisioner.created(func() error {
+			return client.DeleteVIPNode(nodeID)
+		})
+
+		err = provisioner.waitForDeploy(ResourceTypeVIPNode, nodeID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (provisioner *loadBalancedServiceProvisioner) createVIPPool() error {
+	client := provisioner.client
+	configuration := provisioner.configuration
+
+	poolConfiguration := NewVIPPoolConfiguration{
+		Name:              configuration.Name,
+		Description:       configuration.Description,
+		LoadBalanceMethod: configuration.LoadBalanceMethod,
+		ServiceDownAction: ServiceDownActionNone,
+		NetworkDomainID:   configuration.NetworkDomainID,
+	}
+	if poolConfiguration.LoadBalanceMethod == "" {
+		poolConfiguration.LoadBalanceMethod = LoadBalanceMethodRoundRobin
+	}
+	if len(configuration.HealthMonitors) > 0 {
+		healthMonitors, err := client.listAllDefaultHealthMonitors(configuration.NetworkDomainID)
+		if err != nil {
+			return err
+		}
+		healthMonitorIDs := make(map[string]string)
+		for _, healthMonitor := range healthMonitors {
+			healthMonitorIDs[healthMonitor.Name] = healthMonitor.ID
+		}
+
+		for _, healthMonitorName := range configuration.HealthMonitors {
+			healthMonitorID, ok := healthMonitorIDs[healthMonitorName]
+			if !ok {
+				return fmt.Errorf("No health monitor named '%s' is available in network domain '%s'.", healthMonitorName, configuration.NetworkDomainID)
+			}
+			poolConfiguration.HealthMonitorIDs = append(poolConfiguration.HealthMonitorIDs, healthMonitorID)
+		}
+	}
+
+	poolID, err := client.CreateVIPPool(poolConfiguration)
+	if err != nil {
+		return err
+	}
+	provisioner.service.PoolID = poolID
+	provisioner.created(func() error {
+		return client.DeleteVIPPool(poolID)
+	})
+
+	return provisioner.waitForDeploy(ResourceTypeVIPPool, poolID)
+}
+
+func (provisioner *loadBalancedServiceProvisioner) addVIPPoolMembers() error {
+	client := provisioner.client
+	configuration := provisioner.configuration
+
+	for index, backend := range configuration.Backends {
+		port := configuration.Port
+		if backend.Port != nil {
+			port = *backend.Port
+		}
+
+		memberID, err := client.AddVIPPoolMember(provisioner.service.PoolID, provisioner.service.NodeIDs[index], VIPNodeStatusEnabled, &port)
+		if err != nil {
+			return err
+		}
+		provisioner.service.PoolMemberIDs = append(provisioner.service.PoolMemberIDs, memberID)
+		provisioner.created(func() error {
+			return client.RemoveVIPPoolMember(memberID)
+		})
+
+		err = provisioner.waitForDeploy(ResourceTypeVIPPoolMember, memberID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (provisioner *loadBalancedServiceProvisioner) createVirtualListener() error {
+	client := provisioner.client
+	configuration := provisioner.configuration
+
+	listenerIPAddress := configuration.ListenerIPAddress
+	if listenerIPAddress == "" {
+		allocation, err := client.AllocatePublicIPv4Address(configuration.NetworkDomainID, configuration.Timeout)
+		if err != nil {
+			return err
+		}
+		if allocation.AddedBlock {
+			provisioner.service.CreatedPublicIPBlockID = allocation.BlockID
+			provisioner.created(func() error {
+				_, err := client.RemovePublicIPBlockIfUnused(allocation.BlockID)
+
+				return err
+			})
+		}
+		listenerIPAddress = allocation.Address
+	}
+
+	poolID := provisioner.service.PoolID
+	listenerConfiguration := NewVirtualListenerConfiguration{
+		Name:                   configuration.Name,
+		Description:            configuration.Description,
+		Type:                   VirtualListenerTypeStandard,
+		Protocol:               configuration.Protocol,
+		ListenerIPAddress:      &listenerIPAddress,
+		Port:                   configuration.Port,
+		Enabled:                true,
+		ConnectionLimit:        defaultLoadBalancedServiceListenerConnectionLimit,
+		ConnectionRateLimit:    defaultLoadBalancedServiceListenerConnectionRateLimit,
+		SourcePortPreservation: SourcePortPreservationEnabled,
+		PoolID:                 &poolID,
+		NetworkDomainID:        configuration.NetworkDomainID,
+	}
+	if configuration.PersistenceProfile != "" {
+		persistenceProfiles, err := client.listAllDefaultPersistenceProfiles(configuration.NetworkDomainID)
+		if err != nil {
+			return err
+		}
+		for _, persistenceProfile := range persistenceProfiles {
+			if persistenceProfile.Name == configuration.PersistenceProfile {
+				persistenceProfileID := persistenceProfile.ID
+				listenerConfiguration.PersistenceProfileID = &persistenceProfileID
+
+				break
+			}
+		}
+		if listenerConfiguration.PersistenceProfileID == nil {
+			return fmt.Errorf("No persistence profile named '%s' is available in network domain '%s'.", configuration.PersistenceProfile, configuration.NetworkDomainID)
+		}
+	}
+
+	listenerID, err := client.CreateVirtualListener(listenerConfiguration)
+	if err != nil {
+		return err
+	}
+	provisioner.service.ListenerID = listenerID
+	provisioner.service.ListenerIPAddress = listenerIPAddress
+	provisioner.created(func() error {
+		return client.DeleteVirtualListener(listenerID)
+	})
+
+	return provisioner.waitForDeploy(ResourceTypeVirtualListener, listenerID)
+}
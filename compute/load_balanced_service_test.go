@@ -0,0 +1,246 @@
+package compute
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// Provision load-balanced service (existing VIP node is reused for the first backend).
+func TestClient_ProvisionLoadBalancedService_Success(test *testing.T) {
+	expect := expect(test)
+
+	var createdNodes, addedMembers int
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			service, err := client.ProvisionLoadBalancedService(testLoadBalancedServiceConfiguration())
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.EqualsInt("Service.NodeIDs.Length", 2, len(service.NodeIDs))
+			expect.EqualsString("Service.NodeIDs[0]", "34de6ed6-46a4-4dae-a753-2f8d3840c6f9", service.NodeIDs[0])
+			expect.EqualsString("Service.NodeIDs[1]", "9e6b496d-5261-4542-91aa-b50c7f569c54", service.NodeIDs[1])
+			expect.EqualsInt("Service.CreatedNodeIDs.Length", 1, len(service.CreatedNodeIDs))
+			expect.EqualsString("Service.PoolID", "4d360b1f-bc2c-4ab7-9884-1f03ba2768f7", service.PoolID)
+			expect.EqualsInt("Service.PoolMemberIDs.Length", 2, len(service.PoolMemberIDs))
+			expect.EqualsString("Service.ListenerID", "43a445f1-9ac9-4f13-8b0d-a2d1fad231c3", service.ListenerID)
+			expect.EqualsString("Service.ListenerIPAddress", "165.180.12.13", service.ListenerIPAddress)
+			expect.EqualsString("Service.CreatedPublicIPBlockID", "", service.CreatedPublicIPBlockID)
+
+			expect.EqualsInt("VIP nodes created", 1, createdNodes)
+			expect.EqualsInt("VIP pool members added", 2, addedMembers)
+		},
+		Respond: testRespondToLoadBalancedServiceRequest(test, func(request *http.Request) {
+			switch {
+			case strings.HasSuffix(request.URL.Path, "/networkDomainVip/createNode"):
+				createdNodes++
+			case strings.HasSuffix(request.URL.Path, "/networkDomainVip/addPoolMember"):
+				addedMembers++
+			}
+		}),
+	})
+}
+
+// Provision load-balanced service (virtual listener creation fails, so created resources are deleted).
+func TestClient_ProvisionLoadBalancedService_RollBack(test *testing.T) {
+	expect := expect(test)
+
+	var deletedNodes, removedMembers, deletedPools int
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			_, err := client.ProvisionLoadBalancedService(testLoadBalancedServiceConfiguration())
+			expect.NotNil("ProvisionLoadBalancedService error", err)
+
+			serviceError, ok := err.(*LoadBalancedServiceError)
+			expect.IsTrue("Error is LoadBalancedServiceError", ok)
+			expect.EqualsString("LoadBalancedServiceError.FailedStep", "Create virtual listener", serviceError.FailedStep)
+			expect.IsTrue("LoadBalancedServiceError.RolledBack", serviceError.RolledBack)
+			expect.IsTrue("Error message mentions rollback", strings.Contains(err.Error(), "all created resources were deleted"))
+
+			// The existing VIP node is not deleted.
+			expect.EqualsInt("VIP pool members removed", 2, removedMembers)
+			expect.EqualsInt("VIP pools deleted", 1, deletedPools)
+			expect.EqualsInt("VIP nodes deleted", 1, deletedNodes)
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			switch {
+			case strings.HasSuffix(request.URL.Path, "/networkDomainVip/createVirtualListener"):
+				return http.StatusBadRequest, resourceBusyTestResponse
+			case strings.HasSuffix(request.URL.Path, "/networkDomainVip/removePoolMember"):
+				removedMembers++
+			case strings.HasSuffix(request.URL.Path, "/networkDomainVip/deletePool"):
+				deletedPools++
+			case strings.HasSuffix(request.URL.Path, "/networkDomainVip/deleteNode"):
+				deletedNodes++
+			}
+
+			return testRespondToLoadBalancedServiceRequest(test, nil)(test, request)
+		},
+	})
+}
+
+// Provision load-balanced service (invalid configuration).
+func TestClient_ProvisionLoadBalancedService_Invalid(test *testing.T) {
+	expect := expect(test)
+
+	client := NewClient("au1", "user1", "password")
+
+	configuration := testLoadBalancedServiceConfiguration()
+	configuration.Backends = nil
+	_, err := client.ProvisionLoadBalancedService(configuration)
+	expect.NotNil("ProvisionLoadBalancedService error (no backends)", err)
+
+	configuration = testLoadBalancedServiceConfiguration()
+	configuration.Backends[1].IPv4Address = "10.0.3"
+	_, err = client.ProvisionLoadBalancedService(configuration)
+	expect.NotNil("ProvisionLoadBalancedService error (invalid address)", err)
+	expect.IsTrue("Error message mentions backend", strings.Contains(err.Error(), "'Web Node 2'"))
+
+	configuration = testLoadBalancedServiceConfiguration()
+	configuration.Backends[1].Name = "Web Node 1"
+	_, err = client.ProvisionLoadBalancedService(configuration)
+	expect.NotNil("ProvisionLoadBalancedService error (duplicate backend name)", err)
+}
+
+// Tear down load-balanced service (only resources created by ProvisionLoadBalancedService are deleted).
+func TestClient_TeardownLoadBalancedService(test *testing.T) {
+	expect := expect(test)
+
+	var deleted []string
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			err := client.TeardownLoadBalancedService(&LoadBalancedService{
+				Name:              "Web",
+				NetworkDomainID:   "484174a2-ae74-4658-9e56-50fc90e086cf",
+				NodeIDs:           []string{"34de6ed6-46a4-4dae-a753-2f8d3840c6f9", "9e6b496d-5261-4542-91aa-b50c7f569c54"},
+				CreatedNodeIDs:    []string{"9e6b496d-5261-4542-91aa-b50c7f569c54"},
+				PoolID:            "4d360b1f-bc2c-4ab7-9884-1f03ba2768f7",
+				PoolMemberIDs:     []string{"3dd806a2-c2c8-4c0c-9a4f-5219ea9266c0"},
+				ListenerID:        "43a445f1-9ac9-4f13-8b0d-a2d1fad231c3",
+				ListenerIPAddress: "165.180.12.13",
+			})
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.EqualsString("Deletion order", "deleteVirtualListener,removePoolMember,deletePool,deleteNode", strings.Join(deleted, ","))
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			pathSegments := strings.Split(request.URL.Path, "/")
+			deleted = append(deleted, pathSegments[len(pathSegments)-1])
+
+			return http.StatusOK, loadBalancedServiceDeleteTestResponse
+		},
+	})
+}
+
+// Configuration for a load-balanced service with 2 backends in the network domain from exportNetworkDomainTestResponses.
+func testLoadBalancedServiceConfiguration() LoadBalancedServiceConfiguration {
+	return LoadBalancedServiceConfiguration{
+		Name:            "Web",
+		NetworkDomainID: "484174a2-ae74-4658-9e56-50fc90e086cf",
+		Backends: []LoadBalancedServiceBackend{
+			{Name: "Web Node 1", IPv4Address: "10.0.3.17"},
+			{Name: "Web Node 2", IPv4Address: "10.0.3.18"},
+		},
+		Port:              80,
+		Protocol:          VirtualListenerStandardProtocolHTTP,
+		HealthMonitors:    []string{"CCDEFAULT.Http"},
+		ListenerIPAddress: "165.180.12.13",
+	}
+}
+
+// Respond to requests made by ProvisionLoadBalancedService (all created resources are immediately deployed).
+//
+// If onRequest is not nil, it is called for each request.
+func testRespondToLoadBalancedServiceRequest(test *testing.T, onRequest func(request *http.Request)) ClientTestResponder {
+	return func(test *testing.T, request *http.Request) (int, string) {
+		if onRequest != nil {
+			onRequest(request)
+		}
+
+		switch {
+		case strings.HasSuffix(request.URL.Path, "/networkDomainVip/node"):
+			return http.StatusOK, exportNetworkDomainTestResponses["/networkDomainVip/node"]
+		case strings.HasSuffix(request.URL.Path, "/networkDomainVip/defaultHealthMonitor"):
+			return http.StatusOK, loadBalancedServiceListDefaultHealthMonitorsTestResponse
+		case strings.HasSuffix(request.URL.Path, "/networkDomainVip/createNode"):
+			return http.StatusOK, createVIPNodeTestResponse
+		case strings.HasSuffix(request.URL.Path, "/networkDomainVip/node/9e6b496d-5261-4542-91aa-b50c7f569c54"):
+			return http.StatusOK, `{ "id": "9e6b496d-5261-4542-91aa-b50c7f569c54", "name": "Web Node 2", "ipv4Address": "10.0.3.18", "state": "NORMAL" }`
+		case strings.HasSuffix(request.URL.Path, "/networkDomainVip/createPool"):
+			return http.StatusOK, createVIPPoolTestResponse
+		case strings.HasSuffix(request.URL.Path, "/networkDomainVip/pool/4d360b1f-bc2c-4ab7-9884-1f03ba2768f7"):
+			return http.StatusOK, `{ "id": "4d360b1f-bc2c-4ab7-9884-1f03ba2768f7", "name": "Web", "state": "NORMAL" }`
+		case strings.HasSuffix(request.URL.Path, "/networkDomainVip/addPoolMember"):
+			return http.StatusOK, loadBalancedServiceAddPoolMemberTestResponse
+		case strings.HasSuffix(request.URL.Path, "/networkDomainVip/poolMember/3dd806a2-c2c8-4c0c-9a4f-5219ea9266c0"):
+			return http.StatusOK, `{ "id": "3dd806a2-c2c8-4c0c-9a4f-5219ea9266c0", "pool": { "id": "4d360b1f-bc2c-4ab7-9884-1f03ba2768f7" }, "state": "NORMAL" }`
+		case strings.HasSuffix(request.URL.Path, "/networkDomainVip/createVirtualListener"):
+			return http.StatusOK, loadBalancedServiceCreateVirtualListenerTestResponse
+		case strings.HasSuffix(request.URL.Path, "/networkDomainVip/virtualListener/43a445f1-9ac9-4f13-8b0d-a2d1fad231c3"):
+			return http.StatusOK, `{ "id": "43a445f1-9ac9-4f13-8b0d-a2d1fad231c3", "name": "Web", "listenerIpAddress": "165.180.12.13", "state": "NORMAL" }`
+		case strings.HasSuffix(request.URL.Path, "/networkDomainVip/removePoolMember"),
+			strings.HasSuffix(request.URL.Path, "/networkDomainVip/deletePool"),
+			strings.HasSuffix(request.URL.Path, "/networkDomainVip/deleteNode"):
+			return http.StatusOK, loadBalancedServiceDeleteTestResponse
+		}
+
+		test.Fatalf("Unexpected request: %s %s", request.Method, request.URL.Path)
+
+		return http.StatusNotFound, ""
+	}
+}
+
+const loadBalancedServiceListDefaultHealthMonitorsTestResponse = `
+	{
+		"defaultHealthMonitor": [
+			{ "id": "0168b83a-d487-11e4-811f-005056806999", "name": "CCDEFAULT.Icmp", "nodeCompatible": true, "poolCompatible": false },
+			{ "id": "01683574-d487-11e4-811f-005056806999", "name": "CCDEFAULT.Http", "nodeCompatible": false, "poolCompatible": true }
+		],
+		"pageNumber": 1, "pageCount": 1, "totalCount": 2, "pageSize": 50
+	}
+`
+
+const loadBalancedServiceAddPoolMemberTestResponse = `
+	{
+		"operation": "ADD_POOL_MEMBER",
+		"responseCode": "OK",
+		"message": "Pool Member added.",
+		"info": [
+			{ "name": "poolMemberId", "value": "3dd806a2-c2c8-4c0c-9a4f-5219ea9266c0" }
+		],
+		"warning": [],
+		"error": [],
+		"requestId": "na9_20160321T074626030-0400_7e9fffe7-190b-46f2-9107-9d52fe57d0ad"
+	}
+`
+
+const loadBalancedServiceCreateVirtualListenerTestResponse = `
+	{
+		"operation": "CREATE_VIRTUAL_LISTENER",
+		"responseCode": "OK",
+		"message": "Virtual Listener 'Web' has been created on Public IP Address 165.180.12.13.",
+		"info": [
+			{ "name": "virtualListenerId", "value": "43a445f1-9ac9-4f13-8b0d-a2d1fad231c3" },
+			{ "name": "name", "value": "Web" },
+			{ "name": "listenerIpAddress", "value": "165.180.12.13" }
+		],
+		"warning": [],
+		"error": [],
+		"requestId": "na9_20160321T074626030-0400_7e9fffe7-190b-46f2-9107-9d52fe57d0ad"
+	}
+`
+
+const loadBalancedServiceDeleteTestResponse = `
+	{
+		"operation": "DELETE",
+		"responseCode": "OK",
+		"message": "Resource has been deleted.",
+		"info": [],
+		"warning": [],
+		"error": [],
+		"requestId": "na9_20160321T074626030-0400_7e9fffe7-190b-46f2-9107-9d52fe57d0ad"
+	}
+`
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 )
 
 // NATRule represents a Network Address Translation (NAT) rule.
@@ -18,6 +19,33 @@ type NATRule struct {
 	DataCenterID      string `json:"datacenterId"`
 }
 
+// GetID returns the NAT rule's Id.
+func (rule *NATRule) GetID() string {
+	return rule.ID
+}
+
+// GetResourceType returns the NAT rule's resource type.
+func (rule *NATRule) GetResourceType() ResourceType {
+	return ResourceTypeNATRule
+}
+
+// GetName returns the NAT rule's name (its external and internal IPv4 addresses).
+func (rule *NATRule) GetName() string {
+	return fmt.Sprintf("%s -> %s", rule.ExternalIPAddress, rule.InternalIPAddress)
+}
+
+// GetState returns the NAT rule's current state.
+func (rule *NATRule) GetState() string {
+	return rule.State
+}
+
+// IsDeleted determines whether the NAT rule has been deleted (is nil).
+func (rule *NATRule) IsDeleted() bool {
+	return rule == nil
+}
+
+var _ Resource = &NATRule{}
+
 // NATRules represents a page of NATRule results.
 type NATRules struct {
 	Rules []NATRule `json:"natRule"`
@@ -81,14 +109,23 @@ func (client *Client) GetNATRule(id string) (rule *NATRule, err error) {
 
 // ListNATRules retrieves all NAT rules defined for the specified network domain.
 func (client *Client) ListNATRules(networkDomainID string, paging *Paging) (rules *NATRules, err error) {
+	return client.listNATRules(networkDomainID, "", paging)
+}
+
+// List the NAT rules in the specified network domain, optionally filtered by additional query parameters (e.g. "internalIp=10.0.3.17").
+func (client *Client) listNATRules(networkDomainID string, filter string, paging *Paging) (rules *NATRules, err error) {
 	organizationID, err := client.getOrganizationID()
 	if err != nil {
 		return nil, err
 	}
 
-	requestURI := fmt.Sprintf("%s/network/natRule?networkDomainId=%s&%s",
+	if filter != "" {
+		filter = "&" + filter
+	}
+	requestURI := fmt.Sprintf("%s/network/natRule?networkDomainId=%s%s&%s",
 		organizationID,
 		networkDomainID,
+		filter,
 		paging.EnsurePaging().toQueryParameters(),
 	)
 	request, err := client.newRequestV22(requestURI, http.MethodGet, nil)
@@ -118,6 +155,166 @@ func (client *Client) ListNATRules(networkDomainID string, paging *Paging) (rule
 	return rules, err
 }
 
+// listAllNATRules retrieves all NAT rules in the specified network domain (across all pages of results).
+func (client *Client) listAllNATRules(networkDomainID string) (rules []NATRule, err error) {
+	paging := DefaultPaging()
+	for {
+		var page *NATRules
+		page, err = client.ListNATRules(networkDomainID, paging)
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, page.Rules...)
+		if page.IsLastPage() {
+			break
+		}
+
+		paging.Next()
+	}
+
+	return rules, nil
+}
+
+// GetNATRuleByInternalIPAddress retrieves the NAT rule (if any) in the specified network domain that forwards traffic to the specified internal IPv4 address.
+// Returns nil if no NAT rule is found for the internal address.
+func (client *Client) GetNATRuleByInternalIPAddress(networkDomainID string, internalIPAddress string) (rule *NATRule, err error) {
+	return client.findNATRule(networkDomainID, "internalIp", internalIPAddress, func(rule *NATRule) string {
+		return rule.InternalIPAddress
+	})
+}
+
+// GetNATRuleByExternalIPAddress retrieves the NAT rule (if any) in the specified network domain that forwards traffic from the specified external IPv4 address.
+// Returns nil if no NAT rule is found for the external address.
+func (client *Client) GetNATRuleByExternalIPAddress(networkDomainID string, externalIPAddress string) (rule *NATRule, err error) {
+	return client.findNATRule(networkDomainID, "externalIp", externalIPAddress, func(rule *NATRule) string {
+		return rule.ExternalIPAddress
+	})
+}
+
+// Find the first NAT rule in the specified network domain where the specified field has the specified value.
+//
+// The list is filtered by the API, but each rule is checked again in case the filter was not applied.
+func (client *Client) findNATRule(networkDomainID string, fieldName string, value string, getField func(rule *NATRule) string) (*NATRule, error) {
+	filter := fmt.Sprintf("%s=%s", fieldName, url.QueryEscape(value))
+
+	paging := DefaultPaging()
+	for {
+		page, err := client.listNATRules(networkDomainID, filter, paging)
+		if err != nil {
+			return nil, err
+		}
+
+		for index := range page.Rules {
+			rule := &page.Rules[index]
+			if getField(rule) == value {
+				return rule, nil
+			}
+		}
+		if page.IsLastPage() {
+			break
+		}
+
+		paging.Next()
+	}
+
+	return nil, nil
+}
+
+// NATRuleConflictError is the error returned by EnsureNATRule when an existing NAT rule conflicts with the requested one.
+type NATRuleConflictError struct {
+	// The Id of the network domain.
+	NetworkDomainID string
+
+	// The requested internal IPv4 address.
+	InternalIPAddress string
+
+	// The requested external IPv4 address (empty if any external address was acceptable).
+	ExternalIPAddress string
+
+	// The existing NAT rule that conflicts with the requested one.
+	ConflictingRule NATRule
+}
+
+// Error returns the error message associated with the NATRuleConflictError.
+func (conflictError *NATRuleConflictError) Error() string {
+	if conflictError.ConflictingRule.InternalIPAddress == conflictError.InternalIPAddress {
+		return fmt.Sprintf("Cannot map external IPv4 address '%s' to internal IPv4 address '%s' in network domain '%s' because NAT rule '%s' already maps it to external IPv4 address '%s'.",
+			conflictError.ExternalIPAddress,
+			conflictError.InternalIPAddress,
+			conflictError.NetworkDomainID,
+			conflictError.ConflictingRule.ID,
+			conflictError.ConflictingRule.ExternalIPAddress,
+		)
+	}
+
+	return fmt.Sprintf("Cannot map external IPv4 address '%s' to internal IPv4 address '%s' in network domain '%s' because NAT rule '%s' already maps it to internal IPv4 address '%s'.",
+		conflictError.ExternalIPAddress,
+		conflictError.InternalIPAddress,
+		conflictError.NetworkDomainID,
+		conflictError.ConflictingRule.ID,
+		conflictError.ConflictingRule.InternalIPAddress,
+	)
+}
+
+var _ error = &NATRuleConflictError{}
+
+// EnsureNATRule retrieves the NAT rule that forwards traffic from the specified external IPv4 address to the specified internal IPv4 address, creating it if it does not already exist.
+// If externalIPAddress is not specified, any existing NAT rule for the internal address is acceptable (and, if one must be created, an unallocated IPv4 address will be used).
+//
+// Returns a *NATRuleConflictError if the internal address is already mapped to a different external address, or the external address is already mapped to a different internal address.
+func (client *Client) EnsureNATRule(networkDomainID string, internalIPAddress string, externalIPAddress *string) (rule *NATRule, created bool, err error) {
+	rule, err = client.GetNATRuleByInternalIPAddress(networkDomainID, internalIPAddress)
+	if err != nil {
+		return nil, false, err
+	}
+	if rule != nil {
+		if externalIPAddress != nil && rule.ExternalIPAddress != *externalIPAddress {
+			return nil, false, newNATRuleConflictError(networkDomainID, internalIPAddress, externalIPAddress, rule)
+		}
+
+		return rule, false, nil
+	}
+
+	if externalIPAddress != nil {
+		rule, err = client.GetNATRuleByExternalIPAddress(networkDomainID, *externalIPAddress)
+		if err != nil {
+			return nil, false, err
+		}
+		if rule != nil {
+			return nil, false, newNATRuleConflictError(networkDomainID, internalIPAddress, externalIPAddress, rule)
+		}
+	}
+
+	natRuleID, err := client.AddNATRule(networkDomainID, internalIPAddress, externalIPAddress)
+	if err != nil {
+		return nil, false, err
+	}
+
+	rule, err = client.GetNATRule(natRuleID)
+	if err != nil {
+		return nil, true, err
+	}
+	if rule == nil {
+		return nil, true, fmt.Errorf("No NAT rule was found with Id '%s'.", natRuleID)
+	}
+
+	return rule, true, nil
+}
+
+func newNATRuleConflictError(networkDomainID string, internalIPAddress string, externalIPAddress *string, conflictingRule *NATRule) *NATRuleConflictError {
+	conflictError := &NATRuleConflictError{
+		NetworkDomainID:   networkDomainID,
+		InternalIPAddress: internalIPAddress,
+		ConflictingRule:   *conflictingRule,
+	}
+	if externalIPAddress != nil {
+		conflictError.ExternalIPAddress = *externalIPAddress
+	}
+
+	return conflictError
+}
+
 // AddNATRule creates a new NAT rule to forward traffic from the specified external IPv4 address to the specified internal IPv4 address.
 // If externalIPAddress is not specified, an unallocated IPv4 address will be used (if available).
 //
@@ -0,0 +1,149 @@
+package compute
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// Get NAT rule by internal / external IPv4 address.
+func TestClient_GetNATRuleByIPAddress(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			rule, err := client.GetNATRuleByInternalIPAddress("484174a2-ae74-4658-9e56-50fc90e086cf", "10.0.3.17")
+			if err != nil {
+				test.Fatal(err)
+			}
+			expect.NotNil("NATRule (internal)", rule)
+			expect.EqualsString("NATRule.ID (internal)", "2169a38e-5692-497e-a22a-701a838a6539", rule.ID)
+
+			rule, err = client.GetNATRuleByExternalIPAddress("484174a2-ae74-4658-9e56-50fc90e086cf", "165.180.12.12")
+			if err != nil {
+				test.Fatal(err)
+			}
+			expect.NotNil("NATRule (external)", rule)
+			expect.EqualsString("NATRule.InternalIPAddress (external)", "10.0.3.17", rule.InternalIPAddress)
+
+			rule, err = client.GetNATRuleByInternalIPAddress("484174a2-ae74-4658-9e56-50fc90e086cf", "10.0.3.18")
+			if err != nil {
+				test.Fatal(err)
+			}
+			expect.IsTrue("NATRule (not found) is nil", rule == nil)
+		},
+		Respond: testRespondToNATRuleRequest(test, nil),
+	})
+}
+
+// Ensure NAT rule (rule already exists).
+func TestClient_EnsureNATRule_Existing(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			externalIPAddress := "165.180.12.12"
+			rule, created, err := client.EnsureNATRule("484174a2-ae74-4658-9e56-50fc90e086cf", "10.0.3.17", &externalIPAddress)
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.IsFalse("Created", created)
+			expect.EqualsString("NATRule.ID", "2169a38e-5692-497e-a22a-701a838a6539", rule.ID)
+
+			rule, created, err = client.EnsureNATRule("484174a2-ae74-4658-9e56-50fc90e086cf", "10.0.3.17", nil)
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.IsFalse("Created (any external address)", created)
+			expect.EqualsString("NATRule.ExternalIPAddress (any external address)", "165.180.12.12", rule.ExternalIPAddress)
+		},
+		Respond: testRespondToNATRuleRequest(test, nil),
+	})
+}
+
+// Ensure NAT rule (rule does not exist, so it is created).
+func TestClient_EnsureNATRule_Create(test *testing.T) {
+	expect := expect(test)
+
+	var createdRules int
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			externalIPAddress := "165.180.12.13"
+			rule, created, err := client.EnsureNATRule("484174a2-ae74-4658-9e56-50fc90e086cf", "10.0.3.18", &externalIPAddress)
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.IsTrue("Created", created)
+			expect.EqualsInt("Rules created", 1, createdRules)
+			expect.EqualsString("NATRule.ID", "2169a38e-5692-497e-a22a-701a838a6539", rule.ID)
+		},
+		Respond: testRespondToNATRuleRequest(test, func(request *http.Request) {
+			if strings.HasSuffix(request.URL.Path, "/network/createNatRule") {
+				createdRules++
+			}
+		}),
+	})
+}
+
+// Ensure NAT rule (conflicting rules exist).
+func TestClient_EnsureNATRule_Conflict(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			// Internal address is already mapped to a different external address.
+			externalIPAddress := "165.180.12.13"
+			_, _, err := client.EnsureNATRule("484174a2-ae74-4658-9e56-50fc90e086cf", "10.0.3.17", &externalIPAddress)
+			expect.NotNil("EnsureNATRule error (internal address)", err)
+
+			conflictError, ok := err.(*NATRuleConflictError)
+			expect.IsTrue("Error is NATRuleConflictError (internal address)", ok)
+			expect.EqualsString("NATRuleConflictError.ConflictingRule.ID", "2169a38e-5692-497e-a22a-701a838a6539", conflictError.ConflictingRule.ID)
+			expect.IsTrue("Error message mentions existing external address", strings.Contains(err.Error(), "'165.180.12.12'"))
+
+			// External address is already mapped to a different internal address.
+			externalIPAddress = "165.180.12.12"
+			_, _, err = client.EnsureNATRule("484174a2-ae74-4658-9e56-50fc90e086cf", "10.0.3.18", &externalIPAddress)
+			expect.NotNil("EnsureNATRule error (external address)", err)
+
+			_, ok = err.(*NATRuleConflictError)
+			expect.IsTrue("Error is NATRuleConflictError (external address)", ok)
+			expect.IsTrue("Error message mentions existing internal address", strings.Contains(err.Error(), "'10.0.3.17'"))
+		},
+		Respond: testRespondToNATRuleRequest(test, nil),
+	})
+}
+
+// Respond to NAT rule requests (the network domain contains a single NAT rule, 165.180.12.12 -> 10.0.3.17).
+//
+// If onRequest is not nil, it is called for each request.
+func testRespondToNATRuleRequest(test *testing.T, onRequest func(request *http.Request)) ClientTestResponder {
+	return func(test *testing.T, request *http.Request) (int, string) {
+		if onRequest != nil {
+			onRequest(request)
+		}
+
+		switch {
+		case strings.HasSuffix(request.URL.Path, "/network/natRule"):
+			query := request.URL.Query()
+			internalIPAddress := query.Get("internalIp")
+			externalIPAddress := query.Get("externalIp")
+			if (internalIPAddress != "" && internalIPAddress != "10.0.3.17") || (externalIPAddress != "" && externalIPAddress != "165.180.12.12") {
+				return http.StatusOK, `{ "natRule": [], "pageNumber": 1, "pageCount": 0, "totalCount": 0, "pageSize": 250 }`
+			}
+
+			return http.StatusOK, exportNetworkDomainTestResponses["/network/natRule"]
+		case strings.HasSuffix(request.URL.Path, "/network/natRule/2169a38e-5692-497e-a22a-701a838a6539"):
+			return http.StatusOK, `{ "id": "2169a38e-5692-497e-a22a-701a838a6539", "internalIp": "10.0.3.18", "externalIp": "165.180.12.13", "state": "NORMAL" }`
+		case strings.HasSuffix(request.URL.Path, "/network/createNatRule"):
+			return http.StatusOK, exposeServerCreateNATRuleTestResponse
+		}
+
+		test.Fatalf("Unexpected request: %s %s", request.Method, request.URL.Path)
+
+		return http.StatusNotFound, ""
+	}
+}
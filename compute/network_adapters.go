@@ -0,0 +1,229 @@
+package compute
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+const (
+	// NetworkAdapterTypeE1000 represents an E1000 (emulated Intel 82545EM) network adapter.
+	NetworkAdapterTypeE1000 = "E1000"
+
+	// NetworkAdapterTypeE1000E represents an E1000E (emulated Intel 82574) network adapter.
+	NetworkAdapterTypeE1000E = "E1000E"
+
+	// NetworkAdapterTypeVMXNET3 represents a VMXNET3 (paravirtualised) network adapter.
+	NetworkAdapterTypeVMXNET3 = "VMXNET3"
+)
+
+// exchangeNicVLANs represents the request body when exchanging the VLANs of two network adapters.
+type exchangeNicVLANs struct {
+	// The Id of the first network adapter.
+	NicID1 string `json:"nicId1"`
+
+	// The Id of the second network adapter.
+	NicID2 string `json:"nicId2"`
+}
+
+// changeNetworkAdapterType represents the request body when changing the type of a network adapter.
+type changeNetworkAdapterType struct {
+	// The network adapter Id.
+	NicID string `json:"nicId"`
+
+	// The new network adapter type.
+	AdapterType string `json:"networkAdapter"`
+}
+
+// networkAdapterConnection represents the request body when connecting or disconnecting a network adapter.
+type networkAdapterConnection struct {
+	// The network adapter Id.
+	NicID string `json:"nicId"`
+}
+
+// ExchangeNetworkAdapterVLANs swaps the VLANs (and IP addresses) of 2 network adapters on the same server.
+func (client *Client) ExchangeNetworkAdapterVLANs(networkAdapterID1 string, networkAdapterID2 string) error {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return err
+	}
+
+	requestURI := fmt.Sprintf("%s/server/exchangeNicVlans", organizationID)
+	request, err := client.newRequestV24(requestURI, http.MethodPost, &exchangeNicVLANs{
+		NicID1: networkAdapterID1,
+		NicID2: networkAdapterID2,
+	})
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return err
+	}
+
+	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
+	if err != nil {
+		return err
+	}
+
+	if apiResponse.ResponseCode != ResponseCodeInProgress {
+		return apiResponse.ToError("Request to exchange VLANs of network adapters '%s' and '%s' failed with unexpected status code %d (%s): %s", networkAdapterID1, networkAdapterID2, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+	}
+
+	return nil
+}
+
+// ChangeNetworkAdapterType changes the type of a network adapter.
+// adapterType must be one of NetworkAdapterTypeE1000, NetworkAdapterTypeE1000E, or NetworkAdapterTypeVMXNET3.
+func (client *Client) ChangeNetworkAdapterType(networkAdapterID string, adapterType string) error {
+	if !isValidNetworkAdapterType(adapterType) {
+		return fmt.Errorf("'%s' is not a valid network adapter type (must be '%s', '%s', or '%s').", adapterType, NetworkAdapterTypeE1000, NetworkAdapterTypeE1000E, NetworkAdapterTypeVMXNET3)
+	}
+
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return err
+	}
+
+	requestURI := fmt.Sprintf("%s/server/changeNetworkAdapter", organizationID)
+	request, err := client.newRequestV24(requestURI, http.MethodPost, &changeNetworkAdapterType{
+		NicID:       networkAdapterID,
+		AdapterType: adapterType,
+	})
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return err
+	}
+
+	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
+	if err != nil {
+		return err
+	}
+
+	if apiResponse.ResponseCode != ResponseCodeInProgress {
+		return apiResponse.ToError("Request to change type of network adapter '%s' failed with unexpected status code %d (%s): %s", networkAdapterID, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+	}
+
+	return nil
+}
+
+// ConnectNetworkAdapter connects a network adapter (i.e. plugs in its virtual network cable).
+func (client *Client) ConnectNetworkAdapter(networkAdapterID string) error {
+	return client.setNetworkAdapterConnection(networkAdapterID, "connectNic", "connect")
+}
+
+// DisconnectNetworkAdapter disconnects a network adapter (i.e. unplugs its virtual network cable) without removing it from the server.
+func (client *Client) DisconnectNetworkAdapter(networkAdapterID string) error {
+	return client.setNetworkAdapterConnection(networkAdapterID, "disconnectNic", "disconnect")
+}
+
+// Connect or disconnect a network adapter.
+func (client *Client) setNetworkAdapterConnection(networkAdapterID string, operationName string, operationDescription string) error {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return err
+	}
+
+	requestURI := fmt.Sprintf("%s/server/%s", organizationID, operationName)
+	request, err := client.newRequestV24(requestURI, http.MethodPost, &networkAdapterConnection{networkAdapterID})
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return err
+	}
+
+	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
+	if err != nil {
+		return err
+	}
+
+	if apiResponse.ResponseCode != ResponseCodeInProgress {
+		return apiResponse.ToError("Request to %s network adapter '%s' failed with unexpected status code %d (%s): %s", operationDescription, networkAdapterID, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+	}
+
+	return nil
+}
+
+// FindNetworkAdapterByIPv4Address finds the server network adapter (if any) in the specified network domain that has the specified private IPv4 address.
+// Returns nil for both server and network adapter if no matching network adapter was found.
+func (client *Client) FindNetworkAdapterByIPv4Address(networkDomainID string, ipv4Address string) (server *Server, networkAdapter *VirtualMachineNetworkAdapter, err error) {
+	targetAddress, err := parseIPv4Address(ipv4Address)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return client.findNetworkAdapter(networkDomainID, func(adapter *VirtualMachineNetworkAdapter) bool {
+		if adapter.PrivateIPv4Address == nil {
+			return false
+		}
+
+		adapterAddress := net.ParseIP(*adapter.PrivateIPv4Address)
+
+		return adapterAddress != nil && adapterAddress.Equal(targetAddress)
+	})
+}
+
+// FindNetworkAdapterByMACAddress finds the server network adapter (if any) in the specified network domain that has the specified MAC address.
+// Returns nil for both server and network adapter if no matching network adapter was found.
+func (client *Client) FindNetworkAdapterByMACAddress(networkDomainID string, macAddress string) (server *Server, networkAdapter *VirtualMachineNetworkAdapter, err error) {
+	targetAddress, err := net.ParseMAC(macAddress)
+	if err != nil {
+		return nil, nil, fmt.Errorf("'%s' is not a valid MAC address.", macAddress)
+	}
+
+	return client.findNetworkAdapter(networkDomainID, func(adapter *VirtualMachineNetworkAdapter) bool {
+		if adapter.MACAddress == nil {
+			return false
+		}
+
+		adapterAddress, err := net.ParseMAC(*adapter.MACAddress)
+		if err != nil {
+			return strings.EqualFold(*adapter.MACAddress, macAddress)
+		}
+
+		return adapterAddress.String() == targetAddress.String()
+	})
+}
+
+// Find the network adapter (primary or additional) on the server that satisfies the specified predicate.
+// Returns nil if no matching network adapter was found.
+func (server *Server) findNetworkAdapter(predicate func(adapter *VirtualMachineNetworkAdapter) bool) *VirtualMachineNetworkAdapter {
+	if predicate(&server.Network.PrimaryAdapter) {
+		return &server.Network.PrimaryAdapter
+	}
+
+	for index := range server.Network.AdditionalNetworkAdapters {
+		adapter := &server.Network.AdditionalNetworkAdapters[index]
+		if predicate(adapter) {
+			return adapter
+		}
+	}
+
+	return nil
+}
+
+// Find the first server network adapter in the specified network domain that satisfies the specified predicate.
+func (client *Client) findNetworkAdapter(networkDomainID string, predicate func(adapter *VirtualMachineNetworkAdapter) bool) (*Server, *VirtualMachineNetworkAdapter, error) {
+	servers, err := client.listAllServersInNetworkDomain(networkDomainID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for index := range servers {
+		server := &servers[index]
+
+		networkAdapter := server.findNetworkAdapter(predicate)
+		if networkAdapter != nil {
+			return server, networkAdapter, nil
+		}
+	}
+
+	return nil, nil, nil
+}
+
+// Determine whether the specified network adapter type is valid.
+func isValidNetworkAdapterType(adapterType string) bool {
+	switch adapterType {
+	case NetworkAdapterTypeE1000, NetworkAdapterTypeE1000E, NetworkAdapterTypeVMXNET3:
+		return true
+	default:
+		return false
+	}
+}
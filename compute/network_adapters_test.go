@@ -0,0 +1,258 @@
+package compute
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Exchange network adapter VLANs (successful).
+func TestClient_ExchangeNetworkAdapterVLANs_Success(test *testing.T) {
+	expect := expect(test)
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		expect.EqualsString("Request.URL", "/caas/2.4/dummy-organization-id/server/exchangeNicVlans", request.URL.Path)
+
+		requestBody, err := readRequestBodyAsString(request)
+		if err != nil {
+			test.Fatal(err.Error())
+		}
+
+		expect.EqualsString("Request.Body",
+			`{"nicId1":"5999db1d-725c-46ba-9d4e-d33991e61ab1","nicId2":"1d3ef2ae-8b4b-4d7a-a5a2-7f0d5c8e2b6f"}`,
+			requestBody,
+		)
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(writer, exchangeNetworkAdapterVLANsTestResponse)
+	}))
+	defer testServer.Close()
+
+	client := NewClient("au1", "user1", "password")
+	client.setBaseAddress(testServer.URL)
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	err := client.ExchangeNetworkAdapterVLANs("5999db1d-725c-46ba-9d4e-d33991e61ab1", "1d3ef2ae-8b4b-4d7a-a5a2-7f0d5c8e2b6f")
+	if err != nil {
+		test.Fatal(err)
+	}
+}
+
+// Change network adapter type (successful).
+func TestClient_ChangeNetworkAdapterType_Success(test *testing.T) {
+	expect := expect(test)
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		expect.EqualsString("Request.URL", "/caas/2.4/dummy-organization-id/server/changeNetworkAdapter", request.URL.Path)
+
+		requestBody, err := readRequestBodyAsString(request)
+		if err != nil {
+			test.Fatal(err.Error())
+		}
+
+		expect.EqualsString("Request.Body",
+			`{"nicId":"5999db1d-725c-46ba-9d4e-d33991e61ab1","networkAdapter":"VMXNET3"}`,
+			requestBody,
+		)
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(writer, changeNetworkAdapterTypeTestResponse)
+	}))
+	defer testServer.Close()
+
+	client := NewClient("au1", "user1", "password")
+	client.setBaseAddress(testServer.URL)
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	err := client.ChangeNetworkAdapterType("5999db1d-725c-46ba-9d4e-d33991e61ab1", NetworkAdapterTypeVMXNET3)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	err = client.ChangeNetworkAdapterType("5999db1d-725c-46ba-9d4e-d33991e61ab1", "RTL8139")
+	if err == nil {
+		test.Fatal("ChangeNetworkAdapterType should have failed (invalid adapter type).")
+	}
+}
+
+// Disconnect network adapter (successful).
+func TestClient_DisconnectNetworkAdapter_Success(test *testing.T) {
+	expect := expect(test)
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		expect.EqualsString("Request.URL", "/caas/2.4/dummy-organization-id/server/disconnectNic", request.URL.Path)
+
+		requestBody, err := readRequestBodyAsString(request)
+		if err != nil {
+			test.Fatal(err.Error())
+		}
+
+		expect.EqualsString("Request.Body",
+			`{"nicId":"5999db1d-725c-46ba-9d4e-d33991e61ab1"}`,
+			requestBody,
+		)
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(writer, disconnectNetworkAdapterTestResponse)
+	}))
+	defer testServer.Close()
+
+	client := NewClient("au1", "user1", "password")
+	client.setBaseAddress(testServer.URL)
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	err := client.DisconnectNetworkAdapter("5999db1d-725c-46ba-9d4e-d33991e61ab1")
+	if err != nil {
+		test.Fatal(err)
+	}
+}
+
+// Find network adapter by IPv4 and MAC address.
+func TestClient_FindNetworkAdapter(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			server, networkAdapter, err := client.FindNetworkAdapterByIPv4Address("484174a2-ae74-4658-9e56-50fc90e086cf", "10.0.3.18")
+			if err != nil {
+				test.Fatal(err)
+			}
+			expect.NotNil("Server", server)
+			expect.EqualsString("Server.Name", "Production Database Server", server.Name)
+			expect.NotNil("NetworkAdapter", networkAdapter)
+			expect.EqualsString("NetworkAdapter.ID", "1d3ef2ae-8b4b-4d7a-a5a2-7f0d5c8e2b6f", *networkAdapter.ID)
+			expect.EqualsString("NetworkAdapter.AdapterType", NetworkAdapterTypeE1000, *networkAdapter.AdapterType)
+
+			server, networkAdapter, err = client.FindNetworkAdapterByMACAddress("484174a2-ae74-4658-9e56-50fc90e086cf", "00:50:56:B3:1F:0A")
+			if err != nil {
+				test.Fatal(err)
+			}
+			expect.NotNil("Server", server)
+			expect.EqualsString("Server.Name", "Production Web Server", server.Name)
+			expect.NotNil("NetworkAdapter", networkAdapter)
+			expect.EqualsString("NetworkAdapter.ID", "5999db1d-725c-46ba-9d4e-d33991e61ab1", *networkAdapter.ID)
+
+			server, networkAdapter, err = client.FindNetworkAdapterByIPv4Address("484174a2-ae74-4658-9e56-50fc90e086cf", "10.0.3.99")
+			if err != nil {
+				test.Fatal(err)
+			}
+			expect.IsTrue("Server is nil", server == nil)
+			expect.IsTrue("NetworkAdapter is nil", networkAdapter == nil)
+		},
+		Respond: testRespondOK(findNetworkAdapterListServersTestResponse),
+	})
+}
+
+/*
+ * Test responses.
+ */
+
+const exchangeNetworkAdapterVLANsTestResponse = `
+	{
+		"operation": "EXCHANGE_NIC_VLANS",
+		"responseCode": "IN_PROGRESS",
+		"message": "Request to exchange VLANs for NICs 5999db1d-725c-46ba-9d4e-d33991e61ab1 and 1d3ef2ae-8b4b-4d7a-a5a2-7f0d5c8e2b6f has been accepted and is being processed.",
+		"info": [],
+		"warning": [],
+		"error": [],
+		"requestId": "au1_20170302T040121752-0500_c5d0e2f4-30a5-4a8b-9f0e-3b1e5e0d7b2a"
+	}
+`
+
+const changeNetworkAdapterTypeTestResponse = `
+	{
+		"operation": "CHANGE_NETWORK_ADAPTER",
+		"responseCode": "IN_PROGRESS",
+		"message": "Request to change Network Adapter type for NIC 5999db1d-725c-46ba-9d4e-d33991e61ab1 has been accepted and is being processed.",
+		"info": [],
+		"warning": [],
+		"error": [],
+		"requestId": "au1_20170302T040121752-0500_0a9f7e4c-6b52-4f5a-b3bb-5e2a8f1d4c90"
+	}
+`
+
+const disconnectNetworkAdapterTestResponse = `
+	{
+		"operation": "DISCONNECT_NIC",
+		"responseCode": "IN_PROGRESS",
+		"message": "Request to disconnect NIC 5999db1d-725c-46ba-9d4e-d33991e61ab1 has been accepted and is being processed.",
+		"info": [],
+		"warning": [],
+		"error": [],
+		"requestId": "au1_20170302T040121752-0500_6e1f2d3c-8a4b-4c5d-9e6f-7a8b9c0d1e2f"
+	}
+`
+
+const findNetworkAdapterListServersTestResponse = `
+	{
+		"server": [
+			{
+				"id": "5a32d6e4-9707-4813-a269-56ab4d989f4d",
+				"name": "Production Web Server",
+				"networkInfo": {
+					"primaryNic": {
+						"id": "5999db1d-725c-46ba-9d4e-d33991e61ab1",
+						"privateIpv4": "10.0.3.17",
+						"macAddress": "00:50:56:b3:1f:0a",
+						"networkAdapter": "VMXNET3",
+						"connected": true,
+						"vlanId": "0e56433f-d808-4669-821d-812769517ff8",
+						"state": "NORMAL"
+					},
+					"additionalNic": [],
+					"networkDomainId": "484174a2-ae74-4658-9e56-50fc90e086cf"
+				},
+				"started": true,
+				"deployed": true,
+				"state": "NORMAL"
+			},
+			{
+				"id": "0f1d6a3e-2b7c-4c2e-a5a1-3f9d8d6e7b41",
+				"name": "Production Database Server",
+				"networkInfo": {
+					"primaryNic": {
+						"id": "f2b1d3c4-5e6f-4a7b-8c9d-0e1f2a3b4c5d",
+						"privateIpv4": "10.0.3.16",
+						"macAddress": "00:50:56:b3:1f:0b",
+						"networkAdapter": "VMXNET3",
+						"connected": true,
+						"vlanId": "0e56433f-d808-4669-821d-812769517ff8",
+						"state": "NORMAL"
+					},
+					"additionalNic": [
+						{
+							"id": "1d3ef2ae-8b4b-4d7a-a5a2-7f0d5c8e2b6f",
+							"privateIpv4": "10.0.3.18",
+							"macAddress": "00:50:56:b3:1f:0c",
+							"networkAdapter": "E1000",
+							"connected": false,
+							"vlanId": "0e56433f-d808-4669-821d-812769517ff8",
+							"state": "NORMAL"
+						}
+					],
+					"networkDomainId": "484174a2-ae74-4658-9e56-50fc90e086cf"
+				},
+				"started": true,
+				"deployed": true,
+				"state": "NORMAL"
+			}
+		],
+		"pageNumber": 1,
+		"pageCount": 2,
+		"totalCount": 2,
+		"pageSize": 50
+	}
+`
@@ -0,0 +1,719 @@
+package compute
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// NetworkDomainExportFormatVersion is the version of the network domain export document format produced by ExportNetworkDomain.
+const NetworkDomainExportFormatVersion = 1
+
+// NetworkDomainExport is a portable document describing a network domain and everything in it.
+//
+// Resources in the document refer to each other by name (rather than Id), so the document can be used to re-create the network domain elsewhere.
+// The original Id of each resource is retained for auditing purposes.
+type NetworkDomainExport struct {
+	// The version of the document format (see NetworkDomainExportFormatVersion).
+	FormatVersion int `json:"formatVersion"`
+
+	// The date / time (RFC3339, UTC) when the network domain was exported.
+	ExportTime string `json:"exportTime"`
+
+	NetworkDomain     ExportedNetworkDomain      `json:"networkDomain"`
+	VLANs             []ExportedVLAN             `json:"vlans"`
+	Servers           []ExportedServer           `json:"servers"`
+	IPAddressLists    []ExportedIPAddressList    `json:"ipAddressLists"`
+	PortLists         []ExportedPortList         `json:"portLists"`
+	FirewallRules     []ExportedFirewallRule     `json:"firewallRules"`
+	PublicIPBlocks    []ExportedPublicIPBlock    `json:"publicIpBlocks"`
+	NATRules          []ExportedNATRule          `json:"natRules"`
+	VIPNodes          []ExportedVIPNode          `json:"vipNodes"`
+	VIPPools          []ExportedVIPPool          `json:"vipPools"`
+	VirtualListeners  []ExportedVirtualListener  `json:"virtualListeners"`
+	AntiAffinityRules []ExportedAntiAffinityRule `json:"antiAffinityRules"`
+}
+
+// ExportedNetworkDomain represents a network domain in a NetworkDomainExport.
+type ExportedNetworkDomain struct {
+	ID           string            `json:"id"`
+	Name         string            `json:"name"`
+	Description  string            `json:"description"`
+	Type         NetworkDomainType `json:"type"`
+	DatacenterID string            `json:"datacenterId"`
+	Tags         []Tag             `json:"tags,omitempty"`
+}
+
+// ExportedVLAN represents a VLAN in a NetworkDomainExport.
+type ExportedVLAN struct {
+	ID                    string                      `json:"id"`
+	Name                  string                      `json:"name"`
+	Description           string                      `json:"description"`
+	IPv4Range             IPv4Range                   `json:"ipv4Range"`
+	ReservedIPv4Addresses []ExportedReservedIPAddress `json:"reservedIpv4Addresses,omitempty"`
+	Tags                  []Tag                       `json:"tags,omitempty"`
+}
+
+// ExportedReservedIPAddress represents a reserved IP address in a NetworkDomainExport.
+type ExportedReservedIPAddress struct {
+	Address     string `json:"address"`
+	Description string `json:"description,omitempty"`
+}
+
+// ExportedServer represents a server in a NetworkDomainExport.
+type ExportedServer struct {
+	ID                string                   `json:"id"`
+	Name              string                   `json:"name"`
+	Description       string                   `json:"description"`
+	ImageID           string                   `json:"imageId"`
+	OperatingSystem   string                   `json:"operatingSystem"`
+	CPU               VirtualMachineCPU        `json:"cpu"`
+	MemoryGB          int                      `json:"memoryGb"`
+	Disks             []ExportedServerDisk     `json:"disks"`
+	NetworkAdapters   []ExportedNetworkAdapter `json:"networkAdapters"`
+	Started           bool                     `json:"started"`
+	BackupServicePlan string                   `json:"backupServicePlan,omitempty"`
+	MonitoringPlan    string                   `json:"monitoringPlan,omitempty"`
+	Tags              []Tag                    `json:"tags,omitempty"`
+}
+
+// ExportedServerDisk represents a server disk in a NetworkDomainExport.
+type ExportedServerDisk struct {
+	SCSIUnitID int    `json:"scsiUnitId"`
+	SizeGB     int    `json:"sizeGb"`
+	Speed      string `json:"speed"`
+}
+
+// ExportedNetworkAdapter represents a server network adapter in a NetworkDomainExport.
+type ExportedNetworkAdapter struct {
+	IsPrimary          bool   `json:"primary"`
+	VLAN               string `json:"vlan"`
+	PrivateIPv4Address string `json:"privateIpv4Address"`
+	AdapterType        string `json:"adapterType,omitempty"`
+}
+
+// ExportedIPAddressList represents an IP address list in a NetworkDomainExport.
+type ExportedIPAddressList struct {
+	ID          string               `json:"id"`
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	IPVersion   string               `json:"ipVersion"`
+	Addresses   []IPAddressListEntry `json:"addresses"`
+	ChildLists  []string             `json:"childLists,omitempty"`
+}
+
+// ExportedPortList represents a port list in a NetworkDomainExport.
+type ExportedPortList struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Ports       []PortListEntry `json:"ports"`
+	ChildLists  []string        `json:"childLists,omitempty"`
+}
+
+// ExportedFirewallRule represents a firewall rule in a NetworkDomainExport.
+//
+// Firewall rules appear in the document in the order in which they are evaluated.
+type ExportedFirewallRule struct {
+	ID          string                    `json:"id"`
+	Name        string                    `json:"name"`
+	RuleType    string                    `json:"ruleType"`
+	Action      string                    `json:"action"`
+	IPVersion   string                    `json:"ipVersion"`
+	Protocol    string                    `json:"protocol"`
+	Source      ExportedFirewallRuleScope `json:"source"`
+	Destination ExportedFirewallRuleScope `json:"destination"`
+	Enabled     bool                      `json:"enabled"`
+}
+
+// ExportedFirewallRuleScope represents the source or destination scope of a firewall rule in a NetworkDomainExport.
+type ExportedFirewallRuleScope struct {
+	IPAddress   *FirewallRuleIPAddress `json:"ip,omitempty"`
+	AddressList string                 `json:"ipAddressList,omitempty"`
+	Port        *FirewallRulePort      `json:"port,omitempty"`
+	PortList    string                 `json:"portList,omitempty"`
+}
+
+// ExportedPublicIPBlock represents a public IPv4 address block in a NetworkDomainExport.
+type ExportedPublicIPBlock struct {
+	ID     string `json:"id"`
+	BaseIP string `json:"baseIp"`
+	Size   int    `json:"size"`
+	Tags   []Tag  `json:"tags,omitempty"`
+}
+
+// ExportedNATRule represents a NAT rule in a NetworkDomainExport.
+type ExportedNATRule struct {
+	ID                string `json:"id"`
+	InternalIPAddress string `json:"internalIp"`
+	ExternalIPAddress string `json:"externalIp"`
+
+	// The name of the server (if any) whose network adapter has the rule's internal IP address.
+	Server string `json:"server,omitempty"`
+}
+
+// ExportedVIPNode represents a VIP node in a NetworkDomainExport.
+type ExportedVIPNode struct {
+	ID                  string `json:"id"`
+	Name                string `json:"name"`
+	Description         string `json:"description"`
+	IPv4Address         string `json:"ipv4Address,omitempty"`
+	IPv6Address         string `json:"ipv6Address,omitempty"`
+	Status              string `json:"status"`
+	HealthMonitor       string `json:"healthMonitor,omitempty"`
+	ConnectionLimit     int    `json:"connectionLimit"`
+	ConnectionRateLimit int    `json:"connectionRateLimit"`
+
+	// The name of the server (if any) whose network adapter has the node's IPv4 address.
+	Server string `json:"server,omitempty"`
+}
+
+// ExportedVIPPool represents a VIP pool (and its members) in a NetworkDomainExport.
+type ExportedVIPPool struct {
+	ID                string                  `json:"id"`
+	Name              string                  `json:"name"`
+	Description       string                  `json:"description"`
+	LoadBalanceMethod string                  `json:"loadBalanceMethod"`
+	HealthMonitors    []string                `json:"healthMonitors,omitempty"`
+	ServiceDownAction string                  `json:"serviceDownAction"`
+	SlowRampTime      int                     `json:"slowRampTime"`
+	Members           []ExportedVIPPoolMember `json:"members,omitempty"`
+}
+
+// ExportedVIPPoolMember represents a VIP pool member in a NetworkDomainExport.
+type ExportedVIPPoolMember struct {
+	Node   string `json:"node"`
+	Port   *int   `json:"port,omitempty"`
+	Status string `json:"status"`
+}
+
+// ExportedVirtualListener represents a virtual listener in a NetworkDomainExport.
+type ExportedVirtualListener struct {
+	ID                         string   `json:"id"`
+	Name                       string   `json:"name"`
+	Description                string   `json:"description"`
+	Type                       string   `json:"type"`
+	Protocol                   string   `json:"protocol"`
+	ListenerIPAddress          string   `json:"listenerIpAddress"`
+	Port                       int      `json:"port,omitempty"`
+	Enabled                    bool     `json:"enabled"`
+	ConnectionLimit            int      `json:"connectionLimit"`
+	ConnectionRateLimit        int      `json:"connectionRateLimit"`
+	SourcePortPreservation     string   `json:"sourcePortPreservation"`
+	Pool                       string   `json:"pool,omitempty"`
+	ClientClonePool            string   `json:"clientClonePool,omitempty"`
+	PersistenceProfile         string   `json:"persistenceProfile,omitempty"`
+	FallbackPersistenceProfile string   `json:"fallbackPersistenceProfile,omitempty"`
+	IRules                     []string `json:"irules,omitempty"`
+	OptimizationProfiles       []string `json:"optimizationProfiles,omitempty"`
+}
+
+// ExportedAntiAffinityRule represents a server anti-affinity rule in a NetworkDomainExport.
+type ExportedAntiAffinityRule struct {
+	ID      string   `json:"id"`
+	Servers []string `json:"servers"`
+}
+
+// Write writes the network domain export to the specified writer (as JSON).
+func (export *NetworkDomainExport) Write(writer io.Writer) error {
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(data)
+
+	return err
+}
+
+// ReadNetworkDomainExport reads a network domain export (as JSON) from the specified reader.
+//
+// Returns an error if the document's format version is not supported.
+func ReadNetworkDomainExport(reader io.Reader) (*NetworkDomainExport, error) {
+	export := &NetworkDomainExport{}
+	err := json.NewDecoder(reader).Decode(export)
+	if err != nil {
+		return nil, err
+	}
+
+	if export.FormatVersion < 1 || export.FormatVersion > NetworkDomainExportFormatVersion {
+		return nil, fmt.Errorf("Unsupported network domain export format version %d (expected 1 to %d).", export.FormatVersion, NetworkDomainExportFormatVersion)
+	}
+
+	return export, nil
+}
+
+// ExportNetworkDomain exports the specified network domain (and everything in it) to a NetworkDomainExport.
+func (client *Client) ExportNetworkDomain(networkDomainID string) (*NetworkDomainExport, error) {
+	networkDomain, err := client.GetNetworkDomain(networkDomainID)
+	if err != nil {
+		return nil, err
+	}
+	if networkDomain == nil {
+		return nil, fmt.Errorf("No network domain was found with Id '%s'.", networkDomainID)
+	}
+
+	exporter := &networkDomainExporter{
+		client:          client,
+		networkDomainID: networkDomainID,
+		export: &NetworkDomainExport{
+			FormatVersion: NetworkDomainExportFormatVersion,
+			ExportTime:    time.Now().UTC().Format(time.RFC3339),
+			NetworkDomain: ExportedNetworkDomain{
+				ID:           networkDomain.ID,
+				Name:         networkDomain.Name,
+				Description:  networkDomain.Description,
+				Type:         networkDomain.Type,
+				DatacenterID: networkDomain.DatacenterID,
+			},
+		},
+		vlanNames:          make(map[string]string),
+		serverNames:        make(map[string]string),
+		serverNamesByIPv4:  make(map[string]string),
+		addressListNames:   make(map[string]string),
+		portListNames:      make(map[string]string),
+		vipNodeNames:       make(map[string]string),
+		vipPoolNames:       make(map[string]string),
+		healthMonitorNames: make(map[string]string),
+	}
+
+	exporter.export.NetworkDomain.Tags, err = client.getAllAssetTags(networkDomainID, AssetTypeNetworkDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	// Order matters here; later steps refer to resources (by name) that were captured by earlier ones.
+	steps := []func() error{
+		exporter.exportVLANs,
+		exporter.exportServers,
+		exporter.exportIPAddressLists,
+		exporter.exportPortLists,
+		exporter.exportFirewallRules,
+		exporter.exportPublicIPBlocks,
+		exporter.exportNATRules,
+		exporter.exportVIPNodes,
+		exporter.exportVIPPools,
+		exporter.exportVirtualListeners,
+		exporter.exportAntiAffinityRules,
+	}
+	for _, step := range steps {
+		err = step()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return exporter.export, nil
+}
+
+// Exports the contents of a network domain.
+type networkDomainExporter struct {
+	client          *Client
+	networkDomainID string
+	export          *NetworkDomainExport
+
+	// Names of exported resources, keyed by Id (or, for servers, by private IPv4 address).
+	vlanNames          map[string]string
+	serverNames        map[string]string
+	serverNamesByIPv4  map[string]string
+	addressListNames   map[string]string
+	portListNames      map[string]string
+	vipNodeNames       map[string]string
+	vipPoolNames       map[string]string
+	healthMonitorNames map[string]string
+}
+
+func (exporter *networkDomainExporter) exportVLANs() error {
+	vlans, err := exporter.client.listAllVLANs(exporter.networkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, vlan := range vlans {
+		exportedVLAN := ExportedVLAN{
+			ID:          vlan.ID,
+			Name:        vlan.Name,
+			Description: vlan.Description,
+			IPv4Range:   vlan.IPv4Range,
+		}
+
+		reservedAddresses, err := exporter.client.listAllReservedPrivateIPv4Addresses(vlan.ID)
+		if err != nil {
+			return err
+		}
+		for _, reservedAddress := range reservedAddresses {
+			exportedVLAN.ReservedIPv4Addresses = append(exportedVLAN.ReservedIPv4Addresses, ExportedReservedIPAddress{
+				Address:     reservedAddress.Address,
+				Description: reservedAddress.Description,
+			})
+		}
+
+		exportedVLAN.Tags, err = exporter.client.getAllAssetTags(vlan.ID, AssetTypeVLAN)
+		if err != nil {
+			return err
+		}
+
+		exporter.vlanNames[vlan.ID] = vlan.Name
+		exporter.export.VLANs = append(exporter.export.VLANs, exportedVLAN)
+	}
+
+	return nil
+}
+
+func (exporter *networkDomainExporter) exportServers() error {
+	servers, err := exporter.client.listAllServersInNetworkDomain(exporter.networkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, server := range servers {
+		exportedServer := ExportedServer{
+			ID:              server.ID,
+			Name:            server.Name,
+			Description:     server.Description,
+			ImageID:         server.SourceImageID,
+			OperatingSystem: server.OperatingSystem.ID,
+			CPU:             server.CPU,
+			MemoryGB:        server.MemoryGB,
+			Started:         server.Started,
+		}
+		if server.Backup != nil {
+			exportedServer.BackupServicePlan = server.Backup.ServicePlan
+		}
+		if server.Monitoring != nil {
+			exportedServer.MonitoringPlan = server.Monitoring.ServicePlan
+		}
+
+		for _, disk := range server.Disks {
+			exportedServer.Disks = append(exportedServer.Disks, ExportedServerDisk{
+				SCSIUnitID: disk.SCSIUnitID,
+				SizeGB:     disk.SizeGB,
+				Speed:      disk.Speed,
+			})
+		}
+
+		exportedServer.NetworkAdapters = append(exportedServer.NetworkAdapters,
+			exporter.exportNetworkAdapter(server, server.Network.PrimaryAdapter, true),
+		)
+		for _, networkAdapter := range server.Network.AdditionalNetworkAdapters {
+			exportedServer.NetworkAdapters = append(exportedServer.NetworkAdapters,
+				exporter.exportNetworkAdapter(server, networkAdapter, false),
+			)
+		}
+
+		exportedServer.Tags, err = exporter.client.getAllAssetTags(server.ID, AssetTypeServer)
+		if err != nil {
+			return err
+		}
+
+		exporter.serverNames[server.ID] = server.Name
+		exporter.export.Servers = append(exporter.export.Servers, exportedServer)
+	}
+
+	return nil
+}
+
+func (exporter *networkDomainExporter) exportNetworkAdapter(server Server, networkAdapter VirtualMachineNetworkAdapter, isPrimary bool) ExportedNetworkAdapter {
+	exportedNetworkAdapter := ExportedNetworkAdapter{
+		IsPrimary: isPrimary,
+	}
+	if networkAdapter.VLANID != nil {
+		exportedNetworkAdapter.VLAN = exporter.vlanNames[*networkAdapter.VLANID]
+	}
+	if networkAdapter.PrivateIPv4Address != nil {
+		exportedNetworkAdapter.PrivateIPv4Address = *networkAdapter.PrivateIPv4Address
+		exporter.serverNamesByIPv4[*networkAdapter.PrivateIPv4Address] = server.Name
+	}
+	if networkAdapter.AdapterType != nil {
+		exportedNetworkAdapter.AdapterType = *networkAdapter.AdapterType
+	}
+
+	return exportedNetworkAdapter
+}
+
+func (exporter *networkDomainExporter) exportIPAddressLists() error {
+	addressLists, err := exporter.client.listAllIPAddressLists(exporter.networkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, addressList := range addressLists {
+		exporter.addressListNames[addressList.ID] = addressList.Name
+	}
+	for _, addressList := range addressLists {
+		exportedAddressList := ExportedIPAddressList{
+			ID:          addressList.ID,
+			Name:        addressList.Name,
+			Description: addressList.Description,
+			IPVersion:   addressList.IPVersion,
+			Addresses:   addressList.Addresses,
+		}
+		for _, childList := range addressList.ChildLists {
+			exportedAddressList.ChildLists = append(exportedAddressList.ChildLists,
+				nameOrID(exporter.addressListNames, childList),
+			)
+		}
+
+		exporter.export.IPAddressLists = append(exporter.export.IPAddressLists, exportedAddressList)
+	}
+
+	return nil
+}
+
+func (exporter *networkDomainExporter) exportPortLists() error {
+	portLists, err := exporter.client.listAllPortLists(exporter.networkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, portList := range portLists {
+		exporter.portListNames[portList.ID] = portList.Name
+	}
+	for _, portList := range portLists {
+		exportedPortList := ExportedPortList{
+			ID:          portList.ID,
+			Name:        portList.Name,
+			Description: portList.Description,
+			Ports:       portList.Ports,
+		}
+		for _, childList := range portList.ChildLists {
+			exportedPortList.ChildLists = append(exportedPortList.ChildLists,
+				nameOrID(exporter.portListNames, childList),
+			)
+		}
+
+		exporter.export.PortLists = append(exporter.export.PortLists, exportedPortList)
+	}
+
+	return nil
+}
+
+func (exporter *networkDomainExporter) exportFirewallRules() error {
+	rules, err := exporter.client.listAllFirewallRules(exporter.networkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		exporter.export.FirewallRules = append(exporter.export.FirewallRules, ExportedFirewallRule{
+			ID:          rule.ID,
+			Name:        rule.Name,
+			RuleType:    rule.RuleType,
+			Action:      rule.Action,
+			IPVersion:   rule.IPVersion,
+			Protocol:    rule.Protocol,
+			Source:      exporter.exportFirewallRuleScope(rule.Source),
+			Destination: exporter.exportFirewallRuleScope(rule.Destination),
+			Enabled:     rule.Enabled,
+		})
+	}
+
+	return nil
+}
+
+func (exporter *networkDomainExporter) exportFirewallRuleScope(scope FirewallRuleScope) ExportedFirewallRuleScope {
+	exportedScope := ExportedFirewallRuleScope{
+		IPAddress: scope.IPAddress,
+		Port:      scope.Port,
+	}
+	if scope.AddressList != nil {
+		exportedScope.AddressList = nameOrID(exporter.addressListNames, *scope.AddressList)
+	}
+	if scope.PortListID != nil {
+		exportedScope.PortList = nameOrID(exporter.portListNames, EntityReference{ID: *scope.PortListID})
+	}
+
+	return exportedScope
+}
+
+func (exporter *networkDomainExporter) exportPublicIPBlocks() error {
+	blocks, err := exporter.client.listAllPublicIPBlocks(exporter.networkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, block := range blocks {
+		exportedBlock := ExportedPublicIPBlock{
+			ID:     block.ID,
+			BaseIP: block.BaseIP,
+			Size:   block.Size,
+		}
+
+		exportedBlock.Tags, err = exporter.client.getAllAssetTags(block.ID, AssetTypePublicIPBlock)
+		if err != nil {
+			return err
+		}
+
+		exporter.export.PublicIPBlocks = append(exporter.export.PublicIPBlocks, exportedBlock)
+	}
+
+	return nil
+}
+
+func (exporter *networkDomainExporter) exportNATRules() error {
+	rules, err := exporter.client.listAllNATRules(exporter.networkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		exporter.export.NATRules = append(exporter.export.NATRules, ExportedNATRule{
+			ID:                rule.ID,
+			InternalIPAddress: rule.InternalIPAddress,
+			ExternalIPAddress: rule.ExternalIPAddress,
+			Server:            exporter.serverNamesByIPv4[rule.InternalIPAddress],
+		})
+	}
+
+	return nil
+}
+
+func (exporter *networkDomainExporter) exportVIPNodes() error {
+	healthMonitors, err := exporter.client.listAllDefaultHealthMonitors(exporter.networkDomainID)
+	if err != nil {
+		return err
+	}
+	for _, healthMonitor := range healthMonitors {
+		exporter.healthMonitorNames[healthMonitor.ID] = healthMonitor.Name
+	}
+
+	nodes, err := exporter.client.listAllVIPNodesInNetworkDomain(exporter.networkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, node := range nodes {
+		exportedNode := ExportedVIPNode{
+			ID:                  node.ID,
+			Name:                node.Name,
+			Description:         node.Description,
+			IPv4Address:         node.IPv4Address,
+			IPv6Address:         node.IPv6Address,
+			Status:              node.Status,
+			ConnectionLimit:     node.ConnectionLimit,
+			ConnectionRateLimit: node.ConnectionRateLimit,
+			Server:              exporter.serverNamesByIPv4[node.IPv4Address],
+		}
+		if node.HealthMonitorID != "" {
+			exportedNode.HealthMonitor = nameOrID(exporter.healthMonitorNames, EntityReference{ID: node.HealthMonitorID})
+		}
+
+		exporter.vipNodeNames[node.ID] = node.Name
+		exporter.export.VIPNodes = append(exporter.export.VIPNodes, exportedNode)
+	}
+
+	return nil
+}
+
+func (exporter *networkDomainExporter) exportVIPPools() error {
+	pools, err := exporter.client.listAllVIPPoolsInNetworkDomain(exporter.networkDomainID)
+	if err != nil {
+		return err
+	}
+
+	members, err := exporter.client.listAllVIPPoolMembershipsInNetworkDomain(exporter.networkDomainID)
+	if err != nil {
+		return err
+	}
+	membersByPoolID := make(map[string][]ExportedVIPPoolMember)
+	for _, member := range members {
+		membersByPoolID[member.Pool.ID] = append(membersByPoolID[member.Pool.ID], ExportedVIPPoolMember{
+			Node:   nameOrID(exporter.vipNodeNames, member.Node.EntityReference),
+			Port:   member.Port,
+			Status: member.Status,
+		})
+	}
+
+	for _, pool := range pools {
+		exportedPool := ExportedVIPPool{
+			ID:                pool.ID,
+			Name:              pool.Name,
+			Description:       pool.Description,
+			LoadBalanceMethod: pool.LoadBalanceMethod,
+			ServiceDownAction: pool.ServiceDownAction,
+			SlowRampTime:      pool.SlowRampTime,
+			Members:           membersByPoolID[pool.ID],
+		}
+		for _, healthMonitor := range pool.HealthMonitors {
+			exportedPool.HealthMonitors = append(exportedPool.HealthMonitors,
+				nameOrID(exporter.healthMonitorNames, healthMonitor),
+			)
+		}
+
+		exporter.vipPoolNames[pool.ID] = pool.Name
+		exporter.export.VIPPools = append(exporter.export.VIPPools, exportedPool)
+	}
+
+	return nil
+}
+
+func (exporter *networkDomainExporter) exportVirtualListeners() error {
+	listeners, err := exporter.client.listAllVirtualListenersInNetworkDomain(exporter.networkDomainID)
+	if err != nil {
+		return err
+	}
+
+	noNames := map[string]string{}
+	for _, listener := range listeners {
+		exportedListener := ExportedVirtualListener{
+			ID:                         listener.ID,
+			Name:                       listener.Name,
+			Description:                listener.Description,
+			Type:                       listener.Type,
+			Protocol:                   listener.Protocol,
+			ListenerIPAddress:          listener.ListenerIPAddress,
+			Port:                       listener.Port,
+			Enabled:                    listener.Enabled,
+			ConnectionLimit:            listener.ConnectionLimit,
+			ConnectionRateLimit:        listener.ConnectionRateLimit,
+			SourcePortPreservation:     listener.SourcePortPreservation,
+			Pool:                       nameOrID(exporter.vipPoolNames, listener.Pool.EntityReference),
+			ClientClonePool:            nameOrID(exporter.vipPoolNames, listener.ClientClonePool.EntityReference),
+			PersistenceProfile:         nameOrID(noNames, listener.PersistenceProfile),
+			FallbackPersistenceProfile: nameOrID(noNames, listener.FallbackPersistenceProfile),
+			OptimizationProfiles:       listener.OptimizationProfiles,
+		}
+		for _, irule := range listener.IRules {
+			exportedListener.IRules = append(exportedListener.IRules, nameOrID(noNames, irule))
+		}
+
+		exporter.export.VirtualListeners = append(exporter.export.VirtualListeners, exportedListener)
+	}
+
+	return nil
+}
+
+func (exporter *networkDomainExporter) exportAntiAffinityRules() error {
+	rules, err := exporter.client.listAllServerAntiAffinityRules(exporter.networkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		exportedRule := ExportedAntiAffinityRule{
+			ID: rule.ID,
+		}
+		for _, server := range rule.Servers {
+			exportedRule.Servers = append(exportedRule.Servers,
+				nameOrID(exporter.serverNames, server.ToEntityReference()),
+			)
+		}
+
+		exporter.export.AntiAffinityRules = append(exporter.export.AntiAffinityRules, exportedRule)
+	}
+
+	return nil
+}
+
+// nameOrID determines the name of the referenced entity (from the specified names, keyed by Id, or from the reference itself); if the name is unknown, the entity's Id is used instead.
+func nameOrID(names map[string]string, reference EntityReference) string {
+	if name, ok := names[reference.ID]; ok {
+		return name
+	}
+	if reference.Name != "" {
+		return reference.Name
+	}
+
+	return reference.ID
+}
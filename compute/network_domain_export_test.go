@@ -0,0 +1,387 @@
+package compute
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// Export network domain (successful).
+func TestClient_ExportNetworkDomain_Success(test *testing.T) {
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			export, err := client.ExportNetworkDomain("484174a2-ae74-4658-9e56-50fc90e086cf")
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			verifyNetworkDomainExport(test, export)
+
+			// Round-trip.
+			var buffer bytes.Buffer
+			err = export.Write(&buffer)
+			if err != nil {
+				test.Fatal(err)
+			}
+			export, err = ReadNetworkDomainExport(&buffer)
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			verifyNetworkDomainExport(test, export)
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			for pathSuffix, response := range exportNetworkDomainTestResponses {
+				if strings.HasSuffix(request.URL.Path, pathSuffix) {
+					if pathSuffix == "/tag/tag" && request.URL.Query().Get("assetId") != "5a32d6e4-9707-4813-a269-56ab4d989f4d" {
+						return http.StatusOK, `{ "tag": [], "pageNumber": 1, "pageCount": 0, "totalCount": 0, "pageSize": 50 }`
+					}
+
+					return http.StatusOK, response
+				}
+			}
+
+			test.Fatalf("Unexpected request: %s %s", request.Method, request.URL.Path)
+
+			return http.StatusNotFound, ""
+		},
+	})
+}
+
+// Export network domain (IP address lists span more than one page of results).
+func TestClient_ExportNetworkDomain_MultiplePages(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			export, err := client.ExportNetworkDomain("484174a2-ae74-4658-9e56-50fc90e086cf")
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.EqualsInt("Export.IPAddressLists.Length", 2, len(export.IPAddressLists))
+			expect.EqualsString("Export.IPAddressLists[0].ChildLists[0]", "Offices", export.IPAddressLists[0].ChildLists[0])
+			expect.EqualsString("Export.IPAddressLists[1].Name", "Offices", export.IPAddressLists[1].Name)
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			if strings.HasSuffix(request.URL.Path, "/network/ipAddressList") {
+				if request.URL.Query().Get("pageNumber") == "2" {
+					return http.StatusOK, exportNetworkDomainIPAddressListsPage2TestResponse
+				}
+
+				return http.StatusOK, exportNetworkDomainIPAddressListsPage1TestResponse
+			}
+
+			for pathSuffix, response := range exportNetworkDomainTestResponses {
+				if strings.HasSuffix(request.URL.Path, pathSuffix) {
+					if pathSuffix == "/tag/tag" && request.URL.Query().Get("assetId") != "5a32d6e4-9707-4813-a269-56ab4d989f4d" {
+						return http.StatusOK, `{ "tag": [], "pageNumber": 1, "pageCount": 0, "totalCount": 0, "pageSize": 50 }`
+					}
+
+					return http.StatusOK, response
+				}
+			}
+
+			test.Fatalf("Unexpected request: %s %s", request.Method, request.URL.Path)
+
+			return http.StatusNotFound, ""
+		},
+	})
+}
+
+// Read network domain export (unsupported format version).
+func TestReadNetworkDomainExport_UnsupportedVersion(test *testing.T) {
+	_, err := ReadNetworkDomainExport(strings.NewReader(`{ "formatVersion": 99 }`))
+	expect(test).NotNil("ReadNetworkDomainExport error", err)
+}
+
+func verifyNetworkDomainExport(test *testing.T, export *NetworkDomainExport) {
+	expect := expect(test)
+
+	expect.EqualsInt("Export.FormatVersion", NetworkDomainExportFormatVersion, export.FormatVersion)
+	expect.EqualsString("Export.NetworkDomain.Name", "Production Network Domain", export.NetworkDomain.Name)
+	expect.EqualsString("Export.NetworkDomain.Type", string(NetworkDomainTypeAdvanced), string(export.NetworkDomain.Type))
+
+	expect.EqualsInt("Export.VLANs.Length", 1, len(export.VLANs))
+	expect.EqualsString("Export.VLANs[0].Name", "Production VLAN", export.VLANs[0].Name)
+	expect.EqualsString("Export.VLANs[0].IPv4Range", "10.0.3.0/24", export.VLANs[0].IPv4Range.ToDisplayString())
+	expect.EqualsInt("Export.VLANs[0].ReservedIPv4Addresses.Length", 2, len(export.VLANs[0].ReservedIPv4Addresses))
+
+	expect.EqualsInt("Export.Servers.Length", 2, len(export.Servers))
+	server := export.Servers[1]
+	expect.EqualsString("Export.Servers[1].Name", "Production Database Server", server.Name)
+	expect.EqualsInt("Export.Servers[1].NetworkAdapters.Length", 2, len(server.NetworkAdapters))
+	expect.IsTrue("Export.Servers[1].NetworkAdapters[0].IsPrimary", server.NetworkAdapters[0].IsPrimary)
+	expect.EqualsString("Export.Servers[1].NetworkAdapters[1].VLAN", "Production VLAN", server.NetworkAdapters[1].VLAN)
+	expect.EqualsString("Export.Servers[1].NetworkAdapters[1].PrivateIPv4Address", "10.0.3.18", server.NetworkAdapters[1].PrivateIPv4Address)
+	expect.EqualsInt("Export.Servers[0].Tags.Length", 1, len(export.Servers[0].Tags))
+	expect.EqualsString("Export.Servers[0].Tags[0].Name", "role", export.Servers[0].Tags[0].Name)
+
+	expect.EqualsInt("Export.IPAddressLists.Length", 2, len(export.IPAddressLists))
+	expect.EqualsString("Export.IPAddressLists[0].ChildLists[0]", "Offices", export.IPAddressLists[0].ChildLists[0])
+	expect.EqualsInt("Export.PortLists.Length", 1, len(export.PortLists))
+
+	expect.EqualsInt("Export.FirewallRules.Length", 1, len(export.FirewallRules))
+	firewallRule := export.FirewallRules[0]
+	expect.EqualsString("Export.FirewallRules[0].Source.AddressList", "Admins", firewallRule.Source.AddressList)
+	expect.EqualsString("Export.FirewallRules[0].Destination.PortList", "Web", firewallRule.Destination.PortList)
+
+	expect.EqualsInt("Export.PublicIPBlocks.Length", 1, len(export.PublicIPBlocks))
+	expect.EqualsInt("Export.NATRules.Length", 1, len(export.NATRules))
+	expect.EqualsString("Export.NATRules[0].Server", "Production Web Server", export.NATRules[0].Server)
+
+	expect.EqualsInt("Export.VIPNodes.Length", 1, len(export.VIPNodes))
+	expect.EqualsString("Export.VIPNodes[0].Server", "Production Web Server", export.VIPNodes[0].Server)
+	expect.EqualsString("Export.VIPNodes[0].HealthMonitor", "CCDEFAULT.Icmp", export.VIPNodes[0].HealthMonitor)
+
+	expect.EqualsInt("Export.VIPPools.Length", 1, len(export.VIPPools))
+	expect.EqualsInt("Export.VIPPools[0].Members.Length", 1, len(export.VIPPools[0].Members))
+	expect.EqualsString("Export.VIPPools[0].Members[0].Node", "Web Node 1", export.VIPPools[0].Members[0].Node)
+
+	expect.EqualsInt("Export.VirtualListeners.Length", 1, len(export.VirtualListeners))
+	expect.EqualsString("Export.VirtualListeners[0].Pool", "Web Pool", export.VirtualListeners[0].Pool)
+
+	expect.EqualsInt("Export.AntiAffinityRules.Length", 1, len(export.AntiAffinityRules))
+	expect.EqualsString("Export.AntiAffinityRules[0].Servers", "Production Web Server,Production Database Server",
+		strings.Join(export.AntiAffinityRules[0].Servers, ","),
+	)
+}
+
+/*
+ * Test responses.
+ */
+
+// Responses for network domain export, keyed by request path suffix.
+var exportNetworkDomainTestResponses = map[string]string{
+	"/network/networkDomain/484174a2-ae74-4658-9e56-50fc90e086cf": `
+		{
+			"id": "484174a2-ae74-4658-9e56-50fc90e086cf",
+			"name": "Production Network Domain",
+			"description": "Production",
+			"type": "ADVANCED",
+			"state": "NORMAL",
+			"datacenterId": "NA9"
+		}
+	`,
+	"/tag/tag": `
+		{
+			"tag": [
+				{ "assetType": "SERVER", "assetId": "5a32d6e4-9707-4813-a269-56ab4d989f4d", "tagKeyName": "role", "value": "web" }
+			],
+			"pageNumber": 1, "pageCount": 1, "totalCount": 1, "pageSize": 50
+		}
+	`,
+	"/network/vlan": `
+		{
+			"vlan": [
+				{
+					"id": "0e56433f-d808-4669-821d-812769517ff8",
+					"name": "Production VLAN",
+					"description": "For hosting our Production Cloud Servers",
+					"privateIpv4Range": { "address": "10.0.3.0", "prefixSize": 24 },
+					"ipv4GatewayAddress": "10.0.3.1",
+					"state": "NORMAL"
+				}
+			],
+			"pageNumber": 1, "pageCount": 1, "totalCount": 1, "pageSize": 50
+		}
+	`,
+	"/network/reservedPrivateIpv4Address": listReservedPrivateIPv4AddressesTestResponse,
+	"/server/server":                      findNetworkAdapterListServersTestResponse,
+	"/network/ipAddressList": `
+		{
+			"ipAddressList": [
+				{
+					"id": "c8c92ea3-2da8-4d51-8153-f39bec794d69",
+					"name": "Admins",
+					"description": "Administrators",
+					"ipVersion": "IPV4",
+					"state": "NORMAL",
+					"ipAddress": [ { "begin": "192.168.1.10" } ],
+					"childIpAddressList": [ { "id": "7e8a1e36-3f1d-4a3c-8c5a-a8fd1e1b0c2b", "name": "Offices" } ]
+				},
+				{
+					"id": "7e8a1e36-3f1d-4a3c-8c5a-a8fd1e1b0c2b",
+					"name": "Offices",
+					"description": "Office networks",
+					"ipVersion": "IPV4",
+					"state": "NORMAL",
+					"ipAddress": [ { "begin": "203.0.113.0", "prefixSize": 24 } ]
+				}
+			],
+			"pageNumber": 1, "pageCount": 2, "totalCount": 2, "pageSize": 250
+		}
+	`,
+	"/network/portList": `
+		{
+			"portList": [
+				{
+					"id": "0a7c4b3e-1b9f-4a61-9d8a-4a6e0f1e2d3c",
+					"name": "Web",
+					"description": "Web ports",
+					"state": "NORMAL",
+					"port": [ { "begin": 80 }, { "begin": 443 } ]
+				}
+			],
+			"pageNumber": 1, "pageCount": 1, "totalCount": 1, "pageSize": 250
+		}
+	`,
+	"/network/firewallRule": `
+		{
+			"firewallRule": [
+				{
+					"id": "a1b2c3d4-0000-4000-8000-000000000001",
+					"name": "AllowAdminsToWeb",
+					"action": "ACCEPT_DECISIVELY",
+					"ipVersion": "IPV4",
+					"protocol": "TCP",
+					"source": { "ipAddressList": { "id": "c8c92ea3-2da8-4d51-8153-f39bec794d69" } },
+					"destination": { "ip": { "address": "10.0.3.17" }, "portListId": "0a7c4b3e-1b9f-4a61-9d8a-4a6e0f1e2d3c" },
+					"enabled": true,
+					"state": "NORMAL",
+					"ruleType": "CLIENT_RULE"
+				}
+			],
+			"pageNumber": 1, "pageCount": 1, "totalCount": 1, "pageSize": 50
+		}
+	`,
+	"/network/publicIpBlock": `
+		{
+			"publicIpBlock": [
+				{ "id": "4487241a-f0ca-11e3-9315-d4bed9b167ba", "baseIp": "165.180.12.12", "size": 2, "state": "NORMAL" }
+			],
+			"pageNumber": 1, "pageCount": 1, "totalCount": 1, "pageSize": 50
+		}
+	`,
+	"/network/natRule": `
+		{
+			"natRule": [
+				{ "id": "2169a38e-5692-497e-a22a-701a838a6539", "internalIp": "10.0.3.17", "externalIp": "165.180.12.12", "state": "NORMAL" }
+			],
+			"pageNumber": 1, "pageCount": 1, "totalCount": 1, "pageSize": 50
+		}
+	`,
+	"/networkDomainVip/defaultHealthMonitor": `
+		{
+			"defaultHealthMonitor": [
+				{ "id": "0168b83a-d487-11e4-811f-005056806999", "name": "CCDEFAULT.Icmp", "nodeCompatible": true, "poolCompatible": false }
+			],
+			"pageNumber": 1, "pageCount": 1, "totalCount": 1, "pageSize": 50
+		}
+	`,
+	"/networkDomainVip/node": `
+		{
+			"node": [
+				{
+					"id": "34de6ed6-46a4-4dae-a753-2f8d3840c6f9",
+					"name": "Web Node 1",
+					"ipv4Address": "10.0.3.17",
+					"status": "ENABLED",
+					"healthMonitorId": "0168b83a-d487-11e4-811f-005056806999",
+					"connectionLimit": 10000,
+					"connectionRateLimit": 2000,
+					"state": "NORMAL"
+				}
+			],
+			"pageNumber": 1, "pageCount": 1, "totalCount": 1, "pageSize": 50
+		}
+	`,
+	"/networkDomainVip/pool": `
+		{
+			"pool": [
+				{
+					"id": "4d360b1f-bc2c-4ab7-9884-1f03ba2768f7",
+					"name": "Web Pool",
+					"loadBalanceMethod": "ROUND_ROBIN",
+					"serviceDownAction": "NONE",
+					"slowRampTime": 10,
+					"state": "NORMAL"
+				}
+			],
+			"pageNumber": 1, "pageCount": 1, "totalCount": 1, "pageSize": 50
+		}
+	`,
+	"/networkDomainVip/poolMember": `
+		{
+			"poolMember": [
+				{
+					"id": "3dd806a2-c2c8-4c0c-9a4f-5219ea9266c0",
+					"pool": { "id": "4d360b1f-bc2c-4ab7-9884-1f03ba2768f7", "name": "Web Pool" },
+					"node": { "id": "34de6ed6-46a4-4dae-a753-2f8d3840c6f9", "ipAddress": "10.0.3.17" },
+					"port": 80,
+					"status": "ENABLED",
+					"state": "NORMAL"
+				}
+			],
+			"pageNumber": 1, "pageCount": 1, "totalCount": 1, "pageSize": 50
+		}
+	`,
+	"/networkDomainVip/virtualListener": `
+		{
+			"virtualListener": [
+				{
+					"id": "6115469d-a8bb-445b-bb23-d23b5283f2b9",
+					"name": "Web Listener",
+					"type": "STANDARD",
+					"protocol": "HTTP",
+					"listenerIpAddress": "165.180.12.13",
+					"port": 80,
+					"enabled": true,
+					"sourcePortPreservation": "PRESERVE",
+					"pool": { "id": "4d360b1f-bc2c-4ab7-9884-1f03ba2768f7" },
+					"state": "NORMAL"
+				}
+			],
+			"pageNumber": 1, "pageCount": 1, "totalCount": 1, "pageSize": 50
+		}
+	`,
+	"/server/antiAffinityRule": `
+		{
+			"antiAffinityRule": [
+				{
+					"id": "d0ad1a26-4a5b-4b8a-8f0b-0c9b47e1f0f1",
+					"serverSummary": [
+						{ "id": "5a32d6e4-9707-4813-a269-56ab4d989f4d" },
+						{ "id": "0f1d6a3e-2b7c-4c2e-a5a1-3f9d8d6e7b41" }
+					],
+					"state": "NORMAL"
+				}
+			],
+			"pageNumber": 1, "pageCount": 1, "totalCount": 1, "pageSize": 50
+		}
+	`,
+}
+
+const exportNetworkDomainIPAddressListsPage1TestResponse = `
+	{
+		"ipAddressList": [
+			{
+				"id": "c8c92ea3-2da8-4d51-8153-f39bec794d69",
+				"name": "Admins",
+				"description": "Administrators",
+				"ipVersion": "IPV4",
+				"state": "NORMAL",
+				"ipAddress": [ { "begin": "192.168.1.10" } ],
+				"childIpAddressList": [ { "id": "7e8a1e36-3f1d-4a3c-8c5a-a8fd1e1b0c2b", "name": "Offices" } ]
+			}
+		],
+		"pageNumber": 1, "pageCount": 1, "totalCount": 2, "pageSize": 1
+	}
+`
+
+const exportNetworkDomainIPAddressListsPage2TestResponse = `
+	{
+		"ipAddressList": [
+			{
+				"id": "7e8a1e36-3f1d-4a3c-8c5a-a8fd1e1b0c2b",
+				"name": "Offices",
+				"description": "Office networks",
+				"ipVersion": "IPV4",
+				"state": "NORMAL",
+				"ipAddress": [ { "begin": "203.0.113.0", "prefixSize": 24 } ]
+			}
+		],
+		"pageNumber": 2, "pageCount": 1, "totalCount": 2, "pageSize": 1
+	}
+`
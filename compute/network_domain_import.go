@@ -0,0 +1,1150 @@
+package compute
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// NetworkDomainImportOptions represents the options for importing a network domain from a NetworkDomainExport.
+type NetworkDomainImportOptions struct {
+	// The Id of the datacenter where the new network domain will be deployed.
+	DatacenterID string
+
+	// The name of the new network domain (if empty, the name of the exported network domain is used).
+	NetworkDomainName string
+
+	// The administrator password for imported servers (required if the export contains any servers).
+	AdministratorPassword string
+
+	// The primary DNS server for imported servers (optional).
+	PrimaryDNS string
+
+	// The secondary DNS server for imported servers (optional).
+	SecondaryDNS string
+
+	// The Ids of images in the target datacenter, keyed by the Id of the image used by each exported server.
+	//
+	// Images are specific to a datacenter, so every image used by an exported server must be mapped.
+	ImageIDs map[string]string
+
+	// The maximum length of time to wait for each resource to be deployed (or, when rolling back, deleted).
+	StepTimeout time.Duration
+
+	// Delete all resources created by the import if it fails?
+	RollbackOnFailure bool
+}
+
+// ImportedResource represents a resource created by ImportNetworkDomain.
+type ImportedResource struct {
+	// A description of the resource type (e.g. "VLAN").
+	Kind string
+
+	// The resource name (or, for resources without a name, a description of the resource).
+	Name string
+
+	// The Id of the corresponding resource in the exported network domain (if any).
+	SourceID string
+
+	// The Id of the new resource.
+	ID string
+
+	// Deletes the new resource (and waits for the deletion to complete).
+	remove func() error
+}
+
+// NetworkDomainImportResult represents the resources created by ImportNetworkDomain.
+type NetworkDomainImportResult struct {
+	// The Id of the new network domain.
+	NetworkDomainID string
+
+	// The resources created by the import (in the order they were created).
+	Resources []ImportedResource
+
+	// The Ids of the new resources, keyed by the Id of the corresponding resource in the exported network domain.
+	IDs map[string]string
+
+	// The new network domain's public IPv4 addresses, keyed by the corresponding address in the exported network domain.
+	PublicIPv4Addresses map[string]string
+}
+
+// NetworkDomainImportError is an error representing the failure of a step in ImportNetworkDomain.
+type NetworkDomainImportError struct {
+	// The resources that were created before the failure.
+	Result *NetworkDomainImportResult
+
+	// A description of the step that failed.
+	FailedStep string
+
+	// The error that caused the step to fail.
+	Cause error
+
+	// Were the resources created by the import deleted after the failure?
+	RolledBack bool
+
+	// Errors encountered while deleting the resources created by the import (if any).
+	RollbackErrors []error
+}
+
+// Error returns the error message associated with the NetworkDomainImportError.
+func (importError *NetworkDomainImportError) Error() string {
+	message := fmt.Sprintf("Import of network domain failed at step '%s': %s (%d resource(s) created)",
+		importError.FailedStep,
+		importError.Cause.Error(),
+		len(importError.Result.Resources),
+	)
+	if importError.RolledBack {
+		message += "; all created resources were deleted"
+	} else if len(importError.RollbackErrors) > 0 {
+		rollbackErrors := make([]string, len(importError.RollbackErrors))
+		for index, rollbackError := range importError.RollbackErrors {
+			rollbackErrors[index] = rollbackError.Error()
+		}
+
+		message += fmt.Sprintf("; rollback failed: %s", strings.Join(rollbackErrors, "; "))
+	}
+
+	return message
+}
+
+var _ error = &NetworkDomainImportError{}
+
+// ValidateImport checks that the network domain export can be imported using the specified options.
+//
+// This verifies that all references between resources in the export (by name) can be resolved, and that all required options have been supplied.
+func (export *NetworkDomainExport) ValidateImport(options NetworkDomainImportOptions) error {
+	if options.DatacenterID == "" {
+		return fmt.Errorf("A target datacenter must be specified.")
+	}
+	if !export.NetworkDomain.Type.IsValid() {
+		return fmt.Errorf("Invalid network domain type '%s' (must be '%s' or '%s').", export.NetworkDomain.Type, NetworkDomainTypeEssentials, NetworkDomainTypeAdvanced)
+	}
+
+	vlanNames := make(map[string]bool)
+	for _, vlan := range export.VLANs {
+		if vlanNames[vlan.Name] {
+			return fmt.Errorf("The export contains more than one VLAN named '%s'.", vlan.Name)
+		}
+		vlanNames[vlan.Name] = true
+	}
+
+	if len(export.Servers) > 0 && options.AdministratorPassword == "" {
+		return fmt.Errorf("An administrator password must be specified to import servers.")
+	}
+	serverNames := make(map[string]bool)
+	for _, server := range export.Servers {
+		if serverNames[server.Name] {
+			return fmt.Errorf("The export contains more than one server named '%s'.", server.Name)
+		}
+		if _, ok := options.ImageIDs[server.ImageID]; !ok {
+			return fmt.Errorf("No image in datacenter '%s' has been specified for image '%s' (used by server '%s').", options.DatacenterID, server.ImageID, server.Name)
+		}
+
+		for _, networkAdapter := range server.NetworkAdapters {
+			if !vlanNames[networkAdapter.VLAN] {
+				return fmt.Errorf("Server '%s' has a network adapter on VLAN '%s', which is not in the export.", server.Name, networkAdapter.VLAN)
+			}
+		}
+
+		serverNames[server.Name] = true
+	}
+
+	addressListNames := make(map[string]bool)
+	for _, addressList := range export.IPAddressLists {
+		if addressListNames[addressList.Name] {
+			return fmt.Errorf("The export contains more than one IP address list named '%s'.", addressList.Name)
+		}
+		addressListNames[addressList.Name] = true
+	}
+	portListNames := make(map[string]bool)
+	for _, portList := range export.PortLists {
+		if portListNames[portList.Name] {
+			return fmt.Errorf("The export contains more than one port list named '%s'.", portList.Name)
+		}
+		portListNames[portList.Name] = true
+	}
+
+	_, err := orderExportedIPAddressLists(export.IPAddressLists)
+	if err != nil {
+		return err
+	}
+	_, err = orderExportedPortLists(export.PortLists)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range export.FirewallRules {
+		for _, scope := range []ExportedFirewallRuleScope{rule.Source, rule.Destination} {
+			if scope.AddressList != "" && !addressListNames[scope.AddressList] {
+				return fmt.Errorf("Firewall rule '%s' refers to IP address list '%s', which is not in the export.", rule.Name, scope.AddressList)
+			}
+			if scope.PortList != "" && !portListNames[scope.PortList] {
+				return fmt.Errorf("Firewall rule '%s' refers to port list '%s', which is not in the export.", rule.Name, scope.PortList)
+			}
+		}
+	}
+
+	for _, natRule := range export.NATRules {
+		if findExportedPublicIPBlock(export.PublicIPBlocks, natRule.ExternalIPAddress) == -1 {
+			return fmt.Errorf("The external IP address '%s' of NAT rule '%s' does not belong to any public IP block in the export.", natRule.ExternalIPAddress, natRule.ID)
+		}
+	}
+
+	vipNodeNames := make(map[string]bool)
+	for _, node := range export.VIPNodes {
+		if vipNodeNames[node.Name] {
+			return fmt.Errorf("The export contains more than one VIP node named '%s'.", node.Name)
+		}
+		vipNodeNames[node.Name] = true
+	}
+	vipPoolNames := make(map[string]bool)
+	for _, pool := range export.VIPPools {
+		if vipPoolNames[pool.Name] {
+			return fmt.Errorf("The export contains more than one VIP pool named '%s'.", pool.Name)
+		}
+		for _, member := range pool.Members {
+			if !vipNodeNames[member.Node] {
+				return fmt.Errorf("VIP pool '%s' has a member that refers to VIP node '%s', which is not in the export.", pool.Name, member.Node)
+			}
+		}
+
+		vipPoolNames[pool.Name] = true
+	}
+	for _, listener := range export.VirtualListeners {
+		for _, poolName := range []string{listener.Pool, listener.ClientClonePool} {
+			if poolName != "" && !vipPoolNames[poolName] {
+				return fmt.Errorf("Virtual listener '%s' refers to VIP pool '%s', which is not in the export.", listener.Name, poolName)
+			}
+		}
+	}
+
+	for _, rule := range export.AntiAffinityRules {
+		if len(rule.Servers) != 2 {
+			return fmt.Errorf("Server anti-affinity rule '%s' refers to %d server(s) (expected 2).", rule.ID, len(rule.Servers))
+		}
+		for _, serverName := range rule.Servers {
+			if !serverNames[serverName] {
+				return fmt.Errorf("Server anti-affinity rule '%s' refers to server '%s', which is not in the export.", rule.ID, serverName)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ImportNetworkDomain deploys a new network domain (and everything in it) from a NetworkDomainExport.
+//
+// Resources are created in dependency order (network domain, VLANs, IP address and port lists, firewall rules, servers, public IP blocks, NAT rules,
+// VIP nodes, VIP pools, virtual listeners, server anti-affinity rules), waiting for each one to be deployed before moving on.
+// Servers keep their private IPv4 addresses; public IPv4 addresses are mapped (by position) onto the new network domain's public IP blocks.
+//
+// If the import fails, a *NetworkDomainImportError is returned that describes the resources created so far
+// (and, if options.RollbackOnFailure is true, whether they were successfully deleted).
+func (client *Client) ImportNetworkDomain(export *NetworkDomainExport, options NetworkDomainImportOptions) (*NetworkDomainImportResult, error) {
+	err := export.ValidateImport(options)
+	if err != nil {
+		return nil, err
+	}
+
+	importer := &networkDomainImporter{
+		client:  client,
+		export:  export,
+		options: options,
+		result: &NetworkDomainImportResult{
+			IDs:                 make(map[string]string),
+			PublicIPv4Addresses: make(map[string]string),
+		},
+		vlanIDs:        make(map[string]string),
+		serverIDs:      make(map[string]string),
+		addressListIDs: make(map[string]string),
+		portListIDs:    make(map[string]string),
+		vipNodeIDs:     make(map[string]string),
+		vipPoolIDs:     make(map[string]string),
+	}
+
+	// Order matters here; later steps refer to resources (by name) that were created by earlier ones.
+	steps := []struct {
+		description string
+		apply       func() error
+	}{
+		{"Deploy network domain", importer.importNetworkDomain},
+		{"Deploy VLANs", importer.importVLANs},
+		{"Create IP address lists", importer.importIPAddressLists},
+		{"Create port lists", importer.importPortLists},
+		{"Create firewall rules", importer.importFirewallRules},
+		{"Deploy servers", importer.importServers},
+		{"Add public IP blocks", importer.importPublicIPBlocks},
+		{"Create NAT rules", importer.importNATRules},
+		{"Create VIP nodes", importer.importVIPNodes},
+		{"Create VIP pools", importer.importVIPPools},
+		{"Create virtual listeners", importer.importVirtualListeners},
+		{"Create server anti-affinity rules", importer.importAntiAffinityRules},
+	}
+	for _, step := range steps {
+		log.Printf("%s...", step.description)
+
+		err = step.apply()
+		if err != nil {
+			importError := &NetworkDomainImportError{
+				Result:     importer.result,
+				FailedStep: step.description,
+				Cause:      err,
+			}
+			if options.RollbackOnFailure {
+				importError.RollbackErrors = importer.rollBack()
+				importError.RolledBack = len(importError.RollbackErrors) == 0
+			}
+
+			return importer.result, importError
+		}
+	}
+
+	return importer.result, nil
+}
+
+// Imports the contents of a network domain.
+type networkDomainImporter struct {
+	client  *Client
+	export  *NetworkDomainExport
+	options NetworkDomainImportOptions
+	result  *NetworkDomainImportResult
+
+	// Ids of imported resources, keyed by name.
+	vlanIDs        map[string]string
+	serverIDs      map[string]string
+	addressListIDs map[string]string
+	portListIDs    map[string]string
+	vipNodeIDs     map[string]string
+	vipPoolIDs     map[string]string
+
+	// The new network domain's public IP blocks (in the same order as the exported blocks).
+	publicIPBlocks []PublicIPBlock
+
+	// Ids of the new network domain's default health monitors, persistence profiles, and iRules, keyed by name (loaded on demand).
+	healthMonitorIDs      map[string]string
+	persistenceProfileIDs map[string]string
+	iRuleIDs              map[string]string
+}
+
+// Record a newly-created resource.
+func (importer *networkDomainImporter) created(kind string, name string, sourceID string, id string, remove func() error) {
+	importer.result.Resources = append(importer.result.Resources, ImportedResource{
+		Kind:     kind,
+		Name:     name,
+		SourceID: sourceID,
+		ID:       id,
+		remove:   remove,
+	})
+	if sourceID != "" {
+		importer.result.IDs[sourceID] = id
+	}
+}
+
+// Delete the resources created by the import (in reverse order), returning any errors encountered.
+func (importer *networkDomainImporter) rollBack() (errors []error) {
+	resources := importer.result.Resources
+	for index := len(resources) - 1; index >= 0; index-- {
+		resource := resources[index]
+		log.Printf("Deleting %s '%s' ('%s')...", resource.Kind, resource.Name, resource.ID)
+
+		err := resource.remove()
+		if err != nil {
+			errors = append(errors,
+				fmt.Errorf("Failed to delete %s '%s' ('%s'): %s", resource.Kind, resource.Name, resource.ID, err),
+			)
+		}
+	}
+
+	return
+}
+
+// Apply tags (if any) to a newly-created asset.
+func (importer *networkDomainImporter) applyTags(assetID string, assetType string, tags []Tag) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	_, err := importer.client.ApplyAssetTags(assetID, assetType, tags...)
+
+	return err
+}
+
+func (importer *networkDomainImporter) importNetworkDomain() error {
+	client := importer.client
+	exportedNetworkDomain := importer.export.NetworkDomain
+
+	name := importer.options.NetworkDomainName
+	if name == "" {
+		name = exportedNetworkDomain.Name
+	}
+
+	networkDomainID, err := client.DeployNetworkDomain(name, exportedNetworkDomain.Description, exportedNetworkDomain.Type, importer.options.DatacenterID)
+	if err != nil {
+		return err
+	}
+	importer.result.NetworkDomainID = networkDomainID
+	importer.created("network domain", name, exportedNetworkDomain.ID, networkDomainID, func() error {
+		err := client.DeleteNetworkDomain(networkDomainID)
+		if err != nil {
+			return err
+		}
+
+		return client.WaitForDelete(ResourceTypeNetworkDomain, networkDomainID, importer.options.StepTimeout)
+	})
+
+	_, err = client.WaitForDeploy(ResourceTypeNetworkDomain, networkDomainID, importer.options.StepTimeout)
+	if err != nil {
+		return err
+	}
+
+	return importer.applyTags(networkDomainID, AssetTypeNetworkDomain, exportedNetworkDomain.Tags)
+}
+
+func (importer *networkDomainImporter) importVLANs() error {
+	client := importer.client
+	for _, exportedVLAN := range importer.export.VLANs {
+		vlanID, err := client.DeployVLAN(importer.result.NetworkDomainID, exportedVLAN.Name, exportedVLAN.Description, exportedVLAN.IPv4Range.BaseAddress, exportedVLAN.IPv4Range.PrefixSize)
+		if err != nil {
+			return err
+		}
+		importer.vlanIDs[exportedVLAN.Name] = vlanID
+		importer.created("VLAN", exportedVLAN.Name, exportedVLAN.ID, vlanID, func() error {
+			err := client.DeleteVLAN(vlanID)
+			if err != nil {
+				return err
+			}
+
+			return client.WaitForDelete(ResourceTypeVLAN, vlanID, importer.options.StepTimeout)
+		})
+
+		_, err = client.WaitForDeploy(ResourceTypeVLAN, vlanID, importer.options.StepTimeout)
+		if err != nil {
+			return err
+		}
+
+		err = importer.applyTags(vlanID, AssetTypeVLAN, exportedVLAN.Tags)
+		if err != nil {
+			return err
+		}
+
+		for _, reservedAddress := range exportedVLAN.ReservedIPv4Addresses {
+			address := reservedAddress.Address
+			err = client.ReservePrivateIPv4Address(vlanID, address, reservedAddress.Description)
+			if err != nil {
+				return err
+			}
+			importer.created("reserved IPv4 address", address, "", address, func() error {
+				return client.UnreservePrivateIPv4Address(vlanID, address)
+			})
+		}
+	}
+
+	return nil
+}
+
+func (importer *networkDomainImporter) importIPAddressLists() error {
+	client := importer.client
+
+	// Child lists must be created before their parents.
+	addressLists, err := orderExportedIPAddressLists(importer.export.IPAddressLists)
+	if err != nil {
+		return err
+	}
+	for _, exportedAddressList := range addressLists {
+		childListIDs := make([]string, len(exportedAddressList.ChildLists))
+		for index, childListName := range exportedAddressList.ChildLists {
+			childListIDs[index] = importer.addressListIDs[childListName]
+		}
+
+		addressListID, err := client.CreateIPAddressList(exportedAddressList.Name, exportedAddressList.Description, exportedAddressList.IPVersion,
+			importer.result.NetworkDomainID, exportedAddressList.Addresses, childListIDs,
+		)
+		if err != nil {
+			return err
+		}
+		importer.addressListIDs[exportedAddressList.Name] = addressListID
+		importer.created("IP address list", exportedAddressList.Name, exportedAddressList.ID, addressListID, func() error {
+			return client.DeleteIPAddressList(addressListID)
+		})
+	}
+
+	return nil
+}
+
+func (importer *networkDomainImporter) importPortLists() error {
+	client := importer.client
+
+	// Child lists must be created before their parents.
+	portLists, err := orderExportedPortLists(importer.export.PortLists)
+	if err != nil {
+		return err
+	}
+	for _, exportedPortList := range portLists {
+		childListIDs := make([]string, len(exportedPortList.ChildLists))
+		for index, childListName := range exportedPortList.ChildLists {
+			childListIDs[index] = importer.portListIDs[childListName]
+		}
+
+		portListID, err := client.CreatePortList(exportedPortList.Name, exportedPortList.Description,
+			importer.result.NetworkDomainID, exportedPortList.Ports, childListIDs,
+		)
+		if err != nil {
+			return err
+		}
+		importer.portListIDs[exportedPortList.Name] = portListID
+		importer.created("port list", exportedPortList.Name, exportedPortList.ID, portListID, func() error {
+			return client.DeletePortList(portListID)
+		})
+	}
+
+	return nil
+}
+
+func (importer *networkDomainImporter) importFirewallRules() error {
+	client := importer.client
+	for _, exportedRule := range importer.export.FirewallRules {
+		// Default rules are created by the system when the network domain is deployed.
+		if exportedRule.RuleType != "CLIENT_RULE" {
+			continue
+		}
+
+		// Rules are exported in evaluation order, so appending each one preserves that order.
+		ruleConfiguration := FirewallRuleConfiguration{
+			Name:      exportedRule.Name,
+			Action:    exportedRule.Action,
+			Enabled:   exportedRule.Enabled,
+			IPVersion: exportedRule.IPVersion,
+			Protocol:  exportedRule.Protocol,
+			Placement: FirewallRulePlacement{
+				Position: "LAST",
+			},
+			Source:          importer.toFirewallRuleScope(exportedRule.Source),
+			Destination:     importer.toFirewallRuleScope(exportedRule.Destination),
+			NetworkDomainID: importer.result.NetworkDomainID,
+		}
+		ruleID, err := client.CreateFirewallRule(ruleConfiguration)
+		if err != nil {
+			return err
+		}
+		importer.created("firewall rule", exportedRule.Name, exportedRule.ID, ruleID, func() error {
+			return client.DeleteFirewallRule(ruleID)
+		})
+
+		_, err = client.WaitForDeploy(ResourceTypeFirewallRule, ruleID, importer.options.StepTimeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Convert an exported firewall rule scope to a FirewallRuleScope (referring to lists in the new network domain).
+func (importer *networkDomainImporter) toFirewallRuleScope(exportedScope ExportedFirewallRuleScope) FirewallRuleScope {
+	scope := FirewallRuleScope{
+		IPAddress: exportedScope.IPAddress,
+		Port:      exportedScope.Port,
+	}
+	if exportedScope.AddressList != "" {
+		scope.AddressList = &EntityReference{
+			ID: importer.addressListIDs[exportedScope.AddressList],
+		}
+	}
+	if exportedScope.PortList != "" {
+		portListID := importer.portListIDs[exportedScope.PortList]
+		scope.PortListID = &portListID
+	}
+
+	return scope
+}
+
+func (importer *networkDomainImporter) importServers() error {
+	client := importer.client
+	for _, exportedServer := range importer.export.Servers {
+		deploymentConfiguration := ServerDeploymentConfiguration{
+			Name:                  exportedServer.Name,
+			Description:           exportedServer.Description,
+			ImageID:               importer.options.ImageIDs[exportedServer.ImageID],
+			AdministratorPassword: importer.options.AdministratorPassword,
+			CPU:                   exportedServer.CPU,
+			MemoryGB:              exportedServer.MemoryGB,
+			Network: VirtualMachineNetwork{
+				NetworkDomainID: importer.result.NetworkDomainID,
+			},
+			PrimaryDNS:   importer.options.PrimaryDNS,
+			SecondaryDNS: importer.options.SecondaryDNS,
+			Start:        exportedServer.Started,
+		}
+		for _, exportedAdapter := range exportedServer.NetworkAdapters {
+			networkAdapter := importer.toNetworkAdapter(exportedAdapter)
+			if exportedAdapter.IsPrimary {
+				deploymentConfiguration.Network.PrimaryAdapter = networkAdapter
+			} else {
+				deploymentConfiguration.Network.AdditionalNetworkAdapters = append(deploymentConfiguration.Network.AdditionalNetworkAdapters, networkAdapter)
+			}
+		}
+
+		serverID, err := client.DeployServer(deploymentConfiguration)
+		if err != nil {
+			return err
+		}
+		importer.serverIDs[exportedServer.Name] = serverID
+		importer.created("server", exportedServer.Name, exportedServer.ID, serverID, func() error {
+			return client.powerOffAndDeleteServer(serverID, importer.options.StepTimeout)
+		})
+
+		_, err = client.WaitForDeploy(ResourceTypeServer, serverID, importer.options.StepTimeout)
+		if err != nil {
+			return err
+		}
+
+		// The server's disks come from its image, so they may need to be resized, added, or removed to match the exported server.
+		diskSpecs := make([]ServerDiskSpecification, len(exportedServer.Disks))
+		for index, exportedDisk := range exportedServer.Disks {
+			diskSpecs[index] = ServerDiskSpecification{
+				SCSIUnitID: exportedDisk.SCSIUnitID,
+				SizeGB:     exportedDisk.SizeGB,
+				Speed:      exportedDisk.Speed,
+			}
+		}
+		plan, err := client.PlanServerReconciliation(serverID, ServerSpecification{
+			Disks:               diskSpecs,
+			RemoveUnlistedDisks: true,
+		})
+		if err != nil {
+			return err
+		}
+		err = client.ApplyServerReconciliationPlan(plan, importer.options.StepTimeout)
+		if err != nil {
+			return err
+		}
+
+		err = importer.applyTags(serverID, AssetTypeServer, exportedServer.Tags)
+		if err != nil {
+			return err
+		}
+
+		if exportedServer.BackupServicePlan != "" {
+			err = client.EnableServerBackup(serverID, exportedServer.BackupServicePlan)
+			if err != nil {
+				return err
+			}
+		}
+		if exportedServer.MonitoringPlan != "" {
+			err = client.EnableServerMonitoring(serverID, exportedServer.MonitoringPlan)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Convert an exported network adapter to a VirtualMachineNetworkAdapter (on a VLAN in the new network domain).
+//
+// Exactly one of VLANID / PrivateIPv4Address can be specified when deploying a server, so the private IPv4 address is preferred (if known).
+func (importer *networkDomainImporter) toNetworkAdapter(exportedAdapter ExportedNetworkAdapter) VirtualMachineNetworkAdapter {
+	networkAdapter := VirtualMachineNetworkAdapter{}
+	if exportedAdapter.PrivateIPv4Address != "" {
+		privateIPv4Address := exportedAdapter.PrivateIPv4Address
+		networkAdapter.PrivateIPv4Address = &privateIPv4Address
+	} else {
+		vlanID := importer.vlanIDs[exportedAdapter.VLAN]
+		networkAdapter.VLANID = &vlanID
+	}
+	if exportedAdapter.AdapterType != "" {
+		adapterType := exportedAdapter.AdapterType
+		networkAdapter.AdapterType = &adapterType
+	}
+
+	return networkAdapter
+}
+
+func (importer *networkDomainImporter) importPublicIPBlocks() error {
+	client := importer.client
+	for _, exportedBlock := range importer.export.PublicIPBlocks {
+		blockID, err := client.AddPublicIPBlock(importer.result.NetworkDomainID)
+		if err != nil {
+			return err
+		}
+		importer.created("public IP block", exportedBlock.BaseIP, exportedBlock.ID, blockID, func() error {
+			return client.RemovePublicIPBlock(blockID)
+		})
+
+		block, err := client.GetPublicIPBlock(blockID)
+		if err != nil {
+			return err
+		}
+		if block == nil {
+			return fmt.Errorf("No public IP block was found with Id '%s'.", blockID)
+		}
+		if block.State != ResourceStatusNormal {
+			var resource Resource
+			resource, err = client.WaitForDeploy(ResourceTypePublicIPBlock, blockID, importer.options.StepTimeout)
+			if err != nil {
+				return err
+			}
+			block = resource.(*PublicIPBlock)
+		}
+		importer.publicIPBlocks = append(importer.publicIPBlocks, *block)
+
+		err = importer.applyTags(blockID, AssetTypePublicIPBlock, exportedBlock.Tags)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Map a public IPv4 address in the exported network domain to the address at the same position in the corresponding public IP block of the new network domain.
+// Returns an empty string if the address does not belong to any of the exported public IP blocks.
+func (importer *networkDomainImporter) mapPublicIPv4Address(address string) (string, error) {
+	blockIndex := findExportedPublicIPBlock(importer.export.PublicIPBlocks, address)
+	if blockIndex == -1 {
+		return "", nil
+	}
+
+	exportedBlock := importer.export.PublicIPBlocks[blockIndex]
+	newBlock := importer.publicIPBlocks[blockIndex]
+	if newBlock.Size < exportedBlock.Size {
+		return "", fmt.Errorf("Public IP block '%s' has %d address(es), but the corresponding exported block '%s' has %d.", newBlock.ID, newBlock.Size, exportedBlock.ID, exportedBlock.Size)
+	}
+
+	exportedBaseIP, err := parseIPv4Address(exportedBlock.BaseIP)
+	if err != nil {
+		return "", err
+	}
+	newBaseIP, err := parseIPv4Address(newBlock.BaseIP)
+	if err != nil {
+		return "", err
+	}
+	ip, err := parseIPv4Address(address)
+	if err != nil {
+		return "", err
+	}
+
+	offset := ipv4ToUint32(ip) - ipv4ToUint32(exportedBaseIP)
+	mappedAddress := uint32ToIPv4(ipv4ToUint32(newBaseIP) + offset).String()
+	importer.result.PublicIPv4Addresses[address] = mappedAddress
+
+	return mappedAddress, nil
+}
+
+// Find the index of the exported public IP block (if any) that contains the specified address.
+// Returns -1 if no block contains the address.
+func findExportedPublicIPBlock(blocks []ExportedPublicIPBlock, address string) int {
+	ip, err := parseIPv4Address(address)
+	if err != nil {
+		return -1
+	}
+	numericAddress := uint64(ipv4ToUint32(ip))
+
+	for index, block := range blocks {
+		baseIP, err := parseIPv4Address(block.BaseIP)
+		if err != nil {
+			continue
+		}
+		baseAddress := uint64(ipv4ToUint32(baseIP))
+		if numericAddress >= baseAddress && numericAddress < baseAddress+uint64(block.Size) {
+			return index
+		}
+	}
+
+	return -1
+}
+
+func (importer *networkDomainImporter) importNATRules() error {
+	client := importer.client
+	for _, exportedRule := range importer.export.NATRules {
+		externalIPAddress, err := importer.mapPublicIPv4Address(exportedRule.ExternalIPAddress)
+		if err != nil {
+			return err
+		}
+
+		natRuleID, err := client.AddNATRule(importer.result.NetworkDomainID, exportedRule.InternalIPAddress, &externalIPAddress)
+		if err != nil {
+			return err
+		}
+		importer.created("NAT rule", fmt.Sprintf("%s -> %s", externalIPAddress, exportedRule.InternalIPAddress), exportedRule.ID, natRuleID, func() error {
+			return client.DeleteNATRule(natRuleID)
+		})
+
+		_, err = client.WaitForDeploy(ResourceTypeNATRule, natRuleID, importer.options.StepTimeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Load the names and Ids of the new network domain's default health monitors, persistence profiles, and iRules.
+//
+// There are only a handful of each, so a single page of results is sufficient.
+func (importer *networkDomainImporter) loadLoadBalancerDefaults() error {
+	if importer.healthMonitorIDs != nil {
+		return nil
+	}
+
+	client := importer.client
+	networkDomainID := importer.result.NetworkDomainID
+
+	healthMonitors, err := client.listAllDefaultHealthMonitors(networkDomainID)
+	if err != nil {
+		return err
+	}
+	persistenceProfiles, err := client.listAllDefaultPersistenceProfiles(networkDomainID)
+	if err != nil {
+		return err
+	}
+	iRules, err := client.listAllDefaultIRules(networkDomainID)
+	if err != nil {
+		return err
+	}
+
+	importer.healthMonitorIDs = make(map[string]string)
+	for _, healthMonitor := range healthMonitors {
+		importer.healthMonitorIDs[healthMonitor.Name] = healthMonitor.ID
+	}
+	importer.persistenceProfileIDs = make(map[string]string)
+	for _, persistenceProfile := range persistenceProfiles {
+		importer.persistenceProfileIDs[persistenceProfile.Name] = persistenceProfile.ID
+	}
+	importer.iRuleIDs = make(map[string]string)
+	for _, iRule := range iRules {
+		importer.iRuleIDs[iRule.Name] = iRule.ID
+	}
+
+	return nil
+}
+
+// Look up the Id of a default load-balancer object (by name) in the new network domain.
+func lookUpLoadBalancerDefault(ids map[string]string, description string, name string) (string, error) {
+	id, ok := ids[name]
+	if !ok {
+		return "", fmt.Errorf("No %s named '%s' is available in the new network domain.", description, name)
+	}
+
+	return id, nil
+}
+
+func (importer *networkDomainImporter) importVIPNodes() error {
+	client := importer.client
+	for _, exportedNode := range importer.export.VIPNodes {
+		err := importer.loadLoadBalancerDefaults()
+		if err != nil {
+			return err
+		}
+
+		nodeConfiguration := NewVIPNodeConfiguration{
+			Name:                exportedNode.Name,
+			Description:         exportedNode.Description,
+			IPv4Address:         exportedNode.IPv4Address,
+			IPv6Address:         exportedNode.IPv6Address,
+			Status:              exportedNode.Status,
+			ConnectionLimit:     exportedNode.ConnectionLimit,
+			ConnectionRateLimit: exportedNode.ConnectionRateLimit,
+			NetworkDomainID:     importer.result.NetworkDomainID,
+		}
+		if exportedNode.HealthMonitor != "" {
+			nodeConfiguration.HealthMonitorID, err = lookUpLoadBalancerDefault(importer.healthMonitorIDs, "health monitor", exportedNode.HealthMonitor)
+			if err != nil {
+				return err
+			}
+		}
+
+		nodeID, err := client.CreateVIPNode(nodeConfiguration)
+		if err != nil {
+			return err
+		}
+		importer.vipNodeIDs[exportedNode.Name] = nodeID
+		importer.created("VIP node", exportedNode.Name, exportedNode.ID, nodeID, func() error {
+			return client.DeleteVIPNode(nodeID)
+		})
+
+		_, err = client.WaitForDeploy(ResourceTypeVIPNode, nodeID, importer.options.StepTimeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (importer *networkDomainImporter) importVIPPools() error {
+	client := importer.client
+	for _, exportedPool := range importer.export.VIPPools {
+		err := importer.loadLoadBalancerDefaults()
+		if err != nil {
+			return err
+		}
+
+		poolConfiguration := NewVIPPoolConfiguration{
+			Name:              exportedPool.Name,
+			Description:       exportedPool.Description,
+			LoadBalanceMethod: exportedPool.LoadBalanceMethod,
+			ServiceDownAction: exportedPool.ServiceDownAction,
+			SlowRampTime:      exportedPool.SlowRampTime,
+			NetworkDomainID:   importer.result.NetworkDomainID,
+		}
+		for _, healthMonitorName := range exportedPool.HealthMonitors {
+			var healthMonitorID string
+			healthMonitorID, err = lookUpLoadBalancerDefault(importer.healthMonitorIDs, "health monitor", healthMonitorName)
+			if err != nil {
+				return err
+			}
+			poolConfiguration.HealthMonitorIDs = append(poolConfiguration.HealthMonitorIDs, healthMonitorID)
+		}
+
+		poolID, err := client.CreateVIPPool(poolConfiguration)
+		if err != nil {
+			return err
+		}
+		importer.vipPoolIDs[exportedPool.Name] = poolID
+		importer.created("VIP pool", exportedPool.Name, exportedPool.ID, poolID, func() error {
+			return client.DeleteVIPPool(poolID)
+		})
+
+		// Members can only be added once the pool has been deployed.
+		_, err = client.WaitForDeploy(ResourceTypeVIPPool, poolID, importer.options.StepTimeout)
+		if err != nil {
+			return err
+		}
+
+		for _, exportedMember := range exportedPool.Members {
+			memberID, err := client.AddVIPPoolMember(poolID, importer.vipNodeIDs[exportedMember.Node], exportedMember.Status, exportedMember.Port)
+			if err != nil {
+				return err
+			}
+			importer.created("VIP pool member", fmt.Sprintf("%s/%s", exportedPool.Name, exportedMember.Node), "", memberID, func() error {
+				return client.RemoveVIPPoolMember(memberID)
+			})
+
+			_, err = client.WaitForDeploy(ResourceTypeVIPPoolMember, memberID, importer.options.StepTimeout)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (importer *networkDomainImporter) importVirtualListeners() error {
+	client := importer.client
+	for _, exportedListener := range importer.export.VirtualListeners {
+		err := importer.loadLoadBalancerDefaults()
+		if err != nil {
+			return err
+		}
+
+		listenerConfiguration := NewVirtualListenerConfiguration{
+			Name:                   exportedListener.Name,
+			Description:            exportedListener.Description,
+			Type:                   exportedListener.Type,
+			Protocol:               exportedListener.Protocol,
+			Port:                   exportedListener.Port,
+			Enabled:                exportedListener.Enabled,
+			ConnectionLimit:        exportedListener.ConnectionLimit,
+			ConnectionRateLimit:    exportedListener.ConnectionRateLimit,
+			SourcePortPreservation: exportedListener.SourcePortPreservation,
+			OptimizationProfiles:   exportedListener.OptimizationProfiles,
+			NetworkDomainID:        importer.result.NetworkDomainID,
+		}
+
+		listenerConfiguration.ListenerIPAddress, err = importer.mapListenerIPAddress(exportedListener.ListenerIPAddress)
+		if err != nil {
+			return err
+		}
+		if exportedListener.Pool != "" {
+			poolID := importer.vipPoolIDs[exportedListener.Pool]
+			listenerConfiguration.PoolID = &poolID
+		}
+		if exportedListener.ClientClonePool != "" {
+			clientClonePoolID := importer.vipPoolIDs[exportedListener.ClientClonePool]
+			listenerConfiguration.ClientClonePoolID = &clientClonePoolID
+		}
+		if exportedListener.PersistenceProfile != "" {
+			var persistenceProfileID string
+			persistenceProfileID, err = lookUpLoadBalancerDefault(importer.persistenceProfileIDs, "persistence profile", exportedListener.PersistenceProfile)
+			if err != nil {
+				return err
+			}
+			listenerConfiguration.PersistenceProfileID = &persistenceProfileID
+		}
+		if exportedListener.FallbackPersistenceProfile != "" {
+			var fallbackPersistenceProfileID string
+			fallbackPersistenceProfileID, err = lookUpLoadBalancerDefault(importer.persistenceProfileIDs, "persistence profile", exportedListener.FallbackPersistenceProfile)
+			if err != nil {
+				return err
+			}
+			listenerConfiguration.FallbackPersistenceProfileID = &fallbackPersistenceProfileID
+		}
+		for _, iRuleName := range exportedListener.IRules {
+			var iRuleID string
+			iRuleID, err = lookUpLoadBalancerDefault(importer.iRuleIDs, "iRule", iRuleName)
+			if err != nil {
+				return err
+			}
+			listenerConfiguration.IRuleIDs = append(listenerConfiguration.IRuleIDs, iRuleID)
+		}
+
+		listenerID, err := client.CreateVirtualListener(listenerConfiguration)
+		if err != nil {
+			return err
+		}
+		importer.created("virtual listener", exportedListener.Name, exportedListener.ID, listenerID, func() error {
+			return client.DeleteVirtualListener(listenerID)
+		})
+
+		_, err = client.WaitForDeploy(ResourceTypeVirtualListener, listenerID, importer.options.StepTimeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Determine the IP address for an imported virtual listener.
+//
+// Public addresses are mapped onto the new network domain's public IP blocks, and private addresses (on an imported VLAN) are retained.
+// Otherwise, nil is returned (so the system will allocate an address).
+func (importer *networkDomainImporter) mapListenerIPAddress(address string) (*string, error) {
+	if address == "" {
+		return nil, nil
+	}
+
+	mappedAddress, err := importer.mapPublicIPv4Address(address)
+	if err != nil {
+		return nil, err
+	}
+	if mappedAddress != "" {
+		return &mappedAddress, nil
+	}
+
+	for _, exportedVLAN := range importer.export.VLANs {
+		if exportedVLAN.IPv4Range.Contains(address) {
+			return &address, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (importer *networkDomainImporter) importAntiAffinityRules() error {
+	client := importer.client
+	for _, exportedRule := range importer.export.AntiAffinityRules {
+		ruleID, err := client.CreateServerAntiAffinityRule(
+			importer.serverIDs[exportedRule.Servers[0]],
+			importer.serverIDs[exportedRule.Servers[1]],
+		)
+		if err != nil {
+			return err
+		}
+		importer.created("server anti-affinity rule", strings.Join(exportedRule.Servers, "/"), exportedRule.ID, ruleID, func() error {
+			return client.DeleteServerAntiAffinityRule(ruleID, importer.result.NetworkDomainID)
+		})
+	}
+
+	return nil
+}
+
+// Order exported IP address lists so that each list appears after all of its child lists.
+func orderExportedIPAddressLists(addressLists []ExportedIPAddressList) ([]ExportedIPAddressList, error) {
+	childListNames := make(map[string][]string)
+	names := make([]string, len(addressLists))
+	for index, addressList := range addressLists {
+		names[index] = addressList.Name
+		childListNames[addressList.Name] = addressList.ChildLists
+	}
+
+	order, err := orderByDependency("IP address list", names, childListNames)
+	if err != nil {
+		return nil, err
+	}
+
+	orderedLists := make([]ExportedIPAddressList, len(order))
+	for index, listIndex := range order {
+		orderedLists[index] = addressLists[listIndex]
+	}
+
+	return orderedLists, nil
+}
+
+// Order exported port lists so that each list appears after all of its child lists.
+func orderExportedPortLists(portLists []ExportedPortList) ([]ExportedPortList, error) {
+	childListNames := make(map[string][]string)
+	names := make([]string, len(portLists))
+	for index, portList := range portLists {
+		names[index] = portList.Name
+		childListNames[portList.Name] = portList.ChildLists
+	}
+
+	order, err := orderByDependency("port list", names, childListNames)
+	if err != nil {
+		return nil, err
+	}
+
+	orderedLists := make([]ExportedPortList, len(order))
+	for index, listIndex := range order {
+		orderedLists[index] = portLists[listIndex]
+	}
+
+	return orderedLists, nil
+}
+
+// Order named items so that each item appears after all of its dependencies (preserving the original order where possible).
+// Returns the indexes of the items in dependency order, or an error if a dependency is missing or circular.
+func orderByDependency(description string, names []string, dependencies map[string][]string) ([]int, error) {
+	indexes := make(map[string]int)
+	for index, name := range names {
+		indexes[name] = index
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	states := make([]int, len(names))
+	order := make([]int, 0, len(names))
+
+	var visit func(index int) error
+	visit = func(index int) error {
+		switch states[index] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("The %s '%s' (directly or indirectly) contains itself.", description, names[index])
+		}
+
+		states[index] = visiting
+		for _, dependencyName := range dependencies[names[index]] {
+			dependencyIndex, ok := indexes[dependencyName]
+			if !ok {
+				return fmt.Errorf("The %s '%s' refers to child list '%s', which is not in the export.", description, names[index], dependencyName)
+			}
+
+			err := visit(dependencyIndex)
+			if err != nil {
+				return err
+			}
+		}
+		states[index] = visited
+		order = append(order, index)
+
+		return nil
+	}
+	for index := range names {
+		err := visit(index)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
+}
@@ -0,0 +1,271 @@
+package compute
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// Validate network domain import (valid export).
+func TestNetworkDomainExport_ValidateImport_Valid(test *testing.T) {
+	export := createNetworkDomainExportForImportTest()
+
+	err := export.ValidateImport(createNetworkDomainImportOptionsForTest())
+	if err != nil {
+		test.Fatal(err)
+	}
+}
+
+// Validate network domain import (invalid exports / options).
+func TestNetworkDomainExport_ValidateImport_Invalid(test *testing.T) {
+	expect := expect(test)
+
+	export := createNetworkDomainExportForImportTest()
+	options := createNetworkDomainImportOptionsForTest()
+	options.AdministratorPassword = ""
+	err := export.ValidateImport(options)
+	expect.NotNil("ValidateImport error (no administrator password)", err)
+
+	options = createNetworkDomainImportOptionsForTest()
+	delete(options.ImageIDs, "e926545a-1d3b-4d5d-93b7-28a6e8ac5b5b")
+	err = export.ValidateImport(options)
+	expect.NotNil("ValidateImport error (unmapped image)", err)
+	expect.IsTrue("Error message mentions image", strings.Contains(err.Error(), "e926545a-1d3b-4d5d-93b7-28a6e8ac5b5b"))
+
+	export = createNetworkDomainExportForImportTest()
+	export.Servers[0].NetworkAdapters[0].VLAN = "No Such VLAN"
+	err = export.ValidateImport(createNetworkDomainImportOptionsForTest())
+	expect.NotNil("ValidateImport error (unknown VLAN)", err)
+
+	export = createNetworkDomainExportForImportTest()
+	export.IPAddressLists[1].ChildLists = []string{"All Servers"}
+	err = export.ValidateImport(createNetworkDomainImportOptionsForTest())
+	expect.NotNil("ValidateImport error (circular IP address lists)", err)
+	expect.IsTrue("Error message mentions circular list", strings.Contains(err.Error(), "contains itself"))
+
+	export = createNetworkDomainExportForImportTest()
+	export.NATRules[0].ExternalIPAddress = "168.128.2.10"
+	err = export.ValidateImport(createNetworkDomainImportOptionsForTest())
+	expect.NotNil("ValidateImport error (NAT rule outside public IP blocks)", err)
+
+	export = createNetworkDomainExportForImportTest()
+	export.AntiAffinityRules[0].Servers = []string{"Web 1"}
+	err = export.ValidateImport(createNetworkDomainImportOptionsForTest())
+	expect.NotNil("ValidateImport error (anti-affinity rule with 1 server)", err)
+}
+
+// Validate network domain import (duplicate names).
+func TestNetworkDomainExport_ValidateImport_DuplicateNames(test *testing.T) {
+	expect := expect(test)
+
+	export := createNetworkDomainExportForImportTest()
+	export.Servers[1].Name = export.Servers[0].Name
+	err := export.ValidateImport(createNetworkDomainImportOptionsForTest())
+	expect.NotNil("ValidateImport error (duplicate server names)", err)
+	expect.IsTrue("Error message mentions server", strings.Contains(err.Error(), "more than one server named 'Web 1'"))
+
+	export = createNetworkDomainExportForImportTest()
+	export.IPAddressLists[2].Name = export.IPAddressLists[1].Name
+	err = export.ValidateImport(createNetworkDomainImportOptionsForTest())
+	expect.NotNil("ValidateImport error (duplicate IP address list names)", err)
+	expect.IsTrue("Error message mentions IP address list", strings.Contains(err.Error(), "more than one IP address list named"))
+
+	export = createNetworkDomainExportForImportTest()
+	export.PortLists = []ExportedPortList{
+		ExportedPortList{ID: "0a7c4b3e-1b9f-4a61-9d8a-4a6e0f1e2d3c", Name: "Web"},
+		ExportedPortList{ID: "5b3e1f0d-8c2a-4e6b-9f1d-2a7c4e8b0d3f", Name: "Web"},
+	}
+	err = export.ValidateImport(createNetworkDomainImportOptionsForTest())
+	expect.NotNil("ValidateImport error (duplicate port list names)", err)
+	expect.IsTrue("Error message mentions port list", strings.Contains(err.Error(), "more than one port list named 'Web'"))
+
+	export = createNetworkDomainExportForImportTest()
+	export.VIPNodes = []ExportedVIPNode{
+		ExportedVIPNode{ID: "34de6ed6-46a4-4dae-a753-2f8d3840c6f9", Name: "Web Node", IPv4Address: "10.0.3.10"},
+		ExportedVIPNode{ID: "8a5c2e1f-3b4d-4f6a-9c7e-1d2b3a4c5e6f", Name: "Web Node", IPv4Address: "10.0.3.11"},
+	}
+	err = export.ValidateImport(createNetworkDomainImportOptionsForTest())
+	expect.NotNil("ValidateImport error (duplicate VIP node names)", err)
+	expect.IsTrue("Error message mentions VIP node", strings.Contains(err.Error(), "more than one VIP node named 'Web Node'"))
+
+	export = createNetworkDomainExportForImportTest()
+	export.VIPPools = []ExportedVIPPool{
+		ExportedVIPPool{ID: "4d360b1f-bc2c-4ab7-9884-1f03ba2768f7", Name: "Web Pool"},
+		ExportedVIPPool{ID: "9e8d7c6b-5a4f-4e3d-8c2b-1a0f9e8d7c6b", Name: "Web Pool"},
+	}
+	err = export.ValidateImport(createNetworkDomainImportOptionsForTest())
+	expect.NotNil("ValidateImport error (duplicate VIP pool names)", err)
+	expect.IsTrue("Error message mentions VIP pool", strings.Contains(err.Error(), "more than one VIP pool named 'Web Pool'"))
+}
+
+// Order exported IP address lists (child lists first).
+func TestOrderExportedIPAddressLists(test *testing.T) {
+	expect := expect(test)
+
+	addressLists, err := orderExportedIPAddressLists(createNetworkDomainExportForImportTest().IPAddressLists)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	expect.EqualsInt("AddressLists.Length", 3, len(addressLists))
+	expect.EqualsString("AddressLists[0].Name", "Web Servers", addressLists[0].Name)
+	expect.EqualsString("AddressLists[1].Name", "App Servers", addressLists[1].Name)
+	expect.EqualsString("AddressLists[2].Name", "All Servers", addressLists[2].Name)
+}
+
+// Map public IPv4 addresses from an exported network domain to an imported one.
+func TestNetworkDomainImporter_MapPublicIPv4Address(test *testing.T) {
+	expect := expect(test)
+
+	importer := &networkDomainImporter{
+		export: createNetworkDomainExportForImportTest(),
+		result: &NetworkDomainImportResult{
+			PublicIPv4Addresses: make(map[string]string),
+		},
+		publicIPBlocks: []PublicIPBlock{
+			PublicIPBlock{ID: "4487241a-f0ca-11e3-9315-d4bed9b167ba", BaseIP: "165.180.9.20", Size: 2},
+		},
+	}
+
+	mappedAddress, err := importer.mapPublicIPv4Address("168.128.1.11")
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.EqualsString("MappedAddress", "165.180.9.21", mappedAddress)
+	expect.EqualsString("Result.PublicIPv4Addresses[168.128.1.11]", "165.180.9.21", importer.result.PublicIPv4Addresses["168.128.1.11"])
+
+	mappedAddress, err = importer.mapPublicIPv4Address("10.0.3.15")
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.EqualsString("MappedAddress (private)", "", mappedAddress)
+
+	listenerIPAddress, err := importer.mapListenerIPAddress("10.0.3.15")
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.NotNil("ListenerIPAddress (private)", listenerIPAddress)
+	expect.EqualsString("ListenerIPAddress (private)", "10.0.3.15", *listenerIPAddress)
+
+	listenerIPAddress, err = importer.mapListenerIPAddress("192.168.70.1")
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.IsNil("ListenerIPAddress (unknown)", listenerIPAddress)
+}
+
+// Import network domain (deployment of network domain fails).
+func TestClient_ImportNetworkDomain_DeployFailure(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			options := createNetworkDomainImportOptionsForTest()
+			options.RollbackOnFailure = true
+
+			result, err := client.ImportNetworkDomain(createNetworkDomainExportForImportTest(), options)
+			expect.NotNil("ImportNetworkDomain error", err)
+			expect.NotNil("ImportNetworkDomain result", result)
+			expect.EqualsInt("Result.Resources.Length", 0, len(result.Resources))
+
+			importError, ok := err.(*NetworkDomainImportError)
+			expect.IsTrue("Error is NetworkDomainImportError", ok)
+			expect.EqualsString("NetworkDomainImportError.FailedStep", "Deploy network domain", importError.FailedStep)
+			expect.IsTrue("NetworkDomainImportError.RolledBack", importError.RolledBack)
+			expect.IsTrue("Error message mentions cause", strings.Contains(err.Error(), "currently busy"))
+		},
+		Respond: testRespond(400, resourceBusyTestResponse),
+	})
+}
+
+func createNetworkDomainImportOptionsForTest() NetworkDomainImportOptions {
+	return NetworkDomainImportOptions{
+		DatacenterID:          "AU10",
+		AdministratorPassword: "snausages!",
+		ImageIDs: map[string]string{
+			"e926545a-1d3b-4d5d-93b7-28a6e8ac5b5b": "1e44ab3f-2426-45ec-a1b5-827b2ce58836",
+		},
+		StepTimeout: 5 * time.Minute,
+	}
+}
+
+func createNetworkDomainExportForImportTest() *NetworkDomainExport {
+	return &NetworkDomainExport{
+		FormatVersion: NetworkDomainExportFormatVersion,
+		NetworkDomain: ExportedNetworkDomain{
+			ID:           "8cdfd607-f429-4df6-9352-162cfc0891be",
+			Name:         "Production",
+			Type:         NetworkDomainTypeAdvanced,
+			DatacenterID: "AU9",
+		},
+		VLANs: []ExportedVLAN{
+			ExportedVLAN{
+				ID:        "0e56433f-d808-4669-821d-812769517ff8",
+				Name:      "Web",
+				IPv4Range: IPv4Range{BaseAddress: "10.0.3.0", PrefixSize: 24},
+			},
+		},
+		Servers: []ExportedServer{
+			ExportedServer{
+				ID:       "5a32d6e4-9707-4813-a269-56ab4d989f4d",
+				Name:     "Web 1",
+				ImageID:  "e926545a-1d3b-4d5d-93b7-28a6e8ac5b5b",
+				MemoryGB: 4,
+				NetworkAdapters: []ExportedNetworkAdapter{
+					ExportedNetworkAdapter{IsPrimary: true, VLAN: "Web", PrivateIPv4Address: "10.0.3.10"},
+				},
+			},
+			ExportedServer{
+				ID:       "b8caf5c6-a2e4-4d76-8f9e-2d5f0c6ff0a7",
+				Name:     "Web 2",
+				ImageID:  "e926545a-1d3b-4d5d-93b7-28a6e8ac5b5b",
+				MemoryGB: 4,
+				NetworkAdapters: []ExportedNetworkAdapter{
+					ExportedNetworkAdapter{IsPrimary: true, VLAN: "Web", PrivateIPv4Address: "10.0.3.11"},
+				},
+			},
+		},
+		IPAddressLists: []ExportedIPAddressList{
+			ExportedIPAddressList{
+				ID:         "c8c92ea3-2da8-4d51-8153-f39bec794d69",
+				Name:       "All Servers",
+				IPVersion:  "IPV4",
+				ChildLists: []string{"Web Servers", "App Servers"},
+			},
+			ExportedIPAddressList{
+				ID:        "1b1e5b4d-c4f5-4fe4-9e02-4d8f0e1b8d3c",
+				Name:      "Web Servers",
+				IPVersion: "IPV4",
+				Addresses: []IPAddressListEntry{
+					IPAddressListEntry{Begin: "10.0.3.10"},
+				},
+			},
+			ExportedIPAddressList{
+				ID:         "5e7f3e3d-1a4f-4a8b-a4b3-0d6e6b2f3e1a",
+				Name:       "App Servers",
+				IPVersion:  "IPV4",
+				ChildLists: []string{"Web Servers"},
+			},
+		},
+		FirewallRules: []ExportedFirewallRule{
+			ExportedFirewallRule{
+				ID:       "d0a02ba8-7d3c-4f67-8bcb-1b0a1cf9e0a5",
+				Name:     "AllowWeb",
+				RuleType: "CLIENT_RULE",
+				Action:   FirewallRuleActionAccept,
+				Destination: ExportedFirewallRuleScope{
+					AddressList: "Web Servers",
+				},
+			},
+		},
+		PublicIPBlocks: []ExportedPublicIPBlock{
+			ExportedPublicIPBlock{ID: "cacc028a-7f12-11e4-a91c-0030487e0302", BaseIP: "168.128.1.10", Size: 2},
+		},
+		NATRules: []ExportedNATRule{
+			ExportedNATRule{ID: "2169a38e-5692-497e-a22a-701a838a6539", InternalIPAddress: "10.0.3.10", ExternalIPAddress: "168.128.1.11"},
+		},
+		AntiAffinityRules: []ExportedAntiAffinityRule{
+			ExportedAntiAffinityRule{ID: "AU9:3e9b1d1b-0b5f-45d5-9a45-6e5a0f0c5b3f", Servers: []string{"Web 1", "Web 2"}},
+		},
+	}
+}
@@ -0,0 +1,758 @@
+package compute
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// DoNotDeleteTagName is the name of the tag that protects an asset (network domain, VLAN, server, or public IP block) from being deleted by TeardownNetworkDomain.
+//
+// The tag's value is ignored.
+const DoNotDeleteTagName = "do-not-delete"
+
+// Well-known network domain teardown actions.
+const (
+	// NetworkDomainTeardownActionDeleteVirtualListener represents the deletion of a virtual listener.
+	NetworkDomainTeardownActionDeleteVirtualListener = "DELETE_VIRTUAL_LISTENER"
+
+	// NetworkDomainTeardownActionRemoveVIPPoolMember represents the removal of a member from a VIP pool.
+	NetworkDomainTeardownActionRemoveVIPPoolMember = "REMOVE_POOL_MEMBER"
+
+	// NetworkDomainTeardownActionDeleteVIPPool represents the deletion of a VIP pool.
+	NetworkDomainTeardownActionDeleteVIPPool = "DELETE_POOL"
+
+	// NetworkDomainTeardownActionDeleteVIPNode represents the deletion of a VIP node.
+	NetworkDomainTeardownActionDeleteVIPNode = "DELETE_NODE"
+
+	// NetworkDomainTeardownActionDeleteNATRule represents the deletion of a NAT rule.
+	NetworkDomainTeardownActionDeleteNATRule = "DELETE_NAT_RULE"
+
+	// NetworkDomainTeardownActionDeleteFirewallRule represents the deletion of a (client-defined) firewall rule.
+	NetworkDomainTeardownActionDeleteFirewallRule = "DELETE_FIREWALL_RULE"
+
+	// NetworkDomainTeardownActionDeleteIPAddressList represents the deletion of an IP address list.
+	NetworkDomainTeardownActionDeleteIPAddressList = "DELETE_IP_ADDRESS_LIST"
+
+	// NetworkDomainTeardownActionDeletePortList represents the deletion of a port list.
+	NetworkDomainTeardownActionDeletePortList = "DELETE_PORT_LIST"
+
+	// NetworkDomainTeardownActionDeleteServer represents the deletion of a server (powering it off first, if required).
+	NetworkDomainTeardownActionDeleteServer = "DELETE_SERVER"
+
+	// NetworkDomainTeardownActionDeleteAntiAffinityRule represents the deletion of a server anti-affinity rule.
+	NetworkDomainTeardownActionDeleteAntiAffinityRule = "DELETE_ANTI_AFFINITY_RULE"
+
+	// NetworkDomainTeardownActionDeleteVLAN represents the deletion of a VLAN.
+	NetworkDomainTeardownActionDeleteVLAN = "DELETE_VLAN"
+
+	// NetworkDomainTeardownActionRemovePublicIPBlock represents the removal of a public IPv4 address block.
+	NetworkDomainTeardownActionRemovePublicIPBlock = "REMOVE_PUBLIC_IP_BLOCK"
+
+	// NetworkDomainTeardownActionDeleteNetworkDomain represents the deletion of the network domain itself.
+	NetworkDomainTeardownActionDeleteNetworkDomain = "DELETE_NETWORK_DOMAIN"
+)
+
+// NetworkDomainTeardownStep represents a single operation in a NetworkDomainTeardownPlan.
+type NetworkDomainTeardownStep struct {
+	// The action performed by the step (e.g. NetworkDomainTeardownActionDeleteServer).
+	Action string
+
+	// A human-readable description of the step.
+	Description string
+
+	// The Id of the resource to delete.
+	ResourceID string
+
+	// The name of the resource to delete (or, for resources without a name, a description of the resource).
+	ResourceName string
+}
+
+// NetworkDomainTeardownPlan represents an ordered list of operations that, when applied, will delete a network domain and everything in it.
+type NetworkDomainTeardownPlan struct {
+	// The Id of the target network domain.
+	NetworkDomainID string
+
+	// The name of the target network domain.
+	NetworkDomainName string
+
+	// The steps in the plan (in the order that they will be applied).
+	Steps []NetworkDomainTeardownStep
+
+	// Descriptions of the resources that will not be deleted because they (or resources they contain) are tagged with DoNotDeleteTagName.
+	ProtectedResources []string
+}
+
+// IsEmpty determines whether the plan contains no steps.
+func (plan *NetworkDomainTeardownPlan) IsEmpty() bool {
+	return len(plan.Steps) == 0
+}
+
+// DeletesNetworkDomain determines whether the plan will delete the network domain itself.
+func (plan *NetworkDomainTeardownPlan) DeletesNetworkDomain() bool {
+	for _, step := range plan.Steps {
+		if step.Action == NetworkDomainTeardownActionDeleteNetworkDomain {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NetworkDomainTeardownError is an error representing the failure of a step in a NetworkDomainTeardownPlan.
+type NetworkDomainTeardownError struct {
+	// The plan being applied.
+	Plan *NetworkDomainTeardownPlan
+
+	// The steps that were successfully completed before the failure.
+	CompletedSteps []NetworkDomainTeardownStep
+
+	// The step that failed.
+	FailedStep NetworkDomainTeardownStep
+
+	// The error that caused the step to fail.
+	Cause error
+}
+
+// Error returns the error message associated with the NetworkDomainTeardownError.
+func (teardownError *NetworkDomainTeardownError) Error() string {
+	return fmt.Sprintf("Teardown of network domain '%s' failed at step %d of %d (%s): %s",
+		teardownError.Plan.NetworkDomainName,
+		len(teardownError.CompletedSteps)+1,
+		len(teardownError.Plan.Steps),
+		teardownError.FailedStep.Description,
+		teardownError.Cause.Error(),
+	)
+}
+
+var _ error = &NetworkDomainTeardownError{}
+
+// PlanNetworkDomainTeardown discovers everything in the specified network domain, and creates a plan to delete it all (in an order that satisfies the dependencies between resources).
+//
+// Resources are deleted in this order: virtual listeners, VIP pool members, VIP pools, VIP nodes, NAT rules, client-defined firewall rules,
+// IP address lists, port lists, servers, server anti-affinity rules, VLANs, public IP blocks, and finally the network domain.
+//
+// Assets tagged with DoNotDeleteTagName are not deleted; neither are the VLANs that protected servers are attached to, or the network domain itself.
+// NAT rules, VIP nodes, VIP pool members, and server anti-affinity rules that refer to a protected server are also retained
+// (as are VIP pools with protected members, the virtual listeners that use those pools, and public IP blocks used by retained NAT rules or virtual listeners).
+// Returns an error if the network domain itself is tagged with DoNotDeleteTagName.
+func (client *Client) PlanNetworkDomainTeardown(networkDomainID string) (*NetworkDomainTeardownPlan, error) {
+	networkDomain, err := client.GetNetworkDomain(networkDomainID)
+	if err != nil {
+		return nil, err
+	}
+	if networkDomain == nil {
+		return nil, fmt.Errorf("No network domain was found with Id '%s'.", networkDomainID)
+	}
+
+	isProtected, err := client.isAssetProtected(networkDomainID, AssetTypeNetworkDomain)
+	if err != nil {
+		return nil, err
+	}
+	if isProtected {
+		return nil, fmt.Errorf("Network domain '%s' is tagged with '%s' and cannot be torn down.", networkDomain.Name, DoNotDeleteTagName)
+	}
+
+	planner := &networkDomainTeardownPlanner{
+		client: client,
+		plan: &NetworkDomainTeardownPlan{
+			NetworkDomainID:   networkDomainID,
+			NetworkDomainName: networkDomain.Name,
+		},
+		protectedServerIDs:   make(map[string]bool),
+		protectedIPAddresses: make(map[string]bool),
+		protectedVIPPoolIDs:  make(map[string]bool),
+		protectedVLANIDs:     make(map[string]bool),
+	}
+
+	// Order matters here; resources must be deleted before the resources they depend on.
+	// Protected servers (and the VIP pools they are members of) must be identified first, since resources that refer to them are also retained.
+	steps := []func() error{
+		planner.findProtectedServers,
+		planner.findProtectedVIPPools,
+		planner.planVirtualListeners,
+		planner.planVIPPoolMembers,
+		planner.planVIPPools,
+		planner.planVIPNodes,
+		planner.planNATRules,
+		planner.planFirewallRules,
+		planner.planIPAddressLists,
+		planner.planPortLists,
+		planner.planServers,
+		planner.planAntiAffinityRules,
+		planner.planVLANs,
+		planner.planPublicIPBlocks,
+	}
+	for _, step := range steps {
+		err = step()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The network domain can only be deleted if everything in it is deleted.
+	if len(planner.plan.ProtectedResources) == 0 {
+		planner.addStep(NetworkDomainTeardownActionDeleteNetworkDomain, "network domain", networkDomainID, networkDomain.Name)
+	}
+
+	return planner.plan, nil
+}
+
+// ApplyNetworkDomainTeardownPlan applies each step in the plan (in order), waiting for each resource to be deleted before moving on.
+// stepTimeout is the maximum length of time to wait for each step to complete.
+//
+// Resources that have already been deleted are skipped.
+// Stops at the first step that fails, returning a *NetworkDomainTeardownError describing the failed step (and which steps had already completed).
+func (client *Client) ApplyNetworkDomainTeardownPlan(plan *NetworkDomainTeardownPlan, stepTimeout time.Duration) error {
+	var completedSteps []NetworkDomainTeardownStep
+	for _, step := range plan.Steps {
+		log.Printf("%s...", step.Description)
+
+		err := client.applyNetworkDomainTeardownStep(plan.NetworkDomainID, step, stepTimeout)
+		if err != nil && !isResourceNotFoundError(err) {
+			return &NetworkDomainTeardownError{
+				Plan:           plan,
+				CompletedSteps: completedSteps,
+				FailedStep:     step,
+				Cause:          err,
+			}
+		}
+
+		completedSteps = append(completedSteps, step)
+	}
+
+	return nil
+}
+
+// TeardownNetworkDomain deletes everything in the specified network domain (and then the network domain itself), in dependency order.
+//
+// If dryRun is true, nothing is deleted; the returned plan describes what would have been deleted.
+// See PlanNetworkDomainTeardown for details of the order in which resources are deleted, and of protection using DoNotDeleteTagName.
+func (client *Client) TeardownNetworkDomain(networkDomainID string, dryRun bool, stepTimeout time.Duration) (*NetworkDomainTeardownPlan, error) {
+	plan, err := client.PlanNetworkDomainTeardown(networkDomainID)
+	if err != nil {
+		return nil, err
+	}
+	if dryRun {
+		return plan, nil
+	}
+
+	return plan, client.ApplyNetworkDomainTeardownPlan(plan, stepTimeout)
+}
+
+// Apply a single teardown step and wait for it to complete.
+func (client *Client) applyNetworkDomainTeardownStep(networkDomainID string, step NetworkDomainTeardownStep, timeout time.Duration) error {
+	var err error
+	switch step.Action {
+	case NetworkDomainTeardownActionDeleteVirtualListener:
+		return client.DeleteVirtualListener(step.ResourceID)
+
+	case NetworkDomainTeardownActionRemoveVIPPoolMember:
+		return client.RemoveVIPPoolMember(step.ResourceID)
+
+	case NetworkDomainTeardownActionDeleteVIPPool:
+		return client.DeleteVIPPool(step.ResourceID)
+
+	case NetworkDomainTeardownActionDeleteVIPNode:
+		return client.DeleteVIPNode(step.ResourceID)
+
+	case NetworkDomainTeardownActionDeleteNATRule:
+		return client.DeleteNATRule(step.ResourceID)
+
+	case NetworkDomainTeardownActionDeleteFirewallRule:
+		return client.DeleteFirewallRule(step.ResourceID)
+
+	case NetworkDomainTeardownActionDeleteIPAddressList:
+		return client.DeleteIPAddressList(step.ResourceID)
+
+	case NetworkDomainTeardownActionDeletePortList:
+		return client.DeletePortList(step.ResourceID)
+
+	case NetworkDomainTeardownActionDeleteServer:
+		return client.powerOffAndDeleteServer(step.ResourceID, timeout)
+
+	case NetworkDomainTeardownActionDeleteAntiAffinityRule:
+		return client.DeleteServerAntiAffinityRule(step.ResourceID, networkDomainID)
+
+	case NetworkDomainTeardownActionDeleteVLAN:
+		err = client.DeleteVLAN(step.ResourceID)
+		if err != nil {
+			return err
+		}
+
+		return client.WaitForDelete(ResourceTypeVLAN, step.ResourceID, timeout)
+
+	case NetworkDomainTeardownActionRemovePublicIPBlock:
+		return client.RemovePublicIPBlock(step.ResourceID)
+
+	case NetworkDomainTeardownActionDeleteNetworkDomain:
+		err = client.DeleteNetworkDomain(step.ResourceID)
+		if err != nil {
+			return err
+		}
+
+		return client.WaitForDelete(ResourceTypeNetworkDomain, step.ResourceID, timeout)
+
+	default:
+		return fmt.Errorf("Unrecognised network domain teardown action '%s'.", step.Action)
+	}
+}
+
+// Delete a server (powering it off first, if required), and wait for the deletion to complete.
+func (client *Client) powerOffAndDeleteServer(serverID string, timeout time.Duration) error {
+	server, err := client.GetServer(serverID)
+	if err != nil {
+		return err
+	}
+	if server == nil {
+		return nil // Already deleted.
+	}
+
+	if server.Started {
+		err = client.PowerOffServer(serverID)
+		if err != nil {
+			return err
+		}
+
+		_, err = client.WaitForChange(ResourceTypeServer, serverID, "Power off", timeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = client.DeleteServer(serverID)
+	if err != nil {
+		return err
+	}
+
+	return client.WaitForDelete(ResourceTypeServer, serverID, timeout)
+}
+
+// Determine whether the specified error is an API error indicating that the target resource was not found.
+func isResourceNotFoundError(err error) bool {
+	apiError, ok := err.(*APIError)
+
+	return ok && apiError.Response.GetResponseCode() == ResponseCodeResourceNotFound
+}
+
+// Determine whether the specified asset is tagged with DoNotDeleteTagName.
+func (client *Client) isAssetProtected(assetID string, assetType string) (bool, error) {
+	tags, err := client.getAllAssetTags(assetID, assetType)
+	if err != nil {
+		return false, err
+	}
+
+	for _, tag := range tags {
+		if strings.EqualFold(tag.Name, DoNotDeleteTagName) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Plans the teardown of a network domain.
+type networkDomainTeardownPlanner struct {
+	client *Client
+	plan   *NetworkDomainTeardownPlan
+
+	// The servers in the network domain.
+	servers []Server
+
+	// The Ids of servers that are tagged with DoNotDeleteTagName.
+	protectedServerIDs map[string]bool
+
+	// The private IP addresses (IPv4 and IPv6) of protected servers.
+	protectedIPAddresses map[string]bool
+
+	// The VIP pool members in the network domain.
+	vipPoolMembers []VIPPoolMember
+
+	// The Ids of VIP pools that cannot be deleted because protected servers are members of them.
+	protectedVIPPoolIDs map[string]bool
+
+	// The Ids of VLANs that cannot be deleted because protected servers are attached to them.
+	protectedVLANIDs map[string]bool
+
+	// The external (public) IPv4 addresses of NAT rules and virtual listeners that are retained because they refer to protected servers.
+	protectedPublicIPv4Addresses []string
+}
+
+// Create a NetworkDomainTeardownStep (with a description) for the specified action and resource.
+func newNetworkDomainTeardownStep(action string, resourceDescription string, resourceID string, resourceName string) NetworkDomainTeardownStep {
+	verb := "Delete"
+	if action == NetworkDomainTeardownActionRemoveVIPPoolMember || action == NetworkDomainTeardownActionRemovePublicIPBlock {
+		verb = "Remove"
+	}
+
+	return NetworkDomainTeardownStep{
+		Action:       action,
+		Description:  fmt.Sprintf("%s %s '%s' ('%s')", verb, resourceDescription, resourceName, resourceID),
+		ResourceID:   resourceID,
+		ResourceName: resourceName,
+	}
+}
+
+// Add a step to the plan.
+func (planner *networkDomainTeardownPlanner) addStep(action string, resourceDescription string, resourceID string, resourceName string) {
+	planner.plan.Steps = append(planner.plan.Steps,
+		newNetworkDomainTeardownStep(action, resourceDescription, resourceID, resourceName),
+	)
+}
+
+// Record a resource that will not be deleted.
+func (planner *networkDomainTeardownPlanner) addProtectedResource(resourceDescription string, resourceID string, resourceName string, reason string) {
+	planner.plan.ProtectedResources = append(planner.plan.ProtectedResources,
+		fmt.Sprintf("%s '%s' ('%s') %s", resourceDescription, resourceName, resourceID, reason),
+	)
+}
+
+func (planner *networkDomainTeardownPlanner) planVirtualListeners() error {
+	listeners, err := planner.client.listAllVirtualListenersInNetworkDomain(planner.plan.NetworkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, listener := range listeners {
+		if planner.protectedVIPPoolIDs[listener.Pool.ID] || planner.protectedVIPPoolIDs[listener.ClientClonePool.ID] {
+			planner.addProtectedResource("Virtual listener", listener.ID, listener.Name, "uses a VIP pool with protected servers as members")
+			planner.protectedPublicIPv4Addresses = append(planner.protectedPublicIPv4Addresses, listener.ListenerIPAddress)
+
+			continue
+		}
+
+		planner.addStep(NetworkDomainTeardownActionDeleteVirtualListener, "virtual listener", listener.ID, listener.Name)
+	}
+
+	return nil
+}
+
+// Identify VIP pools that have protected servers as members.
+func (planner *networkDomainTeardownPlanner) findProtectedVIPPools() error {
+	members, err := planner.client.listAllVIPPoolMembershipsInNetworkDomain(planner.plan.NetworkDomainID)
+	if err != nil {
+		return err
+	}
+	planner.vipPoolMembers = members
+
+	for _, member := range members {
+		if planner.protectedIPAddresses[member.Node.IPAddress] {
+			planner.protectedVIPPoolIDs[member.Pool.ID] = true
+		}
+	}
+
+	return nil
+}
+
+func (planner *networkDomainTeardownPlanner) planVIPPoolMembers() error {
+	for _, member := range planner.vipPoolMembers {
+		memberName := fmt.Sprintf("%s/%s", member.Pool.Name, member.Node.Name)
+		if planner.protectedIPAddresses[member.Node.IPAddress] {
+			planner.addProtectedResource("VIP pool member", member.ID, memberName, "refers to a protected server")
+
+			continue
+		}
+
+		planner.addStep(NetworkDomainTeardownActionRemoveVIPPoolMember, "VIP pool member", member.ID, memberName)
+	}
+
+	return nil
+}
+
+func (planner *networkDomainTeardownPlanner) planVIPPools() error {
+	pools, err := planner.client.listAllVIPPoolsInNetworkDomain(planner.plan.NetworkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, pool := range pools {
+		if planner.protectedVIPPoolIDs[pool.ID] {
+			planner.addProtectedResource("VIP pool", pool.ID, pool.Name, "has protected servers as members")
+
+			continue
+		}
+
+		planner.addStep(NetworkDomainTeardownActionDeleteVIPPool, "VIP pool", pool.ID, pool.Name)
+	}
+
+	return nil
+}
+
+func (planner *networkDomainTeardownPlanner) planVIPNodes() error {
+	nodes, err := planner.client.listAllVIPNodesInNetworkDomain(planner.plan.NetworkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, node := range nodes {
+		if planner.protectedIPAddresses[node.IPv4Address] || planner.protectedIPAddresses[node.IPv6Address] {
+			planner.addProtectedResource("VIP node", node.ID, node.Name, "refers to a protected server")
+
+			continue
+		}
+
+		planner.addStep(NetworkDomainTeardownActionDeleteVIPNode, "VIP node", node.ID, node.Name)
+	}
+
+	return nil
+}
+
+func (planner *networkDomainTeardownPlanner) planNATRules() error {
+	rules, err := planner.client.listAllNATRules(planner.plan.NetworkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		ruleName := fmt.Sprintf("%s -> %s", rule.ExternalIPAddress, rule.InternalIPAddress)
+		if planner.protectedIPAddresses[rule.InternalIPAddress] {
+			planner.addProtectedResource("NAT rule", rule.ID, ruleName, "refers to a protected server")
+			planner.protectedPublicIPv4Addresses = append(planner.protectedPublicIPv4Addresses, rule.ExternalIPAddress)
+
+			continue
+		}
+
+		planner.addStep(NetworkDomainTeardownActionDeleteNATRule, "NAT rule", rule.ID, ruleName)
+	}
+
+	return nil
+}
+
+func (planner *networkDomainTeardownPlanner) planFirewallRules() error {
+	rules, err := planner.client.listAllFirewallRules(planner.plan.NetworkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		// Default rules are deleted along with the network domain.
+		if rule.RuleType != "CLIENT_RULE" {
+			continue
+		}
+
+		planner.addStep(NetworkDomainTeardownActionDeleteFirewallRule, "firewall rule", rule.ID, rule.Name)
+	}
+
+	return nil
+}
+
+func (planner *networkDomainTeardownPlanner) planIPAddressLists() error {
+	addressLists, err := planner.client.listAllIPAddressLists(planner.plan.NetworkDomainID)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, len(addressLists))
+	isInNetworkDomain := make(map[string]bool)
+	for index, addressList := range addressLists {
+		names[index] = addressList.Name
+		isInNetworkDomain[addressList.Name] = true
+	}
+
+	// Only lists that are being deleted affect the order of deletion.
+	childListNames := make(map[string][]string)
+	for _, addressList := range addressLists {
+		for _, childList := range addressList.ChildLists {
+			if isInNetworkDomain[childList.Name] {
+				childListNames[addressList.Name] = append(childListNames[addressList.Name], childList.Name)
+			}
+		}
+	}
+	order, err := orderByDependency("IP address list", names, childListNames)
+	if err != nil {
+		return err
+	}
+
+	// Parent lists must be deleted before their children.
+	for index := len(order) - 1; index >= 0; index-- {
+		addressList := addressLists[order[index]]
+		planner.addStep(NetworkDomainTeardownActionDeleteIPAddressList, "IP address list", addressList.ID, addressList.Name)
+	}
+
+	return nil
+}
+
+func (planner *networkDomainTeardownPlanner) planPortLists() error {
+	portLists, err := planner.client.listAllPortLists(planner.plan.NetworkDomainID)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, len(portLists))
+	isInNetworkDomain := make(map[string]bool)
+	for index, portList := range portLists {
+		names[index] = portList.Name
+		isInNetworkDomain[portList.Name] = true
+	}
+
+	// Only lists that are being deleted affect the order of deletion.
+	childListNames := make(map[string][]string)
+	for _, portList := range portLists {
+		for _, childList := range portList.ChildLists {
+			if isInNetworkDomain[childList.Name] {
+				childListNames[portList.Name] = append(childListNames[portList.Name], childList.Name)
+			}
+		}
+	}
+	order, err := orderByDependency("port list", names, childListNames)
+	if err != nil {
+		return err
+	}
+
+	// Parent lists must be deleted before their children.
+	for index := len(order) - 1; index >= 0; index-- {
+		portList := portLists[order[index]]
+		planner.addStep(NetworkDomainTeardownActionDeletePortList, "port list", portList.ID, portList.Name)
+	}
+
+	return nil
+}
+
+// Identify servers tagged with DoNotDeleteTagName (and the IP addresses and VLANs they use).
+func (planner *networkDomainTeardownPlanner) findProtectedServers() error {
+	servers, err := planner.client.listAllServersInNetworkDomain(planner.plan.NetworkDomainID)
+	if err != nil {
+		return err
+	}
+	planner.servers = servers
+
+	for _, server := range servers {
+		isProtected, err := planner.client.isAssetProtected(server.ID, AssetTypeServer)
+		if err != nil {
+			return err
+		}
+		if !isProtected {
+			continue
+		}
+
+		planner.protectedServerIDs[server.ID] = true
+		networkAdapters := append(
+			[]VirtualMachineNetworkAdapter{server.Network.PrimaryAdapter},
+			server.Network.AdditionalNetworkAdapters...,
+		)
+		for _, networkAdapter := range networkAdapters {
+			if networkAdapter.VLANID != nil {
+				planner.protectedVLANIDs[*networkAdapter.VLANID] = true
+			}
+			if networkAdapter.PrivateIPv4Address != nil {
+				planner.protectedIPAddresses[*networkAdapter.PrivateIPv4Address] = true
+			}
+			if networkAdapter.PrivateIPv6Address != nil {
+				planner.protectedIPAddresses[*networkAdapter.PrivateIPv6Address] = true
+			}
+		}
+	}
+
+	return nil
+}
+
+func (planner *networkDomainTeardownPlanner) planServers() error {
+	for _, server := range planner.servers {
+		if planner.protectedServerIDs[server.ID] {
+			planner.addProtectedResource("Server", server.ID, server.Name, fmt.Sprintf("is tagged with '%s'", DoNotDeleteTagName))
+
+			continue
+		}
+
+		planner.addStep(NetworkDomainTeardownActionDeleteServer, "server", server.ID, server.Name)
+	}
+
+	return nil
+}
+
+func (planner *networkDomainTeardownPlanner) planAntiAffinityRules() error {
+	rules, err := planner.client.listAllServerAntiAffinityRules(planner.plan.NetworkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		serverNames := make([]string, len(rule.Servers))
+		isProtected := false
+		for index, server := range rule.Servers {
+			serverNames[index] = server.Name
+			if planner.protectedServerIDs[server.ID] {
+				isProtected = true
+			}
+		}
+		if isProtected {
+			planner.addProtectedResource("Server anti-affinity rule", rule.ID, strings.Join(serverNames, "/"), "refers to a protected server")
+
+			continue
+		}
+
+		planner.addStep(NetworkDomainTeardownActionDeleteAntiAffinityRule, "server anti-affinity rule", rule.ID, strings.Join(serverNames, "/"))
+	}
+
+	return nil
+}
+
+func (planner *networkDomainTeardownPlanner) planVLANs() error {
+	vlans, err := planner.client.listAllVLANs(planner.plan.NetworkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, vlan := range vlans {
+		if planner.protectedVLANIDs[vlan.ID] {
+			planner.addProtectedResource("VLAN", vlan.ID, vlan.Name, "has protected servers attached")
+
+			continue
+		}
+
+		isProtected, err := planner.client.isAssetProtected(vlan.ID, AssetTypeVLAN)
+		if err != nil {
+			return err
+		}
+		if isProtected {
+			planner.addProtectedResource("VLAN", vlan.ID, vlan.Name, fmt.Sprintf("is tagged with '%s'", DoNotDeleteTagName))
+
+			continue
+		}
+
+		planner.addStep(NetworkDomainTeardownActionDeleteVLAN, "VLAN", vlan.ID, vlan.Name)
+	}
+
+	return nil
+}
+
+func (planner *networkDomainTeardownPlanner) planPublicIPBlocks() error {
+	blocks, err := planner.client.listAllPublicIPBlocks(planner.plan.NetworkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, block := range blocks {
+		if planner.isPublicIPBlockInUse(block) {
+			planner.addProtectedResource("Public IP block", block.ID, block.BaseIP, "is used by NAT rules or virtual listeners that refer to protected servers")
+
+			continue
+		}
+
+		isProtected, err := planner.client.isAssetProtected(block.ID, AssetTypePublicIPBlock)
+		if err != nil {
+			return err
+		}
+		if isProtected {
+			planner.addProtectedResource("Public IP block", block.ID, block.BaseIP, fmt.Sprintf("is tagged with '%s'", DoNotDeleteTagName))
+
+			continue
+		}
+
+		planner.addStep(NetworkDomainTeardownActionRemovePublicIPBlock, "public IP block", block.ID, block.BaseIP)
+	}
+
+	return nil
+}
+
+// Determine whether the specified public IP block contains the external address of a retained NAT rule or virtual listener.
+func (planner *networkDomainTeardownPlanner) isPublicIPBlockInUse(block PublicIPBlock) bool {
+	for _, address := range planner.protectedPublicIPv4Addresses {
+		if ipv4BlockContains(block.BaseIP, block.Size, address) {
+			return true
+		}
+	}
+
+	return false
+}
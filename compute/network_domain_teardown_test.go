@@ -0,0 +1,224 @@
+package compute
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Tear down network domain (dry run).
+func TestClient_TeardownNetworkDomain_DryRun(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			plan, err := client.TeardownNetworkDomain("484174a2-ae74-4658-9e56-50fc90e086cf", true, 1*time.Minute)
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.EqualsString("Plan.NetworkDomainName", "Production Network Domain", plan.NetworkDomainName)
+			expect.IsTrue("Plan.DeletesNetworkDomain", plan.DeletesNetworkDomain())
+			expect.EqualsInt("Plan.Steps.Length", 15, len(plan.Steps))
+
+			expectedActions := []string{
+				NetworkDomainTeardownActionDeleteVirtualListener,
+				NetworkDomainTeardownActionRemoveVIPPoolMember,
+				NetworkDomainTeardownActionDeleteVIPPool,
+				NetworkDomainTeardownActionDeleteVIPNode,
+				NetworkDomainTeardownActionDeleteNATRule,
+				NetworkDomainTeardownActionDeleteFirewallRule,
+				NetworkDomainTeardownActionDeleteIPAddressList,
+				NetworkDomainTeardownActionDeleteIPAddressList,
+				NetworkDomainTeardownActionDeletePortList,
+				NetworkDomainTeardownActionDeleteServer,
+				NetworkDomainTeardownActionDeleteServer,
+				NetworkDomainTeardownActionDeleteAntiAffinityRule,
+				NetworkDomainTeardownActionDeleteVLAN,
+				NetworkDomainTeardownActionRemovePublicIPBlock,
+				NetworkDomainTeardownActionDeleteNetworkDomain,
+			}
+			for index, expectedAction := range expectedActions {
+				expect.EqualsString("Plan.Steps[].Action", expectedAction, plan.Steps[index].Action)
+			}
+
+			// Parent lists are deleted before their children.
+			expect.EqualsString("Plan.Steps[6].ResourceName", "Admins", plan.Steps[6].ResourceName)
+			expect.EqualsString("Plan.Steps[7].ResourceName", "Offices", plan.Steps[7].ResourceName)
+		},
+		Respond: testRespondToNetworkDomainTeardownRequest(test, ""),
+	})
+}
+
+// Plan network domain teardown (server protected by tag).
+func TestClient_PlanNetworkDomainTeardown_ProtectedServer(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			plan, err := client.PlanNetworkDomainTeardown("484174a2-ae74-4658-9e56-50fc90e086cf")
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.IsFalse("Plan.DeletesNetworkDomain", plan.DeletesNetworkDomain())
+
+			// Resources that refer to the protected server (or that it depends on) are retained.
+			expectedProtectedResources := []string{
+				"Virtual listener 'Web Listener'",
+				"VIP pool member",
+				"VIP pool 'Web Pool'",
+				"VIP node 'Web Node 1'",
+				"NAT rule '165.180.12.12 -> 10.0.3.17'",
+				"Server 'Production Web Server'",
+				"Server anti-affinity rule",
+				"VLAN 'Production VLAN'",
+				"Public IP block '165.180.12.12'",
+			}
+			expect.EqualsInt("Plan.ProtectedResources.Length", len(expectedProtectedResources), len(plan.ProtectedResources))
+			for index, expectedProtectedResource := range expectedProtectedResources {
+				expect.IsTrue("Plan.ProtectedResources[] mentions "+expectedProtectedResource,
+					strings.HasPrefix(plan.ProtectedResources[index], expectedProtectedResource),
+				)
+			}
+
+			expectedActions := []string{
+				NetworkDomainTeardownActionDeleteFirewallRule,
+				NetworkDomainTeardownActionDeleteIPAddressList,
+				NetworkDomainTeardownActionDeleteIPAddressList,
+				NetworkDomainTeardownActionDeletePortList,
+				NetworkDomainTeardownActionDeleteServer,
+			}
+			expect.EqualsInt("Plan.Steps.Length", len(expectedActions), len(plan.Steps))
+			for index, expectedAction := range expectedActions {
+				expect.EqualsString("Plan.Steps[].Action", expectedAction, plan.Steps[index].Action)
+			}
+			expect.EqualsString("Plan.Steps[4].ResourceID", "0f1d6a3e-2b7c-4c2e-a5a1-3f9d8d6e7b41", plan.Steps[4].ResourceID)
+		},
+		Respond: testRespondToNetworkDomainTeardownRequest(test, "5a32d6e4-9707-4813-a269-56ab4d989f4d"),
+	})
+}
+
+// Plan network domain teardown (IP address lists span multiple pages).
+func TestClient_PlanNetworkDomainTeardown_MultiplePages(test *testing.T) {
+	expect := expect(test)
+
+	respondToTeardownRequest := testRespondToNetworkDomainTeardownRequest(test, "")
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			plan, err := client.PlanNetworkDomainTeardown("484174a2-ae74-4658-9e56-50fc90e086cf")
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			var addressListNames []string
+			for _, step := range plan.Steps {
+				if step.Action == NetworkDomainTeardownActionDeleteIPAddressList {
+					addressListNames = append(addressListNames, step.ResourceName)
+				}
+			}
+			expect.EqualsInt("Plan.Steps (IP address lists).Length", 2, len(addressListNames))
+			expect.EqualsString("Plan.Steps (IP address lists)[0].ResourceName", "Admins", addressListNames[0])
+			expect.EqualsString("Plan.Steps (IP address lists)[1].ResourceName", "Offices", addressListNames[1])
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			if strings.HasSuffix(request.URL.Path, "/network/ipAddressList") {
+				if request.URL.Query().Get("pageNumber") == "2" {
+					return http.StatusOK, exportNetworkDomainIPAddressListsPage2TestResponse
+				}
+
+				return http.StatusOK, exportNetworkDomainIPAddressListsPage1TestResponse
+			}
+
+			return respondToTeardownRequest(test, request)
+		},
+	})
+}
+
+// Apply network domain teardown plan (first step fails).
+func TestClient_ApplyNetworkDomainTeardownPlan_Failure(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			plan := createNetworkDomainTeardownPlanForTest()
+
+			err := client.ApplyNetworkDomainTeardownPlan(plan, 1*time.Minute)
+			expect.NotNil("ApplyNetworkDomainTeardownPlan error", err)
+
+			teardownError, ok := err.(*NetworkDomainTeardownError)
+			expect.IsTrue("Error is NetworkDomainTeardownError", ok)
+			expect.EqualsInt("NetworkDomainTeardownError.CompletedSteps size", 0, len(teardownError.CompletedSteps))
+			expect.EqualsString("NetworkDomainTeardownError.FailedStep.Action", NetworkDomainTeardownActionDeleteVirtualListener, teardownError.FailedStep.Action)
+			expect.IsTrue("Error message mentions step", strings.Contains(err.Error(), "step 1 of 2"))
+		},
+		Respond: testRespond(400, resourceBusyTestResponse),
+	})
+}
+
+// Apply network domain teardown plan (resources already deleted).
+func TestClient_ApplyNetworkDomainTeardownPlan_AlreadyDeleted(test *testing.T) {
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			err := client.ApplyNetworkDomainTeardownPlan(createNetworkDomainTeardownPlanForTest(), 1*time.Minute)
+			if err != nil {
+				test.Fatal(err)
+			}
+		},
+		Respond: testRespond(400, unreservePrivateIPv4AddressNotFoundTestResponse),
+	})
+}
+
+func createNetworkDomainTeardownPlanForTest() *NetworkDomainTeardownPlan {
+	return &NetworkDomainTeardownPlan{
+		NetworkDomainID:   "484174a2-ae74-4658-9e56-50fc90e086cf",
+		NetworkDomainName: "Production Network Domain",
+		Steps: []NetworkDomainTeardownStep{
+			NetworkDomainTeardownStep{
+				Action:       NetworkDomainTeardownActionDeleteVirtualListener,
+				Description:  "Delete virtual listener 'Production Web Listener'",
+				ResourceID:   "6115469d-a8bb-445b-bb23-d23b5283f2b9",
+				ResourceName: "Production Web Listener",
+			},
+			NetworkDomainTeardownStep{
+				Action:       NetworkDomainTeardownActionDeleteVIPPool,
+				Description:  "Delete VIP pool 'Web Pool'",
+				ResourceID:   "4d360b1f-bc2c-4ab7-9884-1f03ba2768f7",
+				ResourceName: "Web Pool",
+			},
+		},
+	}
+}
+
+// Respond to requests made when planning a network domain teardown.
+//
+// If protectedAssetID is not empty, that asset is tagged with DoNotDeleteTagName.
+func testRespondToNetworkDomainTeardownRequest(test *testing.T, protectedAssetID string) ClientTestResponder {
+	return func(test *testing.T, request *http.Request) (int, string) {
+		if strings.HasSuffix(request.URL.Path, "/tag/tag") {
+			if protectedAssetID == "" || request.URL.Query().Get("assetId") != protectedAssetID {
+				return http.StatusOK, `{ "tag": [], "pageNumber": 1, "pageCount": 0, "totalCount": 0, "pageSize": 50 }`
+			}
+
+			return http.StatusOK, `
+				{
+					"tag": [
+						{ "assetType": "SERVER", "assetId": "` + protectedAssetID + `", "tagKeyName": "do-not-delete", "value": "" }
+					],
+					"pageNumber": 1, "pageCount": 1, "totalCount": 1, "pageSize": 50
+				}
+			`
+		}
+
+		for pathSuffix, response := range exportNetworkDomainTestResponses {
+			if strings.HasSuffix(request.URL.Path, pathSuffix) {
+				return http.StatusOK, response
+			}
+		}
+
+		test.Fatalf("Unexpected request: %s %s", request.Method, request.URL.Path)
+
+		return http.StatusNotFound, ""
+	}
+}
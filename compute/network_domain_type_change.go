@@ -0,0 +1,150 @@
+package compute
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// NetworkDomainTypeChange represents a planned change to a network domain's type (service plan).
+type NetworkDomainTypeChange struct {
+	// The Id of the network domain to change.
+	NetworkDomainID string
+
+	// The network domain's current type.
+	CurrentType NetworkDomainType
+
+	// The network domain's new type.
+	TargetType NetworkDomainType
+
+	// Warnings about features (currently in use by the network domain) that will stop working after the change.
+	Warnings []string
+}
+
+// IsRequired determines whether the network domain's type actually needs to be changed.
+func (change *NetworkDomainTypeChange) IsRequired() bool {
+	return change.CurrentType != change.TargetType
+}
+
+// IsDowngrade determines whether the change will remove features supported by the network domain's current type.
+func (change *NetworkDomainTypeChange) IsDowngrade() bool {
+	return change.CurrentType.SupportsLoadBalancing() && !change.TargetType.SupportsLoadBalancing()
+}
+
+// HasWarnings determines whether the change has any warnings.
+func (change *NetworkDomainTypeChange) HasWarnings() bool {
+	return len(change.Warnings) > 0
+}
+
+// PlanNetworkDomainTypeChange determines what changing the specified network domain to the target type will involve.
+//
+// Returns an error if the target type is not valid, or is not supported by the network domain's datacenter.
+// If the change is a downgrade, the returned NetworkDomainTypeChange will contain a warning for each type of load-balancer object that exists in the network domain.
+func (client *Client) PlanNetworkDomainTypeChange(networkDomainID string, targetType NetworkDomainType) (*NetworkDomainTypeChange, error) {
+	if !targetType.IsValid() {
+		return nil, fmt.Errorf("Invalid network domain type '%s' (must be '%s' or '%s').", targetType, NetworkDomainTypeEssentials, NetworkDomainTypeAdvanced)
+	}
+
+	networkDomain, err := client.GetNetworkDomain(networkDomainID)
+	if err != nil {
+		return nil, err
+	}
+	if networkDomain == nil {
+		return nil, fmt.Errorf("No network domain was found with Id '%s'.", networkDomainID)
+	}
+
+	change := &NetworkDomainTypeChange{
+		NetworkDomainID: networkDomainID,
+		CurrentType:     networkDomain.Type,
+		TargetType:      targetType,
+	}
+	if !change.IsRequired() {
+		return change, nil
+	}
+
+	datacenter, err := client.GetDatacenter(networkDomain.DatacenterID)
+	if err != nil {
+		return nil, err
+	}
+	if datacenter != nil && !datacenter.SupportsNetworkDomainType(targetType) {
+		return nil, fmt.Errorf("Datacenter '%s' does not support network domains of type '%s'.", networkDomain.DatacenterID, targetType)
+	}
+
+	if change.IsDowngrade() {
+		err = client.addLoadBalancingWarnings(change)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return change, nil
+}
+
+// ChangeNetworkDomainType changes the specified network domain to the target type, and waits for the change to complete.
+//
+// If the change would break features currently in use by the network domain (e.g. downgrading to ESSENTIALS when load-balancer objects exist), the network domain is not changed and an error is returned (unless force is true).
+// The planned change is returned, even if an error occurs.
+func (client *Client) ChangeNetworkDomainType(networkDomainID string, targetType NetworkDomainType, force bool, timeout time.Duration) (*NetworkDomainTypeChange, error) {
+	change, err := client.PlanNetworkDomainTypeChange(networkDomainID, targetType)
+	if err != nil {
+		return change, err
+	}
+	if !change.IsRequired() {
+		return change, nil
+	}
+
+	if change.HasWarnings() && !force {
+		return change, fmt.Errorf("Changing network domain '%s' from '%s' to '%s' would break features currently in use: %s",
+			networkDomainID, change.CurrentType, change.TargetType, strings.Join(change.Warnings, " "),
+		)
+	}
+
+	err = client.EditNetworkDomain(networkDomainID, nil, nil, &targetType)
+	if err != nil {
+		return change, err
+	}
+
+	_, err = client.WaitForEdit(ResourceTypeNetworkDomain, networkDomainID, timeout)
+
+	return change, err
+}
+
+// Add a warning to the change for each type of load-balancer object that exists in the network domain.
+func (client *Client) addLoadBalancingWarnings(change *NetworkDomainTypeChange) error {
+	paging := &Paging{
+		PageNumber: 1,
+		PageSize:   1,
+	}
+
+	nodes, err := client.ListVIPNodesInNetworkDomain(change.NetworkDomainID, paging)
+	if err != nil {
+		return err
+	}
+	if nodes.TotalCount > 0 {
+		change.Warnings = append(change.Warnings,
+			fmt.Sprintf("The network domain contains %d VIP node(s), which are not supported by '%s' network domains.", nodes.TotalCount, change.TargetType),
+		)
+	}
+
+	pools, err := client.ListVIPPoolsInNetworkDomain(change.NetworkDomainID, paging)
+	if err != nil {
+		return err
+	}
+	if pools.TotalCount > 0 {
+		change.Warnings = append(change.Warnings,
+			fmt.Sprintf("The network domain contains %d VIP pool(s), which are not supported by '%s' network domains.", pools.TotalCount, change.TargetType),
+		)
+	}
+
+	listeners, err := client.ListVirtualListenersInNetworkDomain(change.NetworkDomainID, paging)
+	if err != nil {
+		return err
+	}
+	if listeners.TotalCount > 0 {
+		change.Warnings = append(change.Warnings,
+			fmt.Sprintf("The network domain contains %d virtual listener(s), which are not supported by '%s' network domains.", listeners.TotalCount, change.TargetType),
+		)
+	}
+
+	return nil
+}
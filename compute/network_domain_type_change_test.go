@@ -0,0 +1,102 @@
+package compute
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Plan network domain type change (downgrade with load-balancer objects).
+func TestClient_PlanNetworkDomainTypeChange_DowngradeWithWarnings(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			change, err := client.PlanNetworkDomainTypeChange("8cdfd607-f429-4df6-9352-162cfc0891be", NetworkDomainTypeEssentials)
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.EqualsString("Change.CurrentType", string(NetworkDomainTypeAdvanced), string(change.CurrentType))
+			expect.EqualsString("Change.TargetType", string(NetworkDomainTypeEssentials), string(change.TargetType))
+			expect.IsTrue("Change.IsRequired", change.IsRequired())
+			expect.IsTrue("Change.IsDowngrade", change.IsDowngrade())
+			expect.EqualsInt("Change.Warnings.Length", 2, len(change.Warnings))
+			expect.IsTrue("Change.Warnings[0] mentions VIP nodes", strings.Contains(change.Warnings[0], "3 VIP node(s)"))
+			expect.IsTrue("Change.Warnings[1] mentions virtual listeners", strings.Contains(change.Warnings[1], "1 virtual listener(s)"))
+		},
+		Respond: testRespondToNetworkDomainTypeChangeRequest(test),
+	})
+}
+
+// Change network domain type (downgrade refused because of warnings).
+func TestClient_ChangeNetworkDomainType_RefuseDowngrade(test *testing.T) {
+	expect := expect(test)
+
+	respondToPlanRequest := testRespondToNetworkDomainTypeChangeRequest(test)
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			change, err := client.ChangeNetworkDomainType("8cdfd607-f429-4df6-9352-162cfc0891be", NetworkDomainTypeEssentials, false, 1*time.Second)
+			expect.NotNil("ChangeNetworkDomainType error", err)
+			expect.NotNil("NetworkDomainTypeChange", change)
+			expect.IsTrue("Change.HasWarnings", change.HasWarnings())
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			if strings.HasSuffix(request.URL.Path, "/network/editNetworkDomain") {
+				test.Fatal("Network domain should not have been edited.")
+			}
+
+			return respondToPlanRequest(test, request)
+		},
+	})
+}
+
+// Plan network domain type change (invalid target type).
+func TestClient_PlanNetworkDomainTypeChange_InvalidType(test *testing.T) {
+	client := NewClient("au", "user1", "password")
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	_, err := client.PlanNetworkDomainTypeChange("8cdfd607-f429-4df6-9352-162cfc0891be", NetworkDomainType("ENTERPRISE"))
+	expect(test).NotNil("PlanNetworkDomainTypeChange error", err)
+}
+
+func testRespondToNetworkDomainTypeChangeRequest(test *testing.T) ClientTestResponder {
+	return func(test *testing.T, request *http.Request) (int, string) {
+		switch {
+		case strings.HasSuffix(request.URL.Path, "/network/networkDomain/8cdfd607-f429-4df6-9352-162cfc0891be"):
+			return http.StatusOK, advancedNetworkDomainTestResponse
+		case strings.HasSuffix(request.URL.Path, "/infrastructure/datacenter"):
+			return http.StatusOK, getDatacenterTestResponse
+		case strings.HasSuffix(request.URL.Path, "/networkDomainVip/node"):
+			return http.StatusOK, `{ "node": [ { "id": "34de6ed6-46a4-4dae-a753-2f8d3840c6f9" } ], "pageNumber": 1, "pageCount": 1, "totalCount": 3, "pageSize": 1 }`
+		case strings.HasSuffix(request.URL.Path, "/networkDomainVip/pool"):
+			return http.StatusOK, `{ "pool": [], "pageNumber": 1, "pageCount": 0, "totalCount": 0, "pageSize": 1 }`
+		case strings.HasSuffix(request.URL.Path, "/networkDomainVip/virtualListener"):
+			return http.StatusOK, `{ "virtualListener": [ { "id": "6115469d-a8bb-445b-bb23-d23b5283f2b9" } ], "pageNumber": 1, "pageCount": 1, "totalCount": 1, "pageSize": 1 }`
+		}
+
+		test.Fatalf("Unexpected request: %s %s", request.Method, request.URL.Path)
+
+		return http.StatusNotFound, ""
+	}
+}
+
+/*
+ * Test responses.
+ */
+
+const advancedNetworkDomainTestResponse = `
+	{
+		"id": "8cdfd607-f429-4df6-9352-162cfc0891be",
+		"name": "Production Network Domain",
+		"description": "This is a new Network Domain",
+		"type": "ADVANCED",
+		"snatIpv4Address": "165.180.9.252",
+		"createTime": "2015-02-24T10:47:39.000Z",
+		"state": "NORMAL",
+		"datacenterId": "NA9"
+	}
+`
@@ -0,0 +1,259 @@
+package compute
+
+import (
+	"fmt"
+)
+
+// OrphanedResource represents a resource in a network domain that appears to be unused (or refers to resources that no longer exist).
+type OrphanedResource struct {
+	// The kind of resource (e.g. ResourceGraphKindPublicIPBlock).
+	Kind string
+
+	// The resource Id.
+	ID string
+
+	// The resource name (or, for resources without a name, a description of the resource).
+	Name string
+
+	// The reason the resource is considered to be orphaned.
+	Reason string
+
+	// A human-readable description of the suggested cleanup action.
+	SuggestedAction string
+
+	// The steps (if any) that will clean up the resource.
+	//
+	// Empty if the resource cannot be safely cleaned up by deleting it (e.g. a VIP pool with no members that is still used by a virtual listener).
+	CleanupSteps []NetworkDomainTeardownStep
+}
+
+// OrphanedResourceReport represents the orphaned resources found in a network domain.
+type OrphanedResourceReport struct {
+	// The Id of the network domain.
+	NetworkDomainID string
+
+	// The name of the network domain.
+	NetworkDomainName string
+
+	// The orphaned resources (in the order that they can be cleaned up).
+	Resources []OrphanedResource
+}
+
+// IsEmpty determines whether the report contains no orphaned resources.
+func (report *OrphanedResourceReport) IsEmpty() bool {
+	return len(report.Resources) == 0
+}
+
+// CleanupPlan creates a NetworkDomainTeardownPlan (that can be applied using Client.ApplyNetworkDomainTeardownPlan) to clean up the orphaned resources in the report.
+//
+// The plan never deletes the network domain itself.
+func (report *OrphanedResourceReport) CleanupPlan() *NetworkDomainTeardownPlan {
+	plan := &NetworkDomainTeardownPlan{
+		NetworkDomainID:   report.NetworkDomainID,
+		NetworkDomainName: report.NetworkDomainName,
+	}
+	for _, resource := range report.Resources {
+		plan.Steps = append(plan.Steps, resource.CleanupSteps...)
+	}
+
+	return plan
+}
+
+// FindOrphanedResources examines the resources in the specified network domain and reports those that appear to be wasted.
+//
+// This includes public IP blocks with no address in use by a NAT rule or virtual listener, NAT rules whose internal address does not belong to any server,
+// VIP nodes that are not a member of any pool, VIP pools with no members or that are not used by any virtual listener,
+// IP address / port lists that are not used by any firewall rule or parent list, and server anti-affinity rules that refer to deleted servers.
+//
+// Resources that are only used by other orphaned resources are not reported (they will become orphaned once those resources are cleaned up).
+func (client *Client) FindOrphanedResources(networkDomainID string) (*OrphanedResourceReport, error) {
+	networkDomain, err := client.GetNetworkDomain(networkDomainID)
+	if err != nil {
+		return nil, err
+	}
+	if networkDomain == nil {
+		return nil, fmt.Errorf("No network domain was found with Id '%s'.", networkDomainID)
+	}
+
+	graph, err := client.BuildResourceGraph(networkDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := findOrphanedResourcesInGraph(graph)
+	report.NetworkDomainName = networkDomain.Name
+
+	return report, nil
+}
+
+// Find orphaned resources in the specified ResourceGraph.
+func findOrphanedResourcesInGraph(graph *ResourceGraph) *OrphanedResourceReport {
+	finder := &orphanedResourceFinder{
+		graph: graph,
+		report: &OrphanedResourceReport{
+			NetworkDomainID: graph.NetworkDomainID,
+		},
+	}
+
+	// Order matters here; the resources are reported in the order that they can be cleaned up.
+	checks := []struct {
+		kind  string
+		check func(node *ResourceGraphNode)
+	}{
+		{ResourceGraphKindVIPPool, finder.checkVIPPool},
+		{ResourceGraphKindVIPNode, finder.checkVIPNode},
+		{ResourceGraphKindNATRule, finder.checkNATRule},
+		{ResourceGraphKindIPAddressList, finder.checkIPAddressList},
+		{ResourceGraphKindPortList, finder.checkPortList},
+		{ResourceGraphKindServerAntiAffinityRule, finder.checkAntiAffinityRule},
+		{ResourceGraphKindPublicIPBlock, finder.checkPublicIPBlock},
+	}
+	for _, check := range checks {
+		for _, node := range graph.GetNodes() {
+			if node.Kind == check.kind {
+				check.check(node)
+			}
+		}
+	}
+
+	return finder.report
+}
+
+// Finds orphaned resources in a ResourceGraph.
+type orphanedResourceFinder struct {
+	graph  *ResourceGraph
+	report *OrphanedResourceReport
+}
+
+// Record an orphaned resource.
+func (finder *orphanedResourceFinder) found(node *ResourceGraphNode, reason string, suggestedAction string, cleanupSteps ...NetworkDomainTeardownStep) {
+	finder.report.Resources = append(finder.report.Resources, OrphanedResource{
+		Kind:            node.Kind,
+		ID:              node.ID,
+		Name:            node.Name,
+		Reason:          reason,
+		SuggestedAction: suggestedAction,
+		CleanupSteps:    cleanupSteps,
+	})
+}
+
+func (finder *orphanedResourceFinder) checkVIPPool(node *ResourceGraphNode) {
+	members := filterResourceGraphNodes(finder.graph.GetDependents(node.ID), ResourceGraphKindVIPPoolMember)
+	listeners := filterResourceGraphNodes(finder.graph.GetDependents(node.ID), ResourceGraphKindVirtualListener)
+
+	switch {
+	case len(listeners) > 0 && len(members) == 0:
+		finder.found(node,
+			"VIP pool has no members.",
+			"Add members to the VIP pool, or delete the virtual listeners that use it.",
+		)
+	case len(listeners) == 0:
+		reason := "VIP pool is not used by any virtual listener."
+		if len(members) == 0 {
+			reason = "VIP pool has no members, and is not used by any virtual listener."
+		}
+
+		var cleanupSteps []NetworkDomainTeardownStep
+		for _, member := range members {
+			cleanupSteps = append(cleanupSteps,
+				newNetworkDomainTeardownStep(NetworkDomainTeardownActionRemoveVIPPoolMember, "VIP pool member", member.ID, member.Name),
+			)
+		}
+		cleanupSteps = append(cleanupSteps,
+			newNetworkDomainTeardownStep(NetworkDomainTeardownActionDeleteVIPPool, "VIP pool", node.ID, node.Name),
+		)
+
+		finder.found(node, reason, "Delete the VIP pool (removing its members first).", cleanupSteps...)
+	}
+}
+
+func (finder *orphanedResourceFinder) checkVIPNode(node *ResourceGraphNode) {
+	members := filterResourceGraphNodes(finder.graph.GetDependents(node.ID), ResourceGraphKindVIPPoolMember)
+	if len(members) != 0 {
+		return
+	}
+
+	finder.found(node,
+		"VIP node is not a member of any VIP pool.",
+		"Delete the VIP node.",
+		newNetworkDomainTeardownStep(NetworkDomainTeardownActionDeleteVIPNode, "VIP node", node.ID, node.Name),
+	)
+}
+
+func (finder *orphanedResourceFinder) checkNATRule(node *ResourceGraphNode) {
+	// The NAT rule is in use if its internal address belongs to a server, virtual listener, or VIP node.
+	dependencies := finder.graph.GetDependencies(node.ID)
+	for _, kind := range []string{ResourceGraphKindServer, ResourceGraphKindVirtualListener, ResourceGraphKindVIPNode} {
+		if len(filterResourceGraphNodes(dependencies, kind)) != 0 {
+			return
+		}
+	}
+
+	finder.found(node,
+		"NAT rule's internal IPv4 address does not belong to any server, virtual listener, or VIP node.",
+		"Delete the NAT rule.",
+		newNetworkDomainTeardownStep(NetworkDomainTeardownActionDeleteNATRule, "NAT rule", node.ID, node.Name),
+	)
+}
+
+func (finder *orphanedResourceFinder) checkIPAddressList(node *ResourceGraphNode) {
+	if len(finder.graph.GetDependents(node.ID)) != 0 {
+		return
+	}
+
+	finder.found(node,
+		"IP address list is not used by any firewall rule or parent list.",
+		"Delete the IP address list.",
+		newNetworkDomainTeardownStep(NetworkDomainTeardownActionDeleteIPAddressList, "IP address list", node.ID, node.Name),
+	)
+}
+
+func (finder *orphanedResourceFinder) checkPortList(node *ResourceGraphNode) {
+	if len(finder.graph.GetDependents(node.ID)) != 0 {
+		return
+	}
+
+	finder.found(node,
+		"Port list is not used by any firewall rule or parent list.",
+		"Delete the port list.",
+		newNetworkDomainTeardownStep(NetworkDomainTeardownActionDeletePortList, "port list", node.ID, node.Name),
+	)
+}
+
+func (finder *orphanedResourceFinder) checkAntiAffinityRule(node *ResourceGraphNode) {
+	// An anti-affinity rule always refers to exactly 2 servers; any server that is not in the graph has been deleted.
+	servers := filterResourceGraphNodes(finder.graph.GetDependencies(node.ID), ResourceGraphKindServer)
+	if len(servers) >= 2 {
+		return
+	}
+
+	finder.found(node,
+		"Server anti-affinity rule refers to a server that no longer exists.",
+		"Delete the server anti-affinity rule.",
+		newNetworkDomainTeardownStep(NetworkDomainTeardownActionDeleteAntiAffinityRule, "server anti-affinity rule", node.ID, node.Name),
+	)
+}
+
+func (finder *orphanedResourceFinder) checkPublicIPBlock(node *ResourceGraphNode) {
+	if len(finder.graph.GetDependents(node.ID)) != 0 {
+		return
+	}
+
+	finder.found(node,
+		"No address in the public IP block is used by a NAT rule or virtual listener.",
+		"Remove the public IP block.",
+		newNetworkDomainTeardownStep(NetworkDomainTeardownActionRemovePublicIPBlock, "public IP block", node.ID, node.Name),
+	)
+}
+
+// Select the nodes of the specified kind.
+func filterResourceGraphNodes(nodes []*ResourceGraphNode, kind string) []*ResourceGraphNode {
+	var filtered []*ResourceGraphNode
+	for _, node := range nodes {
+		if node.Kind == kind {
+			filtered = append(filtered, node)
+		}
+	}
+
+	return filtered
+}
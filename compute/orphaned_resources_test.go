@@ -0,0 +1,85 @@
+package compute
+
+import (
+	"testing"
+)
+
+// Find orphaned resources in network domain (no orphaned resources).
+func TestClient_FindOrphanedResources_None(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			report, err := client.FindOrphanedResources("484174a2-ae74-4658-9e56-50fc90e086cf")
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.EqualsString("Report.NetworkDomainName", "Production Network Domain", report.NetworkDomainName)
+			expect.IsTrue("Report.IsEmpty", report.IsEmpty())
+			expect.IsTrue("Report.CleanupPlan.IsEmpty", report.CleanupPlan().IsEmpty())
+		},
+		Respond: testRespondToNetworkDomainTeardownRequest(test, ""),
+	})
+}
+
+// Find orphaned resources in resource graph.
+func TestFindOrphanedResourcesInGraph(test *testing.T) {
+	expect := expect(test)
+
+	graph := NewResourceGraph("484174a2-ae74-4658-9e56-50fc90e086cf")
+	graph.AddNode(ResourceGraphKindServer, "server1", "Server 1")
+	graph.AddNode(ResourceGraphKindServerAntiAffinityRule, "antiAffinityRule1", "Server 1/Server 2")
+	graph.AddNode(ResourceGraphKindPublicIPBlock, "block1", "165.180.12.12 (2)")
+	graph.AddNode(ResourceGraphKindPublicIPBlock, "block2", "165.180.12.14 (2)")
+	graph.AddNode(ResourceGraphKindNATRule, "natRule1", "165.180.12.12 -> 10.0.3.17")
+	graph.AddNode(ResourceGraphKindNATRule, "natRule2", "165.180.12.13 -> 10.0.3.50")
+	graph.AddNode(ResourceGraphKindIPAddressList, "parentList", "Parent")
+	graph.AddNode(ResourceGraphKindIPAddressList, "childList", "Child")
+	graph.AddNode(ResourceGraphKindPortList, "portList", "Web")
+	graph.AddNode(ResourceGraphKindVIPNode, "node1", "Node 1")
+	graph.AddNode(ResourceGraphKindVIPNode, "node2", "Node 2")
+	graph.AddNode(ResourceGraphKindVIPPool, "pool1", "Unused Pool")
+	graph.AddNode(ResourceGraphKindVIPPool, "pool2", "Empty Pool")
+	graph.AddNode(ResourceGraphKindVIPPoolMember, "member1", "Unused Pool/Node 1")
+	graph.AddNode(ResourceGraphKindVirtualListener, "listener1", "Listener")
+
+	dependencies := [][2]string{
+		{"antiAffinityRule1", "server1"}, // Server 2 has been deleted.
+		{"natRule1", "block1"},           // No server has the NAT rule's internal address.
+		{"natRule2", "block1"},
+		{"natRule2", "listener1"}, // The NAT rule forwards to the listener, so it is in use.
+		{"parentList", "childList"},
+		{"member1", "pool1"},
+		{"member1", "node1"},
+		{"listener1", "pool2"},
+		{"listener1", "block1"},
+	}
+	for _, dependency := range dependencies {
+		err := graph.AddDependency(dependency[0], dependency[1])
+		if err != nil {
+			test.Fatal(err)
+		}
+	}
+
+	report := findOrphanedResourcesInGraph(graph)
+	expect.EqualsInt("Report.Resources.Length", 8, len(report.Resources))
+
+	expectedIDs := []string{"pool1", "pool2", "node2", "natRule1", "parentList", "portList", "antiAffinityRule1", "block2"}
+	for index, expectedID := range expectedIDs {
+		expect.EqualsString("Report.Resources[].ID", expectedID, report.Resources[index].ID)
+	}
+
+	// Members are removed before the pool is deleted.
+	expect.EqualsInt("Report.Resources[0].CleanupSteps.Length", 2, len(report.Resources[0].CleanupSteps))
+	expect.EqualsString("Report.Resources[0].CleanupSteps[0].Action", NetworkDomainTeardownActionRemoveVIPPoolMember, report.Resources[0].CleanupSteps[0].Action)
+	expect.EqualsString("Report.Resources[0].CleanupSteps[1].Action", NetworkDomainTeardownActionDeleteVIPPool, report.Resources[0].CleanupSteps[1].Action)
+
+	// The empty pool is still used by a listener, so it is not deleted.
+	expect.EqualsInt("Report.Resources[1].CleanupSteps.Length", 0, len(report.Resources[1].CleanupSteps))
+
+	plan := report.CleanupPlan()
+	expect.EqualsInt("CleanupPlan.Steps.Length", 8, len(plan.Steps))
+	expect.IsFalse("CleanupPlan.DeletesNetworkDomain", plan.DeletesNetworkDomain())
+	expect.EqualsString("CleanupPlan.Steps[7].Action", NetworkDomainTeardownActionRemovePublicIPBlock, plan.Steps[7].Action)
+}
@@ -22,6 +22,15 @@ func (page *PagedResult) IsEmpty() bool {
 	return page.PageCount == 0
 }
 
+// IsLastPage determines whether the page is the last page of results.
+func (page *PagedResult) IsLastPage() bool {
+	if page.IsEmpty() {
+		return true
+	}
+
+	return page.PageNumber*page.PageSize >= page.TotalCount
+}
+
 // NextPage creates a Paging for the next page of results.
 func (page *PagedResult) NextPage() *Paging {
 	return &Paging{
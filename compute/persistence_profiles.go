@@ -74,3 +74,24 @@ func (client *Client) ListDefaultPersistenceProfiles(networkDomainID string, pag
 
 	return persistenceProfiles, nil
 }
+
+// listAllDefaultPersistenceProfiles retrieves all default load-balancing persistence profiles in the specified network domain (across all pages of results).
+func (client *Client) listAllDefaultPersistenceProfiles(networkDomainID string) (persistenceProfiles []PersistenceProfile, err error) {
+	paging := DefaultPaging()
+	for {
+		var page *PersistenceProfiles
+		page, err = client.ListDefaultPersistenceProfiles(networkDomainID, paging)
+		if err != nil {
+			return nil, err
+		}
+
+		persistenceProfiles = append(persistenceProfiles, page.Items...)
+		if page.IsLastPage() {
+			break
+		}
+
+		paging.Next()
+	}
+
+	return persistenceProfiles, nil
+}
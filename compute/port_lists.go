@@ -109,12 +109,20 @@ func (client *Client) GetPortList(id string) (portList *PortList, err error) {
 
 // ListPortLists retrieves all port lists associated with the specified network domain.
 func (client *Client) ListPortLists(networkDomainID string) (portLists *PortLists, err error) {
+	return client.listPortLists(networkDomainID, nil)
+}
+
+// listPortLists retrieves a page of the port lists associated with the specified network domain (or the API's default page, if paging is nil).
+func (client *Client) listPortLists(networkDomainID string, paging *Paging) (portLists *PortLists, err error) {
 	organizationID, err := client.getOrganizationID()
 	if err != nil {
 		return nil, err
 	}
 
 	requestURI := fmt.Sprintf("%s/network/portList?networkDomainId=%s", organizationID, networkDomainID)
+	if paging != nil {
+		requestURI += "&" + paging.toQueryParameters()
+	}
 	request, err := client.newRequestV22(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
@@ -142,6 +150,27 @@ func (client *Client) ListPortLists(networkDomainID string) (portLists *PortList
 	return portLists, err
 }
 
+// listAllPortLists retrieves all port lists associated with the specified network domain (across all pages of results).
+func (client *Client) listAllPortLists(networkDomainID string) (portLists []PortList, err error) {
+	paging := DefaultPaging()
+	for {
+		var page *PortLists
+		page, err = client.listPortLists(networkDomainID, paging)
+		if err != nil {
+			return nil, err
+		}
+
+		portLists = append(portLists, page.PortLists...)
+		if page.IsLastPage() {
+			break
+		}
+
+		paging.Next()
+	}
+
+	return portLists, nil
+}
+
 // CreatePortList creates a new port list.
 // Returns the Id of the new port list.
 //
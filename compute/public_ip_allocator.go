@@ -0,0 +1,239 @@
+package compute
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// PublicIPBlockUsage represents the public IPv4 addresses in use in a public IP block.
+type PublicIPBlockUsage struct {
+	// The public IP block.
+	Block PublicIPBlock
+
+	// The addresses in the block that are reserved (in ascending order).
+	ReservedAddresses []string
+
+	// The addresses in the block that are not reserved (in ascending order).
+	FreeAddresses []string
+}
+
+// IsUnused determines whether none of the addresses in the block are reserved.
+func (usage *PublicIPBlockUsage) IsUnused() bool {
+	return len(usage.ReservedAddresses) == 0
+}
+
+// PublicIPv4AddressUsage represents the public IPv4 addresses in use in a network domain.
+type PublicIPv4AddressUsage struct {
+	// The network domain Id.
+	NetworkDomainID string
+
+	// Usage for each of the network domain's public IP blocks (in the order they are listed by the CloudControl API).
+	Blocks []PublicIPBlockUsage
+}
+
+// GetNextFreeAddress retrieves the first public IPv4 address (and the Id of the block that contains it) that is not reserved.
+//
+// Returns empty strings if all addresses in the network domain's public IP blocks are reserved.
+func (usage *PublicIPv4AddressUsage) GetNextFreeAddress() (address string, blockID string) {
+	for _, blockUsage := range usage.Blocks {
+		if len(blockUsage.FreeAddresses) != 0 {
+			return blockUsage.FreeAddresses[0], blockUsage.Block.ID
+		}
+	}
+
+	return "", ""
+}
+
+// GetUnusedBlocks retrieves the public IP blocks that have no reserved addresses.
+func (usage *PublicIPv4AddressUsage) GetUnusedBlocks() []PublicIPBlock {
+	var unusedBlocks []PublicIPBlock
+	for _, blockUsage := range usage.Blocks {
+		if blockUsage.IsUnused() {
+			unusedBlocks = append(unusedBlocks, blockUsage.Block)
+		}
+	}
+
+	return unusedBlocks
+}
+
+// newPublicIPBlockUsage creates a new PublicIPBlockUsage for the specified block (with the specified addresses marked as reserved).
+func newPublicIPBlockUsage(block PublicIPBlock, reservedAddresses map[string]bool) (PublicIPBlockUsage, error) {
+	usage := PublicIPBlockUsage{
+		Block: block,
+	}
+
+	baseIP, err := parseIPv4Address(block.BaseIP)
+	if err != nil {
+		return usage, err
+	}
+
+	baseAddress := ipv4ToUint32(baseIP)
+	for offset := 0; offset < block.Size; offset++ {
+		address := uint32ToIPv4(baseAddress + uint32(offset)).String()
+		if reservedAddresses[address] {
+			usage.ReservedAddresses = append(usage.ReservedAddresses, address)
+		} else {
+			usage.FreeAddresses = append(usage.FreeAddresses, address)
+		}
+	}
+
+	return usage, nil
+}
+
+// GetPublicIPv4AddressUsage determines which public IPv4 addresses are reserved (i.e. used by a NAT rule or virtual listener) in each of the specified network domain's public IP blocks.
+func (client *Client) GetPublicIPv4AddressUsage(networkDomainID string) (*PublicIPv4AddressUsage, error) {
+	blocks, err := client.listAllPublicIPBlocks(networkDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	reservedPublicIPs, err := client.listAllReservedPublicIPAddresses(networkDomainID)
+	if err != nil {
+		return nil, err
+	}
+	reservedAddresses := make(map[string]bool)
+	for _, reservedPublicIP := range reservedPublicIPs {
+		reservedAddresses[reservedPublicIP.Address] = true
+	}
+
+	usage := &PublicIPv4AddressUsage{
+		NetworkDomainID: networkDomainID,
+	}
+	for _, block := range blocks {
+		var blockUsage PublicIPBlockUsage
+		blockUsage, err = newPublicIPBlockUsage(block, reservedAddresses)
+		if err != nil {
+			return nil, err
+		}
+
+		usage.Blocks = append(usage.Blocks, blockUsage)
+	}
+
+	return usage, nil
+}
+
+// PublicIPv4Allocation represents a public IPv4 address allocated by AllocatePublicIPv4Address.
+type PublicIPv4Allocation struct {
+	// The allocated address.
+	Address string
+
+	// The Id of the public IP block that contains the address.
+	BlockID string
+
+	// Was the public IP block added to the network domain in order to allocate the address?
+	AddedBlock bool
+}
+
+// AllocatePublicIPv4Address finds the first public IPv4 address in the specified network domain that is not reserved.
+// If all addresses in the network domain's public IP blocks are reserved, a new public IP block is added (waiting up to the specified timeout for it to be deployed).
+//
+// Note that public IPv4 addresses cannot be reserved directly; the address only becomes reserved once it is used by a NAT rule or virtual listener.
+func (client *Client) AllocatePublicIPv4Address(networkDomainID string, timeout time.Duration) (*PublicIPv4Allocation, error) {
+	usage, err := client.GetPublicIPv4AddressUsage(networkDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	address, blockID := usage.GetNextFreeAddress()
+	if address != "" {
+		return &PublicIPv4Allocation{
+			Address: address,
+			BlockID: blockID,
+		}, nil
+	}
+
+	log.Printf("Add public IP block to network domain '%s'...", networkDomainID)
+	block, err := client.addPublicIPBlockAndWait(networkDomainID, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PublicIPv4Allocation{
+		Address:    block.BaseIP,
+		BlockID:    block.ID,
+		AddedBlock: true,
+	}, nil
+}
+
+// RemovePublicIPBlockIfUnused removes the specified public IP block from its network domain, but only if none of its addresses are reserved.
+//
+// Returns true if the block was removed (or had already been removed).
+func (client *Client) RemovePublicIPBlockIfUnused(blockID string) (bool, error) {
+	block, err := client.GetPublicIPBlock(blockID)
+	if err != nil {
+		return false, err
+	}
+	if block == nil {
+		return true, nil
+	}
+
+	reservedPublicIPs, err := client.listAllReservedPublicIPAddresses(block.NetworkDomainID)
+	if err != nil {
+		return false, err
+	}
+	for _, reservedPublicIP := range reservedPublicIPs {
+		if reservedPublicIP.IPBlockID == block.ID {
+			log.Printf("Not removing public IP block '%s' because address '%s' is still reserved.", block.ID, reservedPublicIP.Address)
+
+			return false, nil
+		}
+	}
+
+	log.Printf("Remove public IP block '%s'...", block.ID)
+	err = client.RemovePublicIPBlock(block.ID)
+	if err != nil && !isResourceNotFoundError(err) {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ReleaseUnusedPublicIPBlocks removes every public IP block in the specified network domain that has no reserved addresses.
+//
+// Returns the blocks that were removed.
+func (client *Client) ReleaseUnusedPublicIPBlocks(networkDomainID string) ([]PublicIPBlock, error) {
+	usage, err := client.GetPublicIPv4AddressUsage(networkDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	var removedBlocks []PublicIPBlock
+	for _, block := range usage.GetUnusedBlocks() {
+		log.Printf("Remove unused public IP block '%s' (%s)...", block.ID, block.GetName())
+		err = client.RemovePublicIPBlock(block.ID)
+		if err != nil && !isResourceNotFoundError(err) {
+			return removedBlocks, err
+		}
+
+		removedBlocks = append(removedBlocks, block)
+	}
+
+	return removedBlocks, nil
+}
+
+// Add a public IP block to the specified network domain, and wait for it to be deployed.
+func (client *Client) addPublicIPBlockAndWait(networkDomainID string, timeout time.Duration) (*PublicIPBlock, error) {
+	blockID, err := client.AddPublicIPBlock(networkDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := client.GetPublicIPBlock(blockID)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("No public IP block was found with Id '%s'.", blockID)
+	}
+	if block.State != ResourceStatusNormal {
+		var resource Resource
+		resource, err = client.WaitForDeploy(ResourceTypePublicIPBlock, blockID, timeout)
+		if err != nil {
+			return nil, err
+		}
+		block = resource.(*PublicIPBlock)
+	}
+
+	return block, nil
+}
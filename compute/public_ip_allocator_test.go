@@ -0,0 +1,144 @@
+package compute
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Get public IPv4 address usage for network domain.
+func TestClient_GetPublicIPv4AddressUsage_Success(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			usage, err := client.GetPublicIPv4AddressUsage("802abc9f-45a7-4efb-9d5a-810082368708")
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.EqualsInt("Usage.Blocks.Length", 2, len(usage.Blocks))
+			expect.EqualsString("Usage.Blocks[0].Block.ID", "4487241a-f0ca-11e3-9315-d4bed9b167ba", usage.Blocks[0].Block.ID)
+			expect.IsTrue("Usage.Blocks[0].IsUnused", usage.Blocks[0].IsUnused())
+			expect.EqualsString("Usage.Blocks[1].Block.ID", "cacc028a-7f12-11e4-a91c-0030487e0302", usage.Blocks[1].Block.ID)
+			expect.EqualsInt("Usage.Blocks[1].ReservedAddresses.Length", 1, len(usage.Blocks[1].ReservedAddresses))
+			expect.EqualsString("Usage.Blocks[1].ReservedAddresses[0]", "165.180.12.12", usage.Blocks[1].ReservedAddresses[0])
+			expect.EqualsInt("Usage.Blocks[1].FreeAddresses.Length", 1, len(usage.Blocks[1].FreeAddresses))
+			expect.IsFalse("Usage.Blocks[1].IsUnused", usage.Blocks[1].IsUnused())
+
+			address, blockID := usage.GetNextFreeAddress()
+			expect.EqualsString("NextFreeAddress", "168.128.7.18", address)
+			expect.EqualsString("NextFreeAddress.BlockID", "4487241a-f0ca-11e3-9315-d4bed9b167ba", blockID)
+
+			unusedBlocks := usage.GetUnusedBlocks()
+			expect.EqualsInt("UnusedBlocks.Length", 1, len(unusedBlocks))
+			expect.EqualsString("UnusedBlocks[0].ID", "4487241a-f0ca-11e3-9315-d4bed9b167ba", unusedBlocks[0].ID)
+		},
+		Respond: testRespondToPublicIPAllocatorRequest(test, publicIPAllocatorListPublicIPBlocksTestResponse, nil),
+	})
+}
+
+// Allocate public IPv4 address (all blocks exhausted, so a new block is added).
+func TestClient_AllocatePublicIPv4Address_AddBlock(test *testing.T) {
+	expect := expect(test)
+
+	var addedBlocks int
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			allocation, err := client.AllocatePublicIPv4Address("802abc9f-45a7-4efb-9d5a-810082368708", 1*time.Minute)
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.EqualsInt("Blocks added", 1, addedBlocks)
+			expect.IsTrue("Allocation.AddedBlock", allocation.AddedBlock)
+			expect.EqualsString("Allocation.BlockID", "cacc028a-7f12-11e4-a91c-0030487e0302", allocation.BlockID)
+			expect.EqualsString("Allocation.Address", "165.180.12.12", allocation.Address)
+		},
+		Respond: testRespondToPublicIPAllocatorRequest(test,
+			`{ "publicIpBlock": [], "pageNumber": 1, "pageCount": 0, "totalCount": 0, "pageSize": 50 }`,
+			func(request *http.Request) {
+				if strings.HasSuffix(request.URL.Path, "/network/addPublicIpBlock") {
+					addedBlocks++
+				}
+			},
+		),
+	})
+}
+
+// Release unused public IP blocks.
+func TestClient_ReleaseUnusedPublicIPBlocks(test *testing.T) {
+	expect := expect(test)
+
+	var removedBlocks int
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			blocks, err := client.ReleaseUnusedPublicIPBlocks("802abc9f-45a7-4efb-9d5a-810082368708")
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.EqualsInt("Blocks.Length", 1, len(blocks))
+			expect.EqualsString("Blocks[0].ID", "4487241a-f0ca-11e3-9315-d4bed9b167ba", blocks[0].ID)
+			expect.EqualsInt("Blocks removed", 1, removedBlocks)
+		},
+		Respond: testRespondToPublicIPAllocatorRequest(test, publicIPAllocatorListPublicIPBlocksTestResponse,
+			func(request *http.Request) {
+				if strings.HasSuffix(request.URL.Path, "/network/removePublicIpBlock") {
+					removedBlocks++
+				}
+			},
+		),
+	})
+}
+
+// Respond to requests made by the public IPv4 address allocator.
+//
+// If onRequest is not nil, it is called for each request.
+func testRespondToPublicIPAllocatorRequest(test *testing.T, listPublicIPBlocksResponse string, onRequest func(request *http.Request)) ClientTestResponder {
+	return func(test *testing.T, request *http.Request) (int, string) {
+		if onRequest != nil {
+			onRequest(request)
+		}
+
+		switch {
+		case strings.HasSuffix(request.URL.Path, "/network/publicIpBlock"):
+			return http.StatusOK, listPublicIPBlocksResponse
+		case strings.HasSuffix(request.URL.Path, "/network/publicIpBlock/4487241a-f0ca-11e3-9315-d4bed9b167ba"):
+			return http.StatusOK, getPublicIPBlockResponse
+		case strings.HasSuffix(request.URL.Path, "/network/reservedPublicIpv4Address"):
+			return http.StatusOK, listReservedPublicIPAddressesResponse
+		case strings.HasSuffix(request.URL.Path, "/network/addPublicIpBlock"):
+			return http.StatusOK, addPublicIPBlockResponse
+		case strings.HasSuffix(request.URL.Path, "/network/removePublicIpBlock"):
+			return http.StatusOK, publicIPAllocatorRemovePublicIPBlockTestResponse
+		}
+
+		test.Fatalf("Unexpected request: %s %s", request.Method, request.URL.Path)
+
+		return http.StatusNotFound, ""
+	}
+}
+
+const publicIPAllocatorListPublicIPBlocksTestResponse = `
+	{
+		"publicIpBlock": [
+			{ "id": "4487241a-f0ca-11e3-9315-d4bed9b167ba", "networkDomainId": "802abc9f-45a7-4efb-9d5a-810082368708", "baseIp": "168.128.7.18", "size": 2, "state": "NORMAL" },
+			{ "id": "cacc028a-7f12-11e4-a91c-0030487e0302", "networkDomainId": "802abc9f-45a7-4efb-9d5a-810082368708", "baseIp": "165.180.12.12", "size": 2, "state": "NORMAL" }
+		],
+		"pageNumber": 1, "pageCount": 1, "totalCount": 2, "pageSize": 50
+	}
+`
+
+const publicIPAllocatorRemovePublicIPBlockTestResponse = `
+	{
+		"operation": "REMOVE_PUBLIC_IP_BLOCK",
+		"responseCode": "OK",
+		"message": "Public IPv4 Address Block has been removed successfully.",
+		"info": [],
+		"warning": [],
+		"error": [],
+		"requestId": "na9_20160321T074626030-0400_7e9fffe7-190b-46f2-9107-9d52fe57d0ad"
+	}
+`
@@ -0,0 +1,120 @@
+package compute
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Region represents a CloudControl region (a geography with its own API endpoint).
+type Region struct {
+	// The region identifier (e.g. "au").
+	ID string
+
+	// The region's display name.
+	Name string
+
+	// The base address for the region's API endpoint.
+	BaseAddress string
+}
+
+// The built-in catalogue of known regions (keyed by region Id).
+var knownRegions = map[string]Region{
+	"na": Region{
+		ID:          "na",
+		Name:        "North America",
+		BaseAddress: "https://api-na.dimensiondata.com",
+	},
+	"eu": Region{
+		ID:          "eu",
+		Name:        "Europe",
+		BaseAddress: "https://api-eu.dimensiondata.com",
+	},
+	"au": Region{
+		ID:          "au",
+		Name:        "Australia",
+		BaseAddress: "https://api-au.dimensiondata.com",
+	},
+	"mea": Region{
+		ID:          "mea",
+		Name:        "Middle East & Africa",
+		BaseAddress: "https://api-mea.dimensiondata.com",
+	},
+	"ap": Region{
+		ID:          "ap",
+		Name:        "Asia Pacific",
+		BaseAddress: "https://api-ap.dimensiondata.com",
+	},
+	"latam": Region{
+		ID:          "latam",
+		Name:        "Latin America",
+		BaseAddress: "https://api-latam.dimensiondata.com",
+	},
+	"canada": Region{
+		ID:          "canada",
+		Name:        "Canada",
+		BaseAddress: "https://api-canada.dimensiondata.com",
+	},
+}
+
+// KnownRegions returns the built-in catalogue of known regions (ordered by region Id).
+func KnownRegions() []Region {
+	regionIDs := make([]string, 0, len(knownRegions))
+	for regionID := range knownRegions {
+		regionIDs = append(regionIDs, regionID)
+	}
+	sort.Strings(regionIDs)
+
+	regions := make([]Region, len(regionIDs))
+	for index, regionID := range regionIDs {
+		regions[index] = knownRegions[regionID]
+	}
+
+	return regions
+}
+
+// GetRegion retrieves the known region with the specified Id (case-insensitive).
+// Returns nil if the region is not one of the KnownRegions.
+func GetRegion(id string) *Region {
+	region, ok := knownRegions[normalizeRegionID(id)]
+	if !ok {
+		return nil
+	}
+
+	return &region
+}
+
+// IsKnownRegion determines whether the specified region Id (case-insensitive) is one of the KnownRegions.
+func IsKnownRegion(id string) bool {
+	return GetRegion(id) != nil
+}
+
+// ValidateRegion returns an error if the specified region Id is not one of the KnownRegions.
+func ValidateRegion(id string) error {
+	if IsKnownRegion(id) {
+		return nil
+	}
+
+	knownRegionIDs := make([]string, 0, len(knownRegions))
+	for _, region := range KnownRegions() {
+		knownRegionIDs = append(knownRegionIDs, region.ID)
+	}
+
+	return fmt.Errorf("'%s' is not a known region (must be one of: %s).", id, strings.Join(knownRegionIDs, ", "))
+}
+
+// Get the base address of the API endpoint for the specified region.
+// If the region is not one of the KnownRegions, the base address is derived from the region Id.
+func getRegionBaseAddress(id string) string {
+	region := GetRegion(id)
+	if region != nil {
+		return region.BaseAddress
+	}
+
+	return fmt.Sprintf("https://api-%s.dimensiondata.com", id)
+}
+
+// Normalise a region Id for lookup.
+func normalizeRegionID(id string) string {
+	return strings.ToLower(strings.TrimSpace(id))
+}
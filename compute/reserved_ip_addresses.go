@@ -0,0 +1,237 @@
+package compute
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// ReservedPrivateIPv4Address represents a private IPv4 address on a VLAN that has been reserved (so it will not be allocated to servers).
+type ReservedPrivateIPv4Address struct {
+	Address      string `json:"value"`
+	VLANID       string `json:"vlanId"`
+	DataCenterID string `json:"datacenterId"`
+	Description  string `json:"description,omitempty"`
+	IsExclusive  bool   `json:"exclusive"`
+}
+
+// ReservedPrivateIPv4Addresses represents a page of ReservedPrivateIPv4Address results.
+type ReservedPrivateIPv4Addresses struct {
+	Items []ReservedPrivateIPv4Address `json:"ipv4"`
+
+	PagedResult
+}
+
+// ReservedIPv6Address represents an IPv6 address on a VLAN that has been reserved (so it will not be allocated to servers).
+type ReservedIPv6Address struct {
+	Address      string `json:"value"`
+	VLANID       string `json:"vlanId"`
+	DataCenterID string `json:"datacenterId"`
+	Description  string `json:"description,omitempty"`
+	IsExclusive  bool   `json:"exclusive"`
+}
+
+// ReservedIPv6Addresses represents a page of ReservedIPv6Address results.
+type ReservedIPv6Addresses struct {
+	Items []ReservedIPv6Address `json:"ipv6"`
+
+	PagedResult
+}
+
+// Request body for reserving an IP address on a VLAN.
+type reserveIPAddress struct {
+	IPAddress   string `json:"ipAddress"`
+	VLANID      string `json:"vlanId"`
+	Description string `json:"description,omitempty"`
+}
+
+// Request body for unreserving an IP address on a VLAN.
+type unreserveIPAddress struct {
+	IPAddress string `json:"ipAddress"`
+	VLANID    string `json:"vlanId"`
+}
+
+// ListReservedPrivateIPv4Addresses retrieves the private IPv4 addresses that have been reserved on the specified VLAN.
+func (client *Client) ListReservedPrivateIPv4Addresses(vlanID string, paging *Paging) (reservedAddresses *ReservedPrivateIPv4Addresses, err error) {
+	reservedAddresses = &ReservedPrivateIPv4Addresses{}
+	err = client.listReservedIPAddresses("reservedPrivateIpv4Address", "private IPv4", vlanID, paging, reservedAddresses)
+	if err != nil {
+		return nil, err
+	}
+
+	return reservedAddresses, nil
+}
+
+// listAllReservedPrivateIPv4Addresses retrieves all private IPv4 addresses reserved on the specified VLAN (across all pages of results).
+func (client *Client) listAllReservedPrivateIPv4Addresses(vlanID string) (reservedAddresses []ReservedPrivateIPv4Address, err error) {
+	paging := DefaultPaging()
+	for {
+		var page *ReservedPrivateIPv4Addresses
+		page, err = client.ListReservedPrivateIPv4Addresses(vlanID, paging)
+		if err != nil {
+			return nil, err
+		}
+
+		reservedAddresses = append(reservedAddresses, page.Items...)
+		if page.IsLastPage() {
+			break
+		}
+
+		paging.Next()
+	}
+
+	return reservedAddresses, nil
+}
+
+// ReservePrivateIPv4Address reserves a private IPv4 address on the specified VLAN, so that it will not be allocated to servers.
+func (client *Client) ReservePrivateIPv4Address(vlanID string, ipAddress string, description string) error {
+	_, err := parseIPv4Address(ipAddress)
+	if err != nil {
+		return err
+	}
+
+	return client.executeReservedIPAddressOperation("reservePrivateIpv4Address", "reserve private IPv4", vlanID, ipAddress, &reserveIPAddress{
+		IPAddress:   ipAddress,
+		VLANID:      vlanID,
+		Description: description,
+	})
+}
+
+// UnreservePrivateIPv4Address releases a reserved private IPv4 address on the specified VLAN.
+func (client *Client) UnreservePrivateIPv4Address(vlanID string, ipAddress string) error {
+	_, err := parseIPv4Address(ipAddress)
+	if err != nil {
+		return err
+	}
+
+	return client.executeReservedIPAddressOperation("unreservePrivateIpv4Address", "unreserve private IPv4", vlanID, ipAddress, &unreserveIPAddress{
+		IPAddress: ipAddress,
+		VLANID:    vlanID,
+	})
+}
+
+// ListReservedIPv6Addresses retrieves the IPv6 addresses that have been reserved on the specified VLAN.
+func (client *Client) ListReservedIPv6Addresses(vlanID string, paging *Paging) (reservedAddresses *ReservedIPv6Addresses, err error) {
+	reservedAddresses = &ReservedIPv6Addresses{}
+	err = client.listReservedIPAddresses("reservedIpv6Address", "IPv6", vlanID, paging, reservedAddresses)
+	if err != nil {
+		return nil, err
+	}
+
+	return reservedAddresses, nil
+}
+
+// listAllReservedIPv6Addresses retrieves all IPv6 addresses reserved on the specified VLAN (across all pages of results).
+func (client *Client) listAllReservedIPv6Addresses(vlanID string) (reservedAddresses []ReservedIPv6Address, err error) {
+	paging := DefaultPaging()
+	for {
+		var page *ReservedIPv6Addresses
+		page, err = client.ListReservedIPv6Addresses(vlanID, paging)
+		if err != nil {
+			return nil, err
+		}
+
+		reservedAddresses = append(reservedAddresses, page.Items...)
+		if page.IsLastPage() {
+			break
+		}
+
+		paging.Next()
+	}
+
+	return reservedAddresses, nil
+}
+
+// ReserveIPv6Address reserves an IPv6 address on the specified VLAN, so that it will not be allocated to servers.
+func (client *Client) ReserveIPv6Address(vlanID string, ipAddress string, description string) error {
+	err := validateIPv6Address(ipAddress)
+	if err != nil {
+		return err
+	}
+
+	return client.executeReservedIPAddressOperation("reserveIpv6Address", "reserve IPv6", vlanID, ipAddress, &reserveIPAddress{
+		IPAddress:   ipAddress,
+		VLANID:      vlanID,
+		Description: description,
+	})
+}
+
+// UnreserveIPv6Address releases a reserved IPv6 address on the specified VLAN.
+func (client *Client) UnreserveIPv6Address(vlanID string, ipAddress string) error {
+	err := validateIPv6Address(ipAddress)
+	if err != nil {
+		return err
+	}
+
+	return client.executeReservedIPAddressOperation("unreserveIpv6Address", "unreserve IPv6", vlanID, ipAddress, &unreserveIPAddress{
+		IPAddress: ipAddress,
+		VLANID:    vlanID,
+	})
+}
+
+// Retrieve a page of reserved IP addresses on the specified VLAN.
+func (client *Client) listReservedIPAddresses(resourceName string, addressDescription string, vlanID string, paging *Paging, page interface{}) error {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return err
+	}
+
+	requestURI := fmt.Sprintf("%s/network/%s?vlanId=%s&%s",
+		organizationID,
+		resourceName,
+		vlanID,
+		paging.EnsurePaging().toQueryParameters(),
+	)
+	request, err := client.newRequestV24(requestURI, http.MethodGet, nil)
+	if err != nil {
+		return err
+	}
+
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return err
+	}
+
+	if statusCode != http.StatusOK {
+		var apiResponse *APIResponseV2
+
+		apiResponse, err = readAPIResponseAsJSON(responseBody, statusCode)
+		if err != nil {
+			return err
+		}
+
+		return apiResponse.ToError("Request to list reserved %s addresses failed with status code %d (%s): %s", addressDescription, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+	}
+
+	return json.Unmarshal(responseBody, page)
+}
+
+// Execute an operation to reserve or unreserve an IP address on a VLAN.
+func (client *Client) executeReservedIPAddressOperation(operationName string, operationDescription string, vlanID string, ipAddress string, requestBody interface{}) error {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return err
+	}
+
+	requestURI := fmt.Sprintf("%s/network/%s", organizationID, operationName)
+	request, err := client.newRequestV24(requestURI, http.MethodPost, requestBody)
+	if err != nil {
+		return err
+	}
+
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return err
+	}
+
+	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
+	if err != nil {
+		return err
+	}
+
+	if apiResponse.ResponseCode != ResponseCodeOK {
+		return apiResponse.ToError("Request to %s address '%s' on VLAN '%s' failed with unexpected status code %d (%s): %s", operationDescription, ipAddress, vlanID, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+	}
+
+	return nil
+}
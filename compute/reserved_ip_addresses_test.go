@@ -0,0 +1,205 @@
+package compute
+
+import (
+	"net/http"
+	"testing"
+)
+
+// List reserved private IPv4 addresses (successful).
+func TestClient_ListReservedPrivateIPv4Addresses_Success(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			reservedAddresses, err := client.ListReservedPrivateIPv4Addresses("0e56433f-d808-4669-821d-812769517ff8", nil)
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.NotNil("ReservedPrivateIPv4Addresses", reservedAddresses)
+			expect.EqualsInt("ReservedPrivateIPv4Addresses.Items.Length", 2, len(reservedAddresses.Items))
+
+			address1 := reservedAddresses.Items[0]
+			expect.EqualsString("Address[0].Address", "10.0.3.10", address1.Address)
+			expect.EqualsString("Address[0].VLANID", "0e56433f-d808-4669-821d-812769517ff8", address1.VLANID)
+			expect.EqualsString("Address[0].Description", "Hardware firewall", address1.Description)
+			expect.IsFalse("Address[0].IsExclusive", address1.IsExclusive)
+
+			address2 := reservedAddresses.Items[1]
+			expect.EqualsString("Address[1].Address", "10.0.3.11", address2.Address)
+			expect.IsTrue("Address[1].IsExclusive", address2.IsExclusive)
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			expect.EqualsString("Request.URL.Path", "/caas/2.4/my-organization-id/network/reservedPrivateIpv4Address", request.URL.Path)
+			expect.EqualsString("Request.URL.Query.vlanId", "0e56433f-d808-4669-821d-812769517ff8", request.URL.Query().Get("vlanId"))
+
+			return http.StatusOK, listReservedPrivateIPv4AddressesTestResponse
+		},
+	})
+}
+
+// Reserve private IPv4 address (successful).
+func TestClient_ReservePrivateIPv4Address_Success(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			err := client.ReservePrivateIPv4Address("0e56433f-d808-4669-821d-812769517ff8", "10.0.3.10", "Hardware firewall")
+			if err != nil {
+				test.Fatal(err)
+			}
+		},
+		Respond: testValidateJSONRequestAndRespondOK(reservePrivateIPv4AddressTestResponse, &reserveIPAddress{}, func(test *testing.T, requestBody interface{}) {
+			request := requestBody.(*reserveIPAddress)
+			expect.EqualsString("ReserveIPAddress.IPAddress", "10.0.3.10", request.IPAddress)
+			expect.EqualsString("ReserveIPAddress.VLANID", "0e56433f-d808-4669-821d-812769517ff8", request.VLANID)
+			expect.EqualsString("ReserveIPAddress.Description", "Hardware firewall", request.Description)
+		}),
+	})
+}
+
+// Reserve private IPv4 address (invalid address).
+func TestClient_ReservePrivateIPv4Address_InvalidAddress(test *testing.T) {
+	client := NewClient("au", "user1", "password")
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	err := client.ReservePrivateIPv4Address("0e56433f-d808-4669-821d-812769517ff8", "fd00::10", "")
+	expect(test).NotNil("ReservePrivateIPv4Address error", err)
+}
+
+// Unreserve private IPv4 address (failure).
+func TestClient_UnreservePrivateIPv4Address_Failure(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			err := client.UnreservePrivateIPv4Address("0e56433f-d808-4669-821d-812769517ff8", "10.0.3.12")
+			expect.NotNil("UnreservePrivateIPv4Address error", err)
+
+			apiError, ok := err.(*APIError)
+			expect.IsTrue("UnreservePrivateIPv4Address error is an APIError", ok)
+			expect.EqualsString("APIError.Response.ResponseCode", ResponseCodeResourceNotFound, apiError.Response.GetResponseCode())
+		},
+		Respond: testValidateJSONRequestAndRespond(http.StatusBadRequest, unreservePrivateIPv4AddressNotFoundTestResponse, &unreserveIPAddress{}, func(test *testing.T, requestBody interface{}) {
+			request := requestBody.(*unreserveIPAddress)
+			expect.EqualsString("UnreserveIPAddress.IPAddress", "10.0.3.12", request.IPAddress)
+		}),
+	})
+}
+
+// List reserved IPv6 addresses (successful).
+func TestClient_ListReservedIPv6Addresses_Success(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			reservedAddresses, err := client.ListReservedIPv6Addresses("0e56433f-d808-4669-821d-812769517ff8", nil)
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.EqualsInt("ReservedIPv6Addresses.Items.Length", 1, len(reservedAddresses.Items))
+			expect.EqualsString("Address[0].Address", "2402:9900:111:1195:0:0:0:10", reservedAddresses.Items[0].Address)
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			expect.EqualsString("Request.URL.Path", "/caas/2.4/my-organization-id/network/reservedIpv6Address", request.URL.Path)
+
+			return http.StatusOK, listReservedIPv6AddressesTestResponse
+		},
+	})
+}
+
+// Reserve IPv6 address (successful).
+func TestClient_ReserveIPv6Address_Success(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			err := client.ReserveIPv6Address("0e56433f-d808-4669-821d-812769517ff8", "2402:9900:111:1195::10", "")
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			err = client.ReserveIPv6Address("0e56433f-d808-4669-821d-812769517ff8", "10.0.3.10", "")
+			expect.NotNil("ReserveIPv6Address error (IPv4 address)", err)
+		},
+		Respond: testValidateJSONRequestAndRespondOK(reserveIPv6AddressTestResponse, &reserveIPAddress{}, func(test *testing.T, requestBody interface{}) {
+			request := requestBody.(*reserveIPAddress)
+			expect.EqualsString("ReserveIPAddress.IPAddress", "2402:9900:111:1195::10", request.IPAddress)
+		}),
+	})
+}
+
+/*
+ * Test responses.
+ */
+
+const listReservedPrivateIPv4AddressesTestResponse = `
+{
+	"ipv4": [
+		{
+			"value": "10.0.3.10",
+			"datacenterId": "NA9",
+			"exclusive": false,
+			"vlanId": "0e56433f-d808-4669-821d-812769517ff8",
+			"description": "Hardware firewall"
+		},
+		{
+			"value": "10.0.3.11",
+			"datacenterId": "NA9",
+			"exclusive": true,
+			"vlanId": "0e56433f-d808-4669-821d-812769517ff8"
+		}
+	],
+	"pageNumber": 1,
+	"pageCount": 2,
+	"totalCount": 2,
+	"pageSize": 250
+}
+`
+
+const reservePrivateIPv4AddressTestResponse = `
+{
+	"operation": "RESERVE_PRIVATE_IPV4_ADDRESS",
+	"responseCode": "OK",
+	"message": "Private IPv4 Address 10.0.3.10 has been reserved.",
+	"requestId": "na9_20160321T074626030-0400_7e9fffe7-190b-46f2-9107-9d52fe57d0ad"
+}
+`
+
+const unreservePrivateIPv4AddressNotFoundTestResponse = `
+{
+	"operation": "UNRESERVE_PRIVATE_IPV4_ADDRESS",
+	"responseCode": "RESOURCE_NOT_FOUND",
+	"message": "Private IPv4 Address 10.0.3.12 is not reserved on VLAN 0e56433f-d808-4669-821d-812769517ff8.",
+	"requestId": "na9_20160321T074626030-0400_7e9fffe7-190b-46f2-9107-9d52fe57d0ad"
+}
+`
+
+const listReservedIPv6AddressesTestResponse = `
+{
+	"ipv6": [
+		{
+			"value": "2402:9900:111:1195:0:0:0:10",
+			"datacenterId": "NA9",
+			"exclusive": false,
+			"vlanId": "0e56433f-d808-4669-821d-812769517ff8"
+		}
+	],
+	"pageNumber": 1,
+	"pageCount": 1,
+	"totalCount": 1,
+	"pageSize": 250
+}
+`
+
+const reserveIPv6AddressTestResponse = `
+{
+	"operation": "RESERVE_IPV6_ADDRESS",
+	"responseCode": "OK",
+	"message": "IPv6 Address 2402:9900:111:1195::10 has been reserved.",
+	"requestId": "na9_20160321T074626030-0400_7e9fffe7-190b-46f2-9107-9d52fe57d0ad"
+}
+`
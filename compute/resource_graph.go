@@ -0,0 +1,620 @@
+package compute
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Well-known kinds of resource in a ResourceGraph.
+const (
+	// ResourceGraphKindVLAN represents a VLAN.
+	ResourceGraphKindVLAN = "VLAN"
+
+	// ResourceGraphKindServer represents a server.
+	ResourceGraphKindServer = "Server"
+
+	// ResourceGraphKindServerAntiAffinityRule represents a server anti-affinity rule.
+	ResourceGraphKindServerAntiAffinityRule = "ServerAntiAffinityRule"
+
+	// ResourceGraphKindPublicIPBlock represents a public IPv4 address block.
+	ResourceGraphKindPublicIPBlock = "PublicIPBlock"
+
+	// ResourceGraphKindNATRule represents a NAT rule.
+	ResourceGraphKindNATRule = "NATRule"
+
+	// ResourceGraphKindIPAddressList represents an IP address list.
+	ResourceGraphKindIPAddressList = "IPAddressList"
+
+	// ResourceGraphKindPortList represents a port list.
+	ResourceGraphKindPortList = "PortList"
+
+	// ResourceGraphKindFirewallRule represents a firewall rule.
+	ResourceGraphKindFirewallRule = "FirewallRule"
+
+	// ResourceGraphKindVIPNode represents a VIP node.
+	ResourceGraphKindVIPNode = "VIPNode"
+
+	// ResourceGraphKindVIPPool represents a VIP pool.
+	ResourceGraphKindVIPPool = "VIPPool"
+
+	// ResourceGraphKindVIPPoolMember represents a VIP pool member.
+	ResourceGraphKindVIPPoolMember = "VIPPoolMember"
+
+	// ResourceGraphKindVirtualListener represents a virtual listener.
+	ResourceGraphKindVirtualListener = "VirtualListener"
+
+	// ResourceGraphKindPersistenceProfile represents a (default) persistence profile.
+	ResourceGraphKindPersistenceProfile = "PersistenceProfile"
+
+	// ResourceGraphKindIRule represents a (default) iRule.
+	ResourceGraphKindIRule = "IRule"
+)
+
+// ResourceGraphNode represents a resource in a ResourceGraph.
+type ResourceGraphNode struct {
+	// The resource Id.
+	ID string
+
+	// The kind of resource (e.g. ResourceGraphKindServer).
+	Kind string
+
+	// The resource name (or, for resources without a name, a description of the resource).
+	Name string
+}
+
+// ResourceGraph represents the resources in a network domain, and the references between them.
+//
+// If resource A refers to resource B (e.g. a server is attached to a VLAN), then A depends on B.
+type ResourceGraph struct {
+	// The Id of the network domain that the graph describes.
+	NetworkDomainID string
+
+	// The Ids of the graph's nodes (in the order they were added).
+	nodeIDs []string
+
+	// The graph's nodes, keyed by Id.
+	nodes map[string]*ResourceGraphNode
+
+	// The Ids of each node's dependencies (and dependents), keyed by node Id.
+	dependencies map[string][]string
+	dependents   map[string][]string
+}
+
+// NewResourceGraph creates a new, empty, ResourceGraph.
+func NewResourceGraph(networkDomainID string) *ResourceGraph {
+	return &ResourceGraph{
+		NetworkDomainID: networkDomainID,
+		nodes:           make(map[string]*ResourceGraphNode),
+		dependencies:    make(map[string][]string),
+		dependents:      make(map[string][]string),
+	}
+}
+
+// AddNode adds a resource to the graph (if it is not already present).
+// Returns the node representing the resource.
+func (graph *ResourceGraph) AddNode(kind string, id string, name string) *ResourceGraphNode {
+	node, ok := graph.nodes[id]
+	if ok {
+		return node
+	}
+
+	node = &ResourceGraphNode{
+		ID:   id,
+		Kind: kind,
+		Name: name,
+	}
+	graph.nodes[id] = node
+	graph.nodeIDs = append(graph.nodeIDs, id)
+
+	return node
+}
+
+// AddDependency records that one resource depends on (refers to) another.
+//
+// Returns an error if either resource is not in the graph.
+func (graph *ResourceGraph) AddDependency(dependentID string, dependencyID string) error {
+	if _, ok := graph.nodes[dependentID]; !ok {
+		return fmt.Errorf("Resource '%s' is not in the graph.", dependentID)
+	}
+	if _, ok := graph.nodes[dependencyID]; !ok {
+		return fmt.Errorf("Resource '%s' is not in the graph.", dependencyID)
+	}
+
+	for _, existingDependencyID := range graph.dependencies[dependentID] {
+		if existingDependencyID == dependencyID {
+			return nil
+		}
+	}
+
+	graph.dependencies[dependentID] = append(graph.dependencies[dependentID], dependencyID)
+	graph.dependents[dependencyID] = append(graph.dependents[dependencyID], dependentID)
+
+	return nil
+}
+
+// GetNode retrieves the node representing the resource with the specified Id.
+// Returns nil if the resource is not in the graph.
+func (graph *ResourceGraph) GetNode(id string) *ResourceGraphNode {
+	return graph.nodes[id]
+}
+
+// GetNodes retrieves all nodes in the graph (in the order they were added).
+func (graph *ResourceGraph) GetNodes() []*ResourceGraphNode {
+	return graph.toNodes(graph.nodeIDs)
+}
+
+// GetDependencies retrieves the resources that the specified resource directly depends on.
+func (graph *ResourceGraph) GetDependencies(id string) []*ResourceGraphNode {
+	return graph.toNodes(graph.dependencies[id])
+}
+
+// GetDependents retrieves the resources that directly depend on the specified resource.
+func (graph *ResourceGraph) GetDependents(id string) []*ResourceGraphNode {
+	return graph.toNodes(graph.dependents[id])
+}
+
+// GetAllDependents retrieves the resources that directly or indirectly depend on the specified resource (e.g. everything that would be affected if it were deleted).
+func (graph *ResourceGraph) GetAllDependents(id string) []*ResourceGraphNode {
+	visited := map[string]bool{
+		id: true,
+	}
+
+	var dependentIDs []string
+	pendingIDs := []string{id}
+	for len(pendingIDs) > 0 {
+		currentID := pendingIDs[0]
+		pendingIDs = pendingIDs[1:]
+
+		for _, dependentID := range graph.dependents[currentID] {
+			if visited[dependentID] {
+				continue
+			}
+			visited[dependentID] = true
+
+			dependentIDs = append(dependentIDs, dependentID)
+			pendingIDs = append(pendingIDs, dependentID)
+		}
+	}
+
+	return graph.toNodes(dependentIDs)
+}
+
+// TopologicalOrder orders the graph's nodes so that each resource appears after all the resources it depends on (i.e. the order in which they could be created).
+// Reverse this order to obtain an order in which the resources could be deleted.
+//
+// Returns a *ResourceGraphCycleError if the graph contains a cycle.
+func (graph *ResourceGraph) TopologicalOrder() ([]*ResourceGraphNode, error) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	states := make(map[string]int)
+
+	var orderedNodes []*ResourceGraphNode
+	var path []string
+	var visit func(id string) error
+	visit = func(id string) error {
+		switch states[id] {
+		case visited:
+			return nil
+		case visiting:
+			// The cycle starts where this node first appears in the current path.
+			for index, pathID := range path {
+				if pathID == id {
+					return &ResourceGraphCycleError{
+						Cycle: graph.toNodes(append(path[index:], id)),
+					}
+				}
+			}
+		}
+
+		states[id] = visiting
+		path = append(path, id)
+		for _, dependencyID := range graph.dependencies[id] {
+			err := visit(dependencyID)
+			if err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		states[id] = visited
+
+		orderedNodes = append(orderedNodes, graph.nodes[id])
+
+		return nil
+	}
+
+	for _, id := range graph.nodeIDs {
+		err := visit(id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return orderedNodes, nil
+}
+
+// ResourceGraphCycleError is the error returned by ResourceGraph.TopologicalOrder when the graph contains a cycle.
+type ResourceGraphCycleError struct {
+	// The resources that make up the cycle (the first resource is repeated at the end).
+	Cycle []*ResourceGraphNode
+}
+
+// Error returns the error message associated with the ResourceGraphCycleError.
+func (cycleError *ResourceGraphCycleError) Error() string {
+	resources := make([]string, len(cycleError.Cycle))
+	for index, node := range cycleError.Cycle {
+		resources[index] = fmt.Sprintf("%s '%s'", node.Kind, node.Name)
+	}
+
+	return fmt.Sprintf("Resource graph contains a cycle (%s).", strings.Join(resources, " -> "))
+}
+
+var _ error = &ResourceGraphCycleError{}
+
+// WriteDOT writes the graph to the specified writer in Graphviz DOT format.
+//
+// Edges point from each resource to the resources it depends on.
+func (graph *ResourceGraph) WriteDOT(writer io.Writer) error {
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "digraph \"%s\" {\n", escapeDOTString(graph.NetworkDomainID))
+	buffer.WriteString("\trankdir=LR;\n")
+	buffer.WriteString("\tnode [shape=box];\n")
+
+	for _, id := range graph.nodeIDs {
+		node := graph.nodes[id]
+		fmt.Fprintf(&buffer, "\t\"%s\" [label=\"%s\\n%s\"];\n",
+			escapeDOTString(node.ID), escapeDOTString(node.Kind), escapeDOTString(node.Name),
+		)
+	}
+	for _, id := range graph.nodeIDs {
+		for _, dependencyID := range graph.dependencies[id] {
+			fmt.Fprintf(&buffer, "\t\"%s\" -> \"%s\";\n", escapeDOTString(id), escapeDOTString(dependencyID))
+		}
+	}
+	buffer.WriteString("}\n")
+
+	_, err := buffer.WriteTo(writer)
+
+	return err
+}
+
+// Convert node Ids to nodes.
+func (graph *ResourceGraph) toNodes(ids []string) []*ResourceGraphNode {
+	nodes := make([]*ResourceGraphNode, len(ids))
+	for index, id := range ids {
+		nodes[index] = graph.nodes[id]
+	}
+
+	return nodes
+}
+
+// Escape a string for use in a quoted DOT identifier.
+func escapeDOTString(value string) string {
+	value = strings.Replace(value, "\\", "\\\\", -1)
+	value = strings.Replace(value, "\"", "\\\"", -1)
+
+	return strings.Replace(value, "\n", "\\n", -1)
+}
+
+// BuildResourceGraph discovers the resources in the specified network domain, and builds a graph of the references between them.
+//
+// The graph links servers to VLANs, VIP nodes to servers (by private IPv4 address), NAT rules to the server, virtual listener, or VIP node
+// that has their internal address (servers take precedence), NAT rules to public IP blocks,
+// VIP pool members to VIP pools and nodes, virtual listeners to VIP pools, persistence profiles, iRules, and public IP blocks,
+// firewall rules to IP address / port lists, IP address / port lists to their child lists, and server anti-affinity rules to servers.
+func (client *Client) BuildResourceGraph(networkDomainID string) (*ResourceGraph, error) {
+	builder := &resourceGraphBuilder{
+		client:                     client,
+		graph:                      NewResourceGraph(networkDomainID),
+		serverIDsByIPv4:            make(map[string]string),
+		loadBalancerIDsByIPAddress: make(map[string]string),
+	}
+
+	// Order matters here; later steps link to resources that were added by earlier ones.
+	steps := []func() error{
+		builder.addVLANs,
+		builder.addServers,
+		builder.addAntiAffinityRules,
+		builder.addPublicIPBlocks,
+		builder.addIPAddressLists,
+		builder.addPortLists,
+		builder.addFirewallRules,
+		builder.addVIPNodes,
+		builder.addVIPPools,
+		builder.addVIPPoolMembers,
+		builder.addVirtualListeners,
+		builder.addNATRules,
+	}
+	for _, step := range steps {
+		err := step()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return builder.graph, nil
+}
+
+// Builds a ResourceGraph for a network domain.
+type resourceGraphBuilder struct {
+	client *Client
+	graph  *ResourceGraph
+
+	// Server Ids, keyed by private IPv4 address.
+	serverIDsByIPv4 map[string]string
+
+	// Virtual listener and VIP node Ids, keyed by IP address (IPv4 or IPv6).
+	loadBalancerIDsByIPAddress map[string]string
+
+	// The network domain's public IP blocks.
+	publicIPBlocks []PublicIPBlock
+}
+
+// Record that one resource depends on another (if the other resource is in the graph).
+func (builder *resourceGraphBuilder) link(dependentID string, dependencyID string) {
+	if builder.graph.GetNode(dependencyID) == nil {
+		return // e.g. a resource outside the network domain.
+	}
+
+	// Both resources are known to be in the graph.
+	_ = builder.graph.AddDependency(dependentID, dependencyID)
+}
+
+func (builder *resourceGraphBuilder) addVLANs() error {
+	vlans, err := builder.client.listAllVLANs(builder.graph.NetworkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, vlan := range vlans {
+		builder.graph.AddNode(ResourceGraphKindVLAN, vlan.ID, vlan.Name)
+	}
+
+	return nil
+}
+
+func (builder *resourceGraphBuilder) addServers() error {
+	servers, err := builder.client.listAllServersInNetworkDomain(builder.graph.NetworkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, server := range servers {
+		builder.graph.AddNode(ResourceGraphKindServer, server.ID, server.Name)
+
+		networkAdapters := append(
+			[]VirtualMachineNetworkAdapter{server.Network.PrimaryAdapter},
+			server.Network.AdditionalNetworkAdapters...,
+		)
+		for _, networkAdapter := range networkAdapters {
+			if networkAdapter.VLANID != nil {
+				builder.link(server.ID, *networkAdapter.VLANID)
+			}
+			if networkAdapter.PrivateIPv4Address != nil {
+				builder.serverIDsByIPv4[*networkAdapter.PrivateIPv4Address] = server.ID
+			}
+		}
+	}
+
+	return nil
+}
+
+func (builder *resourceGraphBuilder) addAntiAffinityRules() error {
+	rules, err := builder.client.listAllServerAntiAffinityRules(builder.graph.NetworkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		serverNames := make([]string, len(rule.Servers))
+		for index, server := range rule.Servers {
+			serverNames[index] = server.Name
+		}
+		builder.graph.AddNode(ResourceGraphKindServerAntiAffinityRule, rule.ID, strings.Join(serverNames, "/"))
+
+		for _, server := range rule.Servers {
+			builder.link(rule.ID, server.ID)
+		}
+	}
+
+	return nil
+}
+
+func (builder *resourceGraphBuilder) addPublicIPBlocks() error {
+	blocks, err := builder.client.listAllPublicIPBlocks(builder.graph.NetworkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, block := range blocks {
+		builder.graph.AddNode(ResourceGraphKindPublicIPBlock, block.ID, fmt.Sprintf("%s (%d)", block.BaseIP, block.Size))
+		builder.publicIPBlocks = append(builder.publicIPBlocks, block)
+	}
+
+	return nil
+}
+
+func (builder *resourceGraphBuilder) addNATRules() error {
+	rules, err := builder.client.listAllNATRules(builder.graph.NetworkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		builder.graph.AddNode(ResourceGraphKindNATRule, rule.ID, fmt.Sprintf("%s -> %s", rule.ExternalIPAddress, rule.InternalIPAddress))
+
+		if serverID, ok := builder.serverIDsByIPv4[rule.InternalIPAddress]; ok {
+			builder.link(rule.ID, serverID)
+		} else if loadBalancerID, ok := builder.loadBalancerIDsByIPAddress[rule.InternalIPAddress]; ok {
+			builder.link(rule.ID, loadBalancerID)
+		}
+		blockID := builder.findPublicIPBlock(rule.ExternalIPAddress)
+		if blockID != "" {
+			builder.link(rule.ID, blockID)
+		}
+	}
+
+	return nil
+}
+
+// Find the Id of the public IP block (if any) that contains the specified address.
+func (builder *resourceGraphBuilder) findPublicIPBlock(address string) string {
+	for _, block := range builder.publicIPBlocks {
+		if ipv4BlockContains(block.BaseIP, block.Size, address) {
+			return block.ID
+		}
+	}
+
+	return ""
+}
+
+func (builder *resourceGraphBuilder) addIPAddressLists() error {
+	addressLists, err := builder.client.listAllIPAddressLists(builder.graph.NetworkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, addressList := range addressLists {
+		builder.graph.AddNode(ResourceGraphKindIPAddressList, addressList.ID, addressList.Name)
+	}
+	for _, addressList := range addressLists {
+		for _, childList := range addressList.ChildLists {
+			builder.link(addressList.ID, childList.ID)
+		}
+	}
+
+	return nil
+}
+
+func (builder *resourceGraphBuilder) addPortLists() error {
+	portLists, err := builder.client.listAllPortLists(builder.graph.NetworkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, portList := range portLists {
+		builder.graph.AddNode(ResourceGraphKindPortList, portList.ID, portList.Name)
+	}
+	for _, portList := range portLists {
+		for _, childList := range portList.ChildLists {
+			builder.link(portList.ID, childList.ID)
+		}
+	}
+
+	return nil
+}
+
+func (builder *resourceGraphBuilder) addFirewallRules() error {
+	rules, err := builder.client.listAllFirewallRules(builder.graph.NetworkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		builder.graph.AddNode(ResourceGraphKindFirewallRule, rule.ID, rule.Name)
+
+		for _, scope := range []FirewallRuleScope{rule.Source, rule.Destination} {
+			if scope.AddressList != nil {
+				builder.link(rule.ID, scope.AddressList.ID)
+			}
+			if scope.PortListID != nil {
+				builder.link(rule.ID, *scope.PortListID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (builder *resourceGraphBuilder) addVIPNodes() error {
+	nodes, err := builder.client.listAllVIPNodesInNetworkDomain(builder.graph.NetworkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, node := range nodes {
+		builder.graph.AddNode(ResourceGraphKindVIPNode, node.ID, node.Name)
+		for _, address := range []string{node.IPv4Address, node.IPv6Address} {
+			if address != "" {
+				builder.loadBalancerIDsByIPAddress[address] = node.ID
+			}
+		}
+
+		if serverID, ok := builder.serverIDsByIPv4[node.IPv4Address]; ok {
+			builder.link(node.ID, serverID)
+		}
+	}
+
+	return nil
+}
+
+func (builder *resourceGraphBuilder) addVIPPools() error {
+	pools, err := builder.client.listAllVIPPoolsInNetworkDomain(builder.graph.NetworkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, pool := range pools {
+		builder.graph.AddNode(ResourceGraphKindVIPPool, pool.ID, pool.Name)
+	}
+
+	return nil
+}
+
+func (builder *resourceGraphBuilder) addVIPPoolMembers() error {
+	members, err := builder.client.listAllVIPPoolMembershipsInNetworkDomain(builder.graph.NetworkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, member := range members {
+		builder.graph.AddNode(ResourceGraphKindVIPPoolMember, member.ID, fmt.Sprintf("%s/%s", member.Pool.Name, member.Node.Name))
+		builder.link(member.ID, member.Pool.ID)
+		builder.link(member.ID, member.Node.ID)
+	}
+
+	return nil
+}
+
+func (builder *resourceGraphBuilder) addVirtualListeners() error {
+	listeners, err := builder.client.listAllVirtualListenersInNetworkDomain(builder.graph.NetworkDomainID)
+	if err != nil {
+		return err
+	}
+
+	for _, listener := range listeners {
+		builder.graph.AddNode(ResourceGraphKindVirtualListener, listener.ID, listener.Name)
+		if listener.ListenerIPAddress != "" {
+			builder.loadBalancerIDsByIPAddress[listener.ListenerIPAddress] = listener.ID
+		}
+
+		blockID := builder.findPublicIPBlock(listener.ListenerIPAddress)
+		if blockID != "" {
+			builder.link(listener.ID, blockID)
+		}
+
+		for _, pool := range []EntityReference{listener.Pool.EntityReference, listener.ClientClonePool.EntityReference} {
+			if pool.ID != "" {
+				builder.link(listener.ID, pool.ID)
+			}
+		}
+
+		// Persistence profiles and iRules are system-defined, so they are only added to the graph when referenced.
+		for _, persistenceProfile := range []EntityReference{listener.PersistenceProfile, listener.FallbackPersistenceProfile} {
+			if persistenceProfile.ID != "" {
+				builder.graph.AddNode(ResourceGraphKindPersistenceProfile, persistenceProfile.ID, persistenceProfile.Name)
+				builder.link(listener.ID, persistenceProfile.ID)
+			}
+		}
+		for _, iRule := range listener.IRules {
+			builder.graph.AddNode(ResourceGraphKindIRule, iRule.ID, iRule.Name)
+			builder.link(listener.ID, iRule.ID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,228 @@
+package compute
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// Build resource graph for network domain (successful).
+func TestClient_BuildResourceGraph_Success(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			graph, err := client.BuildResourceGraph("484174a2-ae74-4658-9e56-50fc90e086cf")
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			server := graph.GetNode("5a32d6e4-9707-4813-a269-56ab4d989f4d")
+			expect.NotNil("Server node", server)
+			expect.EqualsString("Server.Kind", ResourceGraphKindServer, server.Kind)
+
+			serverDependencies := graph.GetDependencies(server.ID)
+			expect.EqualsInt("Server.Dependencies.Length", 1, len(serverDependencies))
+			expect.EqualsString("Server.Dependencies[0].Kind", ResourceGraphKindVLAN, serverDependencies[0].Kind)
+
+			vlanDependents := graph.GetDependents("0e56433f-d808-4669-821d-812769517ff8")
+			expect.EqualsInt("VLAN.Dependents.Length", 2, len(vlanDependents))
+
+			// Servers, anti-affinity rule, NAT rule, VIP node, and pool member.
+			allVLANDependents := graph.GetAllDependents("0e56433f-d808-4669-821d-812769517ff8")
+			expect.EqualsInt("VLAN.AllDependents.Length", 6, len(allVLANDependents))
+
+			natRuleDependencies := graph.GetDependencies("2169a38e-5692-497e-a22a-701a838a6539")
+			expect.EqualsInt("NATRule.Dependencies.Length", 2, len(natRuleDependencies))
+			expect.EqualsString("NATRule.Dependencies[0].Kind", ResourceGraphKindServer, natRuleDependencies[0].Kind)
+			expect.EqualsString("NATRule.Dependencies[1].Kind", ResourceGraphKindPublicIPBlock, natRuleDependencies[1].Kind)
+
+			poolMemberDependencies := graph.GetDependencies("3dd806a2-c2c8-4c0c-9a4f-5219ea9266c0")
+			expect.EqualsInt("PoolMember.Dependencies.Length", 2, len(poolMemberDependencies))
+
+			listenerDependencies := graph.GetDependencies("6115469d-a8bb-445b-bb23-d23b5283f2b9")
+			expect.EqualsInt("VirtualListener.Dependencies.Length", 2, len(listenerDependencies))
+			expect.EqualsString("VirtualListener.Dependencies[0].Kind", ResourceGraphKindPublicIPBlock, listenerDependencies[0].Kind)
+			expect.EqualsString("VirtualListener.Dependencies[1].Kind", ResourceGraphKindVIPPool, listenerDependencies[1].Kind)
+
+			firewallRuleDependencies := graph.GetDependencies("a1b2c3d4-0000-4000-8000-000000000001")
+			expect.EqualsInt("FirewallRule.Dependencies.Length", 2, len(firewallRuleDependencies))
+			expect.EqualsString("FirewallRule.Dependencies[0].Name", "Admins", firewallRuleDependencies[0].Name)
+			expect.EqualsString("FirewallRule.Dependencies[1].Name", "Web", firewallRuleDependencies[1].Name)
+
+			order, err := graph.TopologicalOrder()
+			if err != nil {
+				test.Fatal(err)
+			}
+			expect.EqualsInt("Order.Length", len(graph.GetNodes()), len(order))
+			positions := make(map[string]int)
+			for index, node := range order {
+				positions[node.ID] = index
+			}
+			for _, node := range order {
+				for _, dependency := range graph.GetDependencies(node.ID) {
+					expect.IsTrue("Dependency appears before dependent", positions[dependency.ID] < positions[node.ID])
+				}
+			}
+
+			var buffer bytes.Buffer
+			err = graph.WriteDOT(&buffer)
+			if err != nil {
+				test.Fatal(err)
+			}
+			dot := buffer.String()
+			expect.IsTrue("DOT starts with digraph", strings.HasPrefix(dot, `digraph "484174a2-ae74-4658-9e56-50fc90e086cf" {`))
+			expect.IsTrue("DOT contains server -> VLAN edge", strings.Contains(dot, `"5a32d6e4-9707-4813-a269-56ab4d989f4d" -> "0e56433f-d808-4669-821d-812769517ff8";`))
+			expect.IsTrue("DOT contains server label", strings.Contains(dot, `[label="Server\nProduction Web Server"]`))
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			for pathSuffix, response := range exportNetworkDomainTestResponses {
+				if strings.HasSuffix(request.URL.Path, pathSuffix) {
+					return http.StatusOK, response
+				}
+			}
+
+			test.Fatalf("Unexpected request: %s %s", request.Method, request.URL.Path)
+
+			return http.StatusNotFound, ""
+		},
+	})
+}
+
+// Build resource graph for network domain (IP address lists span multiple pages).
+func TestClient_BuildResourceGraph_MultiplePages(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			graph, err := client.BuildResourceGraph("484174a2-ae74-4658-9e56-50fc90e086cf")
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			// "Offices" is on the second page.
+			addressListDependencies := graph.GetDependencies("c8c92ea3-2da8-4d51-8153-f39bec794d69")
+			expect.EqualsInt("IPAddressList.Dependencies.Length", 1, len(addressListDependencies))
+			expect.EqualsString("IPAddressList.Dependencies[0].Name", "Offices", addressListDependencies[0].Name)
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			if strings.HasSuffix(request.URL.Path, "/network/ipAddressList") {
+				if request.URL.Query().Get("pageNumber") == "2" {
+					return http.StatusOK, exportNetworkDomainIPAddressListsPage2TestResponse
+				}
+
+				return http.StatusOK, exportNetworkDomainIPAddressListsPage1TestResponse
+			}
+
+			for pathSuffix, response := range exportNetworkDomainTestResponses {
+				if strings.HasSuffix(request.URL.Path, pathSuffix) {
+					return http.StatusOK, response
+				}
+			}
+
+			test.Fatalf("Unexpected request: %s %s", request.Method, request.URL.Path)
+
+			return http.StatusNotFound, ""
+		},
+	})
+}
+
+// Build resource graph for network domain (NAT rule forwards to a virtual listener).
+func TestClient_BuildResourceGraph_NATRuleToVirtualListener(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			graph, err := client.BuildResourceGraph("484174a2-ae74-4658-9e56-50fc90e086cf")
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			natRuleDependencies := graph.GetDependencies("2169a38e-5692-497e-a22a-701a838a6539")
+			expect.EqualsInt("NATRule.Dependencies.Length", 2, len(natRuleDependencies))
+			expect.EqualsString("NATRule.Dependencies[0].Kind", ResourceGraphKindVirtualListener, natRuleDependencies[0].Kind)
+			expect.EqualsString("NATRule.Dependencies[0].ID", "6115469d-a8bb-445b-bb23-d23b5283f2b9", natRuleDependencies[0].ID)
+			expect.EqualsString("NATRule.Dependencies[1].Kind", ResourceGraphKindPublicIPBlock, natRuleDependencies[1].Kind)
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			if strings.HasSuffix(request.URL.Path, "/network/natRule") {
+				return http.StatusOK, resourceGraphNATRuleToVirtualListenerTestResponse
+			}
+			if strings.HasSuffix(request.URL.Path, "/networkDomainVip/virtualListener") {
+				return http.StatusOK, resourceGraphPrivateVirtualListenerTestResponse
+			}
+
+			for pathSuffix, response := range exportNetworkDomainTestResponses {
+				if strings.HasSuffix(request.URL.Path, pathSuffix) {
+					return http.StatusOK, response
+				}
+			}
+
+			test.Fatalf("Unexpected request: %s %s", request.Method, request.URL.Path)
+
+			return http.StatusNotFound, ""
+		},
+	})
+}
+
+// Resource graph topological order (graph contains a cycle).
+func TestResourceGraph_TopologicalOrder_Cycle(test *testing.T) {
+	expect := expect(test)
+
+	graph := NewResourceGraph("484174a2-ae74-4658-9e56-50fc90e086cf")
+	graph.AddNode(ResourceGraphKindIPAddressList, "list1", "List 1")
+	graph.AddNode(ResourceGraphKindIPAddressList, "list2", "List 2")
+
+	err := graph.AddDependency("list1", "list2")
+	if err != nil {
+		test.Fatal(err)
+	}
+	err = graph.AddDependency("list2", "list1")
+	if err != nil {
+		test.Fatal(err)
+	}
+	err = graph.AddDependency("list2", "list3")
+	expect.NotNil("AddDependency error (unknown resource)", err)
+
+	_, err = graph.TopologicalOrder()
+	expect.NotNil("TopologicalOrder error", err)
+
+	cycleError, ok := err.(*ResourceGraphCycleError)
+	expect.IsTrue("Error is ResourceGraphCycleError", ok)
+	expect.EqualsInt("ResourceGraphCycleError.Cycle.Length", 3, len(cycleError.Cycle))
+	expect.EqualsString("Error message", "Resource graph contains a cycle (IPAddressList 'List 1' -> IPAddressList 'List 2' -> IPAddressList 'List 1').", err.Error())
+}
+
+// Escape strings for DOT output.
+func TestEscapeDOTString(test *testing.T) {
+	expect(test).EqualsString("Escaped", `Say \"hello\"\\n`, escapeDOTString(`Say "hello"\n`))
+}
+
+const resourceGraphNATRuleToVirtualListenerTestResponse = `
+	{
+		"natRule": [
+			{ "id": "2169a38e-5692-497e-a22a-701a838a6539", "internalIp": "10.0.3.50", "externalIp": "165.180.12.12", "state": "NORMAL" }
+		],
+		"pageNumber": 1, "pageCount": 1, "totalCount": 1, "pageSize": 50
+	}
+`
+
+const resourceGraphPrivateVirtualListenerTestResponse = `
+	{
+		"virtualListener": [
+			{
+				"id": "6115469d-a8bb-445b-bb23-d23b5283f2b9",
+				"name": "Web Listener",
+				"type": "STANDARD",
+				"protocol": "HTTP",
+				"listenerIpAddress": "10.0.3.50",
+				"port": 80,
+				"enabled": true,
+				"pool": { "id": "4d360b1f-bc2c-4ab7-9884-1f03ba2768f7" },
+				"state": "NORMAL"
+			}
+		],
+		"pageNumber": 1, "pageCount": 1, "totalCount": 1, "pageSize": 50
+	}
+`
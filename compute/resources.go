@@ -41,6 +41,12 @@ const (
 
 	// ResourceTypeVirtualListener represents a virtual listener.
 	ResourceTypeVirtualListener
+
+	// ResourceTypeNATRule represents a NAT rule.
+	ResourceTypeNATRule
+
+	// ResourceTypeVIPPoolMember represents a VIP pool member.
+	ResourceTypeVIPPoolMember
 )
 
 // Resource represents a compute resource.
@@ -94,6 +100,12 @@ func GetResourceDescription(resourceType ResourceType) (string, error) {
 	case ResourceTypeVirtualListener:
 		return "virtual listener", nil
 
+	case ResourceTypeNATRule:
+		return "NAT rule", nil
+
+	case ResourceTypeVIPPoolMember:
+		return "VIP pool member", nil
+
 	default:
 		return "", fmt.Errorf("Unrecognised resource type (value = %d).", resourceType)
 	}
@@ -133,6 +145,12 @@ func (client *Client) GetResource(id string, resourceType ResourceType) (Resourc
 
 	case ResourceTypeVirtualListener:
 		return client.GetVirtualListener(id)
+
+	case ResourceTypeNATRule:
+		return client.GetNATRule(id)
+
+	case ResourceTypeVIPPoolMember:
+		return client.GetVIPPoolMember(id)
 	}
 
 	return nil, fmt.Errorf("Unrecognised resource type (value = %d).", resourceType)
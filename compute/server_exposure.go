@@ -0,0 +1,227 @@
+package compute
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// ExposeServerOptions represents the options for exposing a server to the Internet.
+type ExposeServerOptions struct {
+	// The Id of the server to expose.
+	ServerID string
+
+	// The server's private IPv4 address to expose (if not specified, the address of the server's primary network adapter is used).
+	PrivateIPv4Address string
+
+	// The TCP ports to allow through the firewall (one firewall rule is created for each port).
+	Ports []int
+
+	// The amount of time to wait for each new resource (public IP block, NAT rule, or firewall rule) to be deployed.
+	Timeout time.Duration
+}
+
+// ServerExposure represents a server that has been exposed to the Internet (via a NAT rule and firewall rules).
+//
+// It records which resources were created, so that UnexposeServer can remove exactly those resources.
+type ServerExposure struct {
+	// The Id of the exposed server.
+	ServerID string
+
+	// The Id of the network domain where the server is located.
+	NetworkDomainID string
+
+	// The server's private IPv4 address.
+	PrivateIPv4Address string
+
+	// The public IPv4 address that the server is exposed on.
+	PublicIPv4Address string
+
+	// The Id of the NAT rule that maps the public IPv4 address to the server's private IPv4 address.
+	NATRuleID string
+
+	// The Ids of the firewall rules that allow traffic to the public IPv4 address.
+	FirewallRuleIDs []string
+
+	// The Id of the public IP block that was added to provide the public IPv4 address (empty if an existing block had a free address).
+	CreatedPublicIPBlockID string
+
+	// Was the NAT rule created by ExposeServer (rather than already existing)?
+	CreatedNATRule bool
+
+	// The Ids of the firewall rules that were created by ExposeServer (rather than already existing).
+	CreatedFirewallRuleIDs []string
+}
+
+// ExposeServer publishes a server to the Internet.
+//
+// It finds a free public IPv4 address in the server's network domain (adding a new public IP block if there are none), creates a NAT rule from that address to the server's private IPv4 address,
+// and creates a firewall rule that accepts TCP traffic to the public address for each of the requested ports (waiting up to options.Timeout for each new resource to be deployed).
+//
+// ExposeServer is idempotent; if the server's private address already has a NAT rule, its public address is reused, and firewall rules that already exist (by name) are not created again.
+// If an error occurs, the returned ServerExposure describes the resources that were created before the failure (so the operation can be retried, or undone using UnexposeServer).
+func (client *Client) ExposeServer(options ExposeServerOptions) (*ServerExposure, error) {
+	if len(options.Ports) == 0 {
+		return nil, fmt.Errorf("Must specify at least one port to expose server '%s'.", options.ServerID)
+	}
+	for _, port := range options.Ports {
+		if port < 1 || port > 65535 {
+			return nil, fmt.Errorf("Invalid port %d (must be between 1 and 65535).", port)
+		}
+	}
+
+	server, err := client.GetServer(options.ServerID)
+	if err != nil {
+		return nil, err
+	}
+	if server == nil {
+		return nil, fmt.Errorf("No server was found with Id '%s'.", options.ServerID)
+	}
+
+	exposure := &ServerExposure{
+		ServerID:           server.ID,
+		NetworkDomainID:    server.Network.NetworkDomainID,
+		PrivateIPv4Address: options.PrivateIPv4Address,
+	}
+	if exposure.PrivateIPv4Address == "" {
+		if server.Network.PrimaryAdapter.PrivateIPv4Address == nil {
+			return nil, fmt.Errorf("Server '%s' does not have a private IPv4 address.", server.ID)
+		}
+		exposure.PrivateIPv4Address = *server.Network.PrimaryAdapter.PrivateIPv4Address
+	}
+
+	err = client.ensureServerExposureNATRule(exposure, options.Timeout)
+	if err != nil {
+		return exposure, err
+	}
+
+	err = client.ensureServerExposureFirewallRules(exposure, options.Ports, options.Timeout)
+	if err != nil {
+		return exposure, err
+	}
+
+	return exposure, nil
+}
+
+// UnexposeServer removes the resources created by ExposeServer (firewall rules, the NAT rule, and the public IP block, if ExposeServer created them).
+//
+// Resources that were already present when ExposeServer was called are left in place, as are resources that have already been deleted.
+// A public IP block is only removed if none of its addresses are still reserved.
+func (client *Client) UnexposeServer(exposure *ServerExposure) error {
+	for _, ruleID := range exposure.CreatedFirewallRuleIDs {
+		log.Printf("Delete firewall rule '%s'...", ruleID)
+		err := client.DeleteFirewallRule(ruleID)
+		if err != nil && !isResourceNotFoundError(err) {
+			return err
+		}
+	}
+
+	if exposure.CreatedNATRule {
+		log.Printf("Delete NAT rule '%s'...", exposure.NATRuleID)
+		err := client.DeleteNATRule(exposure.NATRuleID)
+		if err != nil && !isResourceNotFoundError(err) {
+			return err
+		}
+	}
+
+	if exposure.CreatedPublicIPBlockID == "" {
+		return nil
+	}
+
+	_, err := client.RemovePublicIPBlockIfUnused(exposure.CreatedPublicIPBlockID)
+
+	return err
+}
+
+// Find (or create) the NAT rule for an exposed server.
+func (client *Client) ensureServerExposureNATRule(exposure *ServerExposure, timeout time.Duration) error {
+	natRule, err := client.GetNATRuleByInternalIPAddress(exposure.NetworkDomainID, exposure.PrivateIPv4Address)
+	if err != nil {
+		return err
+	}
+	if natRule != nil {
+		exposure.NATRuleID = natRule.ID
+		exposure.PublicIPv4Address = natRule.ExternalIPAddress
+
+		return nil
+	}
+
+	allocation, err := client.AllocatePublicIPv4Address(exposure.NetworkDomainID, timeout)
+	if err != nil {
+		return err
+	}
+	if allocation.AddedBlock {
+		exposure.CreatedPublicIPBlockID = allocation.BlockID
+	}
+	publicIPv4Address := allocation.Address
+
+	log.Printf("Create NAT rule '%s' -> '%s'...", publicIPv4Address, exposure.PrivateIPv4Address)
+	natRuleID, err := client.AddNATRule(exposure.NetworkDomainID, exposure.PrivateIPv4Address, &publicIPv4Address)
+	if err != nil {
+		return err
+	}
+	exposure.NATRuleID = natRuleID
+	exposure.PublicIPv4Address = publicIPv4Address
+	exposure.CreatedNATRule = true
+
+	_, err = client.WaitForDeploy(ResourceTypeNATRule, natRuleID, timeout)
+
+	return err
+}
+
+// Find (or create) the firewall rules for an exposed server.
+func (client *Client) ensureServerExposureFirewallRules(exposure *ServerExposure, ports []int, timeout time.Duration) error {
+	existingRules, err := client.listAllFirewallRules(exposure.NetworkDomainID)
+	if err != nil {
+		return err
+	}
+	existingRuleIDs := make(map[string]string)
+	for _, existingRule := range existingRules {
+		existingRuleIDs[existingRule.Name] = existingRule.ID
+	}
+
+	for _, port := range ports {
+		ruleName := serverExposureFirewallRuleName(exposure.PublicIPv4Address, port)
+		if ruleID, ok := existingRuleIDs[ruleName]; ok {
+			exposure.FirewallRuleIDs = append(exposure.FirewallRuleIDs, ruleID)
+
+			continue
+		}
+
+		ruleConfiguration := &FirewallRuleConfiguration{
+			Name:            ruleName,
+			NetworkDomainID: exposure.NetworkDomainID,
+			Placement: FirewallRulePlacement{
+				Position: "LAST",
+			},
+		}
+		ruleConfiguration.Enable().Accept().IPv4().TCP()
+		ruleConfiguration.MatchAnySourceAddress().MatchAnySourcePort()
+		ruleConfiguration.MatchDestinationAddress(exposure.PublicIPv4Address).MatchDestinationPort(port)
+
+		log.Printf("Create firewall rule '%s'...", ruleName)
+		var ruleID string
+		ruleID, err = client.CreateFirewallRule(*ruleConfiguration)
+		if err != nil {
+			return err
+		}
+		exposure.FirewallRuleIDs = append(exposure.FirewallRuleIDs, ruleID)
+		exposure.CreatedFirewallRuleIDs = append(exposure.CreatedFirewallRuleIDs, ruleID)
+
+		_, err = client.WaitForDeploy(ResourceTypeFirewallRule, ruleID, timeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Get the name of the firewall rule that allows traffic to the specified port on an exposed server's public IPv4 address.
+func serverExposureFirewallRuleName(publicIPv4Address string, port int) string {
+	return fmt.Sprintf("Expose_%s_TCP_%d",
+		strings.Replace(publicIPv4Address, ".", "_", -1),
+		port,
+	)
+}
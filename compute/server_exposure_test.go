@@ -0,0 +1,183 @@
+package compute
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Expose server (free address in existing public IP block).
+func TestClient_ExposeServer_Success(test *testing.T) {
+	expect := expect(test)
+
+	var createdNATRules, createdFirewallRules int
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			exposure, err := client.ExposeServer(ExposeServerOptions{
+				ServerID: "5a32d6e4-9707-4813-a269-56ab4d989f4d",
+				Ports:    []int{80, 443},
+				Timeout:  30 * time.Second,
+			})
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.EqualsString("Exposure.NetworkDomainID", "553f26b6-2a73-42c3-a78b-6116f11291d0", exposure.NetworkDomainID)
+			expect.EqualsString("Exposure.PrivateIPv4Address", "10.0.4.8", exposure.PrivateIPv4Address)
+			expect.EqualsString("Exposure.PublicIPv4Address", "165.180.12.13", exposure.PublicIPv4Address)
+			expect.EqualsString("Exposure.NATRuleID", "2169a38e-5692-497e-a22a-701a838a6539", exposure.NATRuleID)
+			expect.IsTrue("Exposure.CreatedNATRule", exposure.CreatedNATRule)
+			expect.EqualsString("Exposure.CreatedPublicIPBlockID", "", exposure.CreatedPublicIPBlockID)
+
+			// The rule for port 443 already exists.
+			expect.EqualsInt("Exposure.FirewallRuleIDs.Length", 2, len(exposure.FirewallRuleIDs))
+			expect.EqualsInt("Exposure.CreatedFirewallRuleIDs.Length", 1, len(exposure.CreatedFirewallRuleIDs))
+			expect.EqualsString("Exposure.FirewallRuleIDs[1]", "b4f1a5f7-2c4e-4e0b-8f3c-0a5b1ad1c443", exposure.FirewallRuleIDs[1])
+
+			expect.EqualsInt("NAT rules created", 1, createdNATRules)
+			expect.EqualsInt("Firewall rules created", 1, createdFirewallRules)
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			switch {
+			case strings.HasSuffix(request.URL.Path, "/server/server/5a32d6e4-9707-4813-a269-56ab4d989f4d"):
+				return http.StatusOK, getServerTestResponse
+			case strings.HasSuffix(request.URL.Path, "/network/natRule"):
+				return http.StatusOK, `{ "natRule": [], "pageNumber": 1, "pageCount": 0, "totalCount": 0, "pageSize": 50 }`
+			case strings.HasSuffix(request.URL.Path, "/network/publicIpBlock"):
+				return http.StatusOK, exposeServerListPublicIPBlocksTestResponse
+			case strings.HasSuffix(request.URL.Path, "/network/reservedPublicIpv4Address"):
+				return http.StatusOK, listReservedPublicIPAddressesResponse
+			case strings.HasSuffix(request.URL.Path, "/network/createNatRule"):
+				createdNATRules++
+
+				return http.StatusOK, exposeServerCreateNATRuleTestResponse
+			case strings.HasSuffix(request.URL.Path, "/network/natRule/2169a38e-5692-497e-a22a-701a838a6539"):
+				return http.StatusOK, `{ "id": "2169a38e-5692-497e-a22a-701a838a6539", "internalIp": "10.0.4.8", "externalIp": "165.180.12.13", "state": "NORMAL" }`
+			case strings.HasSuffix(request.URL.Path, "/network/firewallRule"):
+				return http.StatusOK, exposeServerListFirewallRulesTestResponse
+			case strings.HasSuffix(request.URL.Path, "/network/createFirewallRule"):
+				createdFirewallRules++
+
+				return http.StatusOK, exposeServerCreateFirewallRuleTestResponse
+			case strings.HasSuffix(request.URL.Path, "/network/firewallRule/a1b2c3d4-0000-4000-8000-000000000080"):
+				return http.StatusOK, `{ "id": "a1b2c3d4-0000-4000-8000-000000000080", "name": "Expose_165_180_12_13_TCP_80", "state": "NORMAL" }`
+			}
+
+			test.Fatalf("Unexpected request: %s %s", request.Method, request.URL.Path)
+
+			return http.StatusNotFound, ""
+		},
+	})
+}
+
+// Expose server (invalid port).
+func TestClient_ExposeServer_InvalidPort(test *testing.T) {
+	expect := expect(test)
+
+	client := NewClient("au1", "user1", "password")
+	_, err := client.ExposeServer(ExposeServerOptions{
+		ServerID: "5a32d6e4-9707-4813-a269-56ab4d989f4d",
+		Ports:    []int{80, 70000},
+	})
+	expect.NotNil("ExposeServer error", err)
+	expect.IsTrue("Error message mentions port", strings.Contains(err.Error(), "70000"))
+}
+
+// Unexpose server (only resources created by ExposeServer are deleted).
+func TestClient_UnexposeServer(test *testing.T) {
+	expect := expect(test)
+
+	var deletedFirewallRules, deletedNATRules int
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			err := client.UnexposeServer(&ServerExposure{
+				ServerID:               "5a32d6e4-9707-4813-a269-56ab4d989f4d",
+				NetworkDomainID:        "553f26b6-2a73-42c3-a78b-6116f11291d0",
+				PrivateIPv4Address:     "10.0.4.8",
+				PublicIPv4Address:      "165.180.12.13",
+				NATRuleID:              "2169a38e-5692-497e-a22a-701a838a6539",
+				FirewallRuleIDs:        []string{"a1b2c3d4-0000-4000-8000-000000000080", "b4f1a5f7-2c4e-4e0b-8f3c-0a5b1ad1c443"},
+				CreatedFirewallRuleIDs: []string{"a1b2c3d4-0000-4000-8000-000000000080"},
+			})
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.EqualsInt("Firewall rules deleted", 1, deletedFirewallRules)
+			expect.EqualsInt("NAT rules deleted", 0, deletedNATRules)
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			switch {
+			case strings.HasSuffix(request.URL.Path, "/network/deleteFirewallRule"):
+				deletedFirewallRules++
+
+				return http.StatusOK, exposeServerDeleteFirewallRuleTestResponse
+			case strings.HasSuffix(request.URL.Path, "/network/deleteNatRule"):
+				deletedNATRules++
+			}
+
+			test.Fatalf("Unexpected request: %s %s", request.Method, request.URL.Path)
+
+			return http.StatusNotFound, ""
+		},
+	})
+}
+
+const exposeServerListPublicIPBlocksTestResponse = `
+	{
+		"publicIpBlock": [
+			{ "id": "cacc028a-7f12-11e4-a91c-0030487e0302", "baseIp": "165.180.12.12", "size": 2, "state": "NORMAL" }
+		],
+		"pageNumber": 1, "pageCount": 1, "totalCount": 1, "pageSize": 50
+	}
+`
+
+const exposeServerListFirewallRulesTestResponse = `
+	{
+		"firewallRule": [
+			{ "id": "b4f1a5f7-2c4e-4e0b-8f3c-0a5b1ad1c443", "name": "Expose_165_180_12_13_TCP_443", "ruleType": "CLIENT_RULE", "state": "NORMAL" }
+		],
+		"pageNumber": 1, "pageCount": 1, "totalCount": 1, "pageSize": 50
+	}
+`
+
+const exposeServerCreateNATRuleTestResponse = `
+	{
+		"operation": "CREATE_NAT_RULE",
+		"responseCode": "OK",
+		"message": "NAT Rule has been created.",
+		"info": [
+			{ "name": "natRuleId", "value": "2169a38e-5692-497e-a22a-701a838a6539" }
+		],
+		"warning": [],
+		"error": [],
+		"requestId": "na9_20160321T074626030-0400_7e9fffe7-190b-46f2-9107-9d52fe57d0ad"
+	}
+`
+
+const exposeServerCreateFirewallRuleTestResponse = `
+	{
+		"operation": "CREATE_FIREWALL_RULE",
+		"responseCode": "OK",
+		"message": "Firewall Rule 'Expose_165_180_12_13_TCP_80' has been created.",
+		"info": [
+			{ "name": "firewallRuleId", "value": "a1b2c3d4-0000-4000-8000-000000000080" }
+		],
+		"warning": [],
+		"error": [],
+		"requestId": "na9_20160321T074626030-0400_7e9fffe7-190b-46f2-9107-9d52fe57d0ad"
+	}
+`
+
+const exposeServerDeleteFirewallRuleTestResponse = `
+	{
+		"operation": "DELETE_FIREWALL_RULE",
+		"responseCode": "OK",
+		"message": "Firewall Rule has been deleted.",
+		"info": [],
+		"warning": [],
+		"error": [],
+		"requestId": "na9_20160321T074626030-0400_7e9fffe7-190b-46f2-9107-9d52fe57d0ad"
+	}
+`
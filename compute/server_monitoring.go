@@ -0,0 +1,470 @@
+package compute
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// ServerMonitoringPlanEssentials represents the "Essentials" server monitoring plan.
+	ServerMonitoringPlanEssentials = "ESSENTIALS"
+
+	// ServerMonitoringPlanAdvanced represents the "Advanced" server monitoring plan.
+	ServerMonitoringPlanAdvanced = "ADVANCED"
+)
+
+// The date format used by report parameters.
+const reportDateFormat = "2006-01-02"
+
+// The timestamp formats (in order of preference) that may appear in reports.
+var reportTimestampFormats = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	reportDateFormat,
+}
+
+// ServerMonitoring represents the monitoring configuration for a server (as returned with the server's details).
+type ServerMonitoring struct {
+	// The server's monitoring Id.
+	MonitoringID string `json:"monitoringId"`
+
+	// The server's monitoring plan (e.g. ServerMonitoringPlanEssentials).
+	ServicePlan string `json:"servicePlan"`
+
+	// The server's current monitoring state.
+	State string `json:"state"`
+}
+
+// IsMonitoringEnabled determines whether monitoring is enabled for the server.
+func (server *Server) IsMonitoringEnabled() bool {
+	return server.Monitoring != nil
+}
+
+// TimeSeriesPoint represents a single value in a time series.
+type TimeSeriesPoint struct {
+	// The time at which the value was recorded.
+	Timestamp time.Time
+
+	// The recorded value.
+	Value float64
+}
+
+// TimeSeries represents a series of values over time (in chronological order).
+type TimeSeries []TimeSeriesPoint
+
+// Len returns the number of values in the time series (implements sort.Interface).
+func (series TimeSeries) Len() int {
+	return len(series)
+}
+
+// Less determines whether the value at index1 was recorded before the value at index2 (implements sort.Interface).
+func (series TimeSeries) Less(index1 int, index2 int) bool {
+	return series[index1].Timestamp.Before(series[index2].Timestamp)
+}
+
+// Swap exchanges the values at the specified indexes (implements sort.Interface).
+func (series TimeSeries) Swap(index1 int, index2 int) {
+	series[index1], series[index2] = series[index2], series[index1]
+}
+
+// IsEmpty determines whether the time series contains no values.
+func (series TimeSeries) IsEmpty() bool {
+	return len(series) == 0
+}
+
+// Average calculates the average of the values in the time series (0 if the series is empty).
+func (series TimeSeries) Average() float64 {
+	if series.IsEmpty() {
+		return 0
+	}
+
+	return series.Total() / float64(len(series))
+}
+
+// Maximum determines the largest value in the time series (0 if the series is empty).
+func (series TimeSeries) Maximum() float64 {
+	maximum := 0.0
+	for index, point := range series {
+		if index == 0 || point.Value > maximum {
+			maximum = point.Value
+		}
+	}
+
+	return maximum
+}
+
+// Total calculates the sum of the values in the time series.
+func (series TimeSeries) Total() float64 {
+	total := 0.0
+	for _, point := range series {
+		total += point.Value
+	}
+
+	return total
+}
+
+// ServerMonitoringData represents monitoring data (resource utilisation over time) for a server.
+type ServerMonitoringData struct {
+	// The server Id.
+	ServerID string
+
+	// The server name.
+	ServerName string
+
+	// CPU utilisation (percent).
+	CPUPercent TimeSeries
+
+	// Memory utilisation (percent).
+	MemoryPercent TimeSeries
+
+	// Disk reads (KB/s).
+	DiskReadKBPerSecond TimeSeries
+
+	// Disk writes (KB/s).
+	DiskWriteKBPerSecond TimeSeries
+
+	// Network traffic received (Kb/s).
+	NetworkInKbPerSecond TimeSeries
+
+	// Network traffic sent (Kb/s).
+	NetworkOutKbPerSecond TimeSeries
+}
+
+// ServerUsage represents resource usage (per day) for a server.
+type ServerUsage struct {
+	// The server Id.
+	ServerID string
+
+	// The server name.
+	ServerName string
+
+	// CPU usage (CPU-hours).
+	CPUHours TimeSeries
+
+	// Memory usage (GB-hours).
+	MemoryGBHours TimeSeries
+
+	// Disk usage (GB-hours, all disk speeds).
+	StorageGBHours TimeSeries
+
+	// Network traffic received (GB).
+	NetworkInGB TimeSeries
+
+	// Network traffic sent (GB).
+	NetworkOutGB TimeSeries
+}
+
+// Request body when enabling monitoring for a server or changing its monitoring plan.
+type serverMonitoringPlan struct {
+	ServerID    string `json:"id"`
+	ServicePlan string `json:"servicePlan"`
+}
+
+// Request body when disabling monitoring for a server.
+type disableServerMonitoring struct {
+	ServerID string `json:"id"`
+}
+
+// Report columns for server monitoring data.
+const (
+	monitoringColumnServerID   = "Server Id"
+	monitoringColumnServerName = "Server Name"
+	monitoringColumnTimestamp  = "Timestamp"
+	monitoringColumnCPU        = "CPU (%)"
+	monitoringColumnMemory     = "Memory (%)"
+	monitoringColumnDiskRead   = "Disk Read (KB/s)"
+	monitoringColumnDiskWrite  = "Disk Write (KB/s)"
+	monitoringColumnNetworkIn  = "Network In (Kb/s)"
+	monitoringColumnNetworkOut = "Network Out (Kb/s)"
+)
+
+// Report columns for server usage.
+const (
+	usageColumnServerID   = "Server Id"
+	usageColumnServerName = "Server Name"
+	usageColumnDate       = "Date"
+	usageColumnCPU        = "CPU Hours"
+	usageColumnMemory     = "RAM Hours"
+	usageColumnStorage    = "Storage Hours"
+	usageColumnNetworkIn  = "Network In (GB)"
+	usageColumnNetworkOut = "Network Out (GB)"
+)
+
+// EnableServerMonitoring enables monitoring for a server, using the specified plan (e.g. ServerMonitoringPlanEssentials).
+func (client *Client) EnableServerMonitoring(serverID string, servicePlan string) error {
+	return client.executeServerMonitoringOperation("enableServerMonitoring", &serverMonitoringPlan{
+		ServerID:    serverID,
+		ServicePlan: servicePlan,
+	}, "enable monitoring for server '%s'", serverID)
+}
+
+// ChangeServerMonitoringPlan changes the monitoring plan for a server (e.g. to ServerMonitoringPlanAdvanced).
+func (client *Client) ChangeServerMonitoringPlan(serverID string, servicePlan string) error {
+	return client.executeServerMonitoringOperation("changeServerMonitoringPlan", &serverMonitoringPlan{
+		ServerID:    serverID,
+		ServicePlan: servicePlan,
+	}, "change monitoring plan for server '%s'", serverID)
+}
+
+// DisableServerMonitoring disables monitoring for a server.
+func (client *Client) DisableServerMonitoring(serverID string) error {
+	return client.executeServerMonitoringOperation("disableServerMonitoring", &disableServerMonitoring{
+		ServerID: serverID,
+	}, "disable monitoring for server '%s'", serverID)
+}
+
+// GetServerMonitoringData retrieves monitoring data for the specified server, between the specified dates (inclusive).
+// Monitoring must be enabled for the server.
+func (client *Client) GetServerMonitoringData(serverID string, startDate time.Time, endDate time.Time) (data *ServerMonitoringData, err error) {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return nil, err
+	}
+
+	requestURI := fmt.Sprintf("%s/report/serverMonitoring?serverId=%s&startDate=%s&endDate=%s",
+		organizationID,
+		serverID,
+		startDate.Format(reportDateFormat),
+		endDate.Format(reportDateFormat),
+	)
+	reportData, err := client.downloadReport(requestURI, "server monitoring")
+	if err != nil {
+		return nil, err
+	}
+
+	servers, err := parseServerTimeSeriesReport(reportData, monitoringColumnServerID, monitoringColumnServerName, monitoringColumnTimestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	data = &ServerMonitoringData{
+		ServerID: serverID,
+	}
+	for _, server := range servers {
+		if server.ServerID != serverID {
+			continue
+		}
+
+		data.ServerName = server.ServerName
+		data.CPUPercent = server.Metrics[monitoringColumnCPU]
+		data.MemoryPercent = server.Metrics[monitoringColumnMemory]
+		data.DiskReadKBPerSecond = server.Metrics[monitoringColumnDiskRead]
+		data.DiskWriteKBPerSecond = server.Metrics[monitoringColumnDiskWrite]
+		data.NetworkInKbPerSecond = server.Metrics[monitoringColumnNetworkIn]
+		data.NetworkOutKbPerSecond = server.Metrics[monitoringColumnNetworkOut]
+	}
+
+	return data, nil
+}
+
+// GetServerUsage retrieves resource usage for all servers in the organisation, between the specified dates (inclusive).
+func (client *Client) GetServerUsage(startDate time.Time, endDate time.Time) (usage []ServerUsage, err error) {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return nil, err
+	}
+
+	requestURI := fmt.Sprintf("%s/report/usage?startDate=%s&endDate=%s",
+		organizationID,
+		startDate.Format(reportDateFormat),
+		endDate.Format(reportDateFormat),
+	)
+	reportData, err := client.downloadReport(requestURI, "usage")
+	if err != nil {
+		return nil, err
+	}
+
+	servers, err := parseServerTimeSeriesReport(reportData, usageColumnServerID, usageColumnServerName, usageColumnDate)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, server := range servers {
+		usage = append(usage, ServerUsage{
+			ServerID:       server.ServerID,
+			ServerName:     server.ServerName,
+			CPUHours:       server.Metrics[usageColumnCPU],
+			MemoryGBHours:  server.Metrics[usageColumnMemory],
+			StorageGBHours: server.Metrics[usageColumnStorage],
+			NetworkInGB:    server.Metrics[usageColumnNetworkIn],
+			NetworkOutGB:   server.Metrics[usageColumnNetworkOut],
+		})
+	}
+
+	return usage, nil
+}
+
+// Execute a server monitoring operation.
+func (client *Client) executeServerMonitoringOperation(operationName string, requestBody interface{}, operationDescriptionOrFormat string, formatArgs ...interface{}) error {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return err
+	}
+
+	requestURI := fmt.Sprintf("%s/server/%s", organizationID, operationName)
+	request, err := client.newRequestV22(requestURI, http.MethodPost, requestBody)
+	if err != nil {
+		return err
+	}
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return err
+	}
+
+	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
+	if err != nil {
+		return err
+	}
+
+	if apiResponse.ResponseCode != ResponseCodeOK {
+		operationDescription := fmt.Sprintf(operationDescriptionOrFormat, formatArgs...)
+
+		return apiResponse.ToError("Request to %s failed with unexpected status code %d (%s): %s", operationDescription, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+	}
+
+	return nil
+}
+
+// Download a report (as CSV).
+func (client *Client) downloadReport(requestURI string, reportName string) ([]byte, error) {
+	request, err := client.newRequestV22(requestURI, http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "text/csv")
+
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode != http.StatusOK {
+		var apiResponse *APIResponseV2
+
+		apiResponse, err = readAPIResponseAsJSON(responseBody, statusCode)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, apiResponse.ToError("Request to download %s report failed with status code %d (%s): %s", reportName, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+	}
+
+	return responseBody, nil
+}
+
+// Time-series data (by metric) for a single server from a report.
+type serverTimeSeries struct {
+	ServerID   string
+	ServerName string
+	Metrics    map[string]TimeSeries
+}
+
+// Parse a CSV report containing one row per server per point in time.
+//
+// Every column other than the server Id, server name, and timestamp columns is treated as a numeric metric (keyed by column name).
+// Empty metric values are skipped. Servers are returned in the order they first appear in the report.
+func parseServerTimeSeriesReport(reportData []byte, serverIDColumn string, serverNameColumn string, timestampColumn string) (servers []serverTimeSeries, err error) {
+	reader := csv.NewReader(bytes.NewReader(reportData))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read report header: %s", err.Error())
+	}
+
+	columnIndexes := make(map[string]int)
+	for index, columnName := range header {
+		columnIndexes[strings.TrimSpace(columnName)] = index
+	}
+	for _, requiredColumn := range []string{serverIDColumn, timestampColumn} {
+		if _, ok := columnIndexes[requiredColumn]; !ok {
+			return nil, fmt.Errorf("Report is missing required column '%s'.", requiredColumn)
+		}
+	}
+
+	serverIndexes := make(map[string]int)
+	for rowNumber := 2; ; rowNumber++ {
+		var row []string
+		row, err = reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Unable to read report row %d: %s", rowNumber, err.Error())
+		}
+
+		serverID := row[columnIndexes[serverIDColumn]]
+		timestamp, err := parseReportTimestamp(row[columnIndexes[timestampColumn]])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid timestamp in report row %d: %s", rowNumber, err.Error())
+		}
+
+		serverIndex, ok := serverIndexes[serverID]
+		if !ok {
+			server := serverTimeSeries{
+				ServerID: serverID,
+				Metrics:  make(map[string]TimeSeries),
+			}
+			if nameIndex, ok := columnIndexes[serverNameColumn]; ok {
+				server.ServerName = row[nameIndex]
+			}
+
+			serverIndex = len(servers)
+			serverIndexes[serverID] = serverIndex
+			servers = append(servers, server)
+		}
+
+		for columnName, columnIndex := range columnIndexes {
+			if columnName == serverIDColumn || columnName == serverNameColumn || columnName == timestampColumn {
+				continue
+			}
+
+			rawValue := strings.TrimSpace(row[columnIndex])
+			if len(rawValue) == 0 {
+				continue
+			}
+
+			value, err := strconv.ParseFloat(rawValue, 64)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid value '%s' for '%s' in report row %d.", rawValue, columnName, rowNumber)
+			}
+
+			servers[serverIndex].Metrics[columnName] = append(servers[serverIndex].Metrics[columnName], TimeSeriesPoint{
+				Timestamp: timestamp,
+				Value:     value,
+			})
+		}
+	}
+
+	for _, server := range servers {
+		for _, series := range server.Metrics {
+			sort.Stable(series)
+		}
+	}
+
+	return servers, nil
+}
+
+// Parse a timestamp from a report.
+func parseReportTimestamp(rawTimestamp string) (timestamp time.Time, err error) {
+	rawTimestamp = strings.TrimSpace(rawTimestamp)
+	for _, format := range reportTimestampFormats {
+		timestamp, err = time.Parse(format, rawTimestamp)
+		if err == nil {
+			return timestamp, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("'%s' is not a recognised timestamp", rawTimestamp)
+}
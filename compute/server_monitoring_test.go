@@ -0,0 +1,120 @@
+package compute
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+// Enable server monitoring (successful).
+func TestClient_EnableServerMonitoring_Success(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			err := client.EnableServerMonitoring("5a32d6e4-9707-4813-a269-56ab4d989f4d", ServerMonitoringPlanAdvanced)
+			if err != nil {
+				test.Fatal(err)
+			}
+		},
+		Respond: testValidateJSONRequestAndRespondOK(enableServerMonitoringTestResponse, &serverMonitoringPlan{}, func(test *testing.T, requestBody interface{}) {
+			request := requestBody.(*serverMonitoringPlan)
+			expect.EqualsString("ServerMonitoringPlan.ServerID", "5a32d6e4-9707-4813-a269-56ab4d989f4d", request.ServerID)
+			expect.EqualsString("ServerMonitoringPlan.ServicePlan", ServerMonitoringPlanAdvanced, request.ServicePlan)
+		}),
+	})
+}
+
+// Get server monitoring data (successful).
+func TestClient_GetServerMonitoringData_Success(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			startDate := time.Date(2017, time.March, 1, 0, 0, 0, 0, time.UTC)
+			endDate := time.Date(2017, time.March, 2, 0, 0, 0, 0, time.UTC)
+
+			data, err := client.GetServerMonitoringData("5a32d6e4-9707-4813-a269-56ab4d989f4d", startDate, endDate)
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.NotNil("ServerMonitoringData", data)
+			expect.EqualsString("ServerMonitoringData.ServerName", "Production Web Server", data.ServerName)
+			expect.EqualsInt("ServerMonitoringData.CPUPercent.Length", 3, len(data.CPUPercent))
+			expect.EqualsInt("ServerMonitoringData.NetworkOutKbPerSecond.Length", 2, len(data.NetworkOutKbPerSecond)) // One value is missing.
+
+			// Values are sorted chronologically.
+			expect.IsTrue("ServerMonitoringData.CPUPercent[0].Value", data.CPUPercent[0].Value == 12.5)
+			expect.IsTrue("ServerMonitoringData.CPUPercent[2].Value", data.CPUPercent[2].Value == 80)
+			expect.IsTrue("ServerMonitoringData.CPUPercent.Maximum", data.CPUPercent.Maximum() == 80)
+			expect.IsTrue("ServerMonitoringData.CPUPercent.Average", data.CPUPercent.Average() == 42.5)
+			expect.IsTrue("ServerMonitoringData.MemoryPercent.Average", data.MemoryPercent.Average() == 50)
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			expect.EqualsString("Request.URL.Path", "/caas/2.2/my-organization-id/report/serverMonitoring", request.URL.Path)
+			expect.EqualsString("Request.Header.Accept", "text/csv", request.Header.Get("Accept"))
+
+			query := request.URL.Query()
+			expect.EqualsString("Query.serverId", "5a32d6e4-9707-4813-a269-56ab4d989f4d", query.Get("serverId"))
+			expect.EqualsString("Query.startDate", "2017-03-01", query.Get("startDate"))
+			expect.EqualsString("Query.endDate", "2017-03-02", query.Get("endDate"))
+
+			return http.StatusOK, getServerMonitoringDataTestResponse
+		},
+	})
+}
+
+// Get server usage (successful).
+func TestClient_GetServerUsage_Success(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			startDate := time.Date(2017, time.March, 1, 0, 0, 0, 0, time.UTC)
+			endDate := time.Date(2017, time.March, 2, 0, 0, 0, 0, time.UTC)
+
+			usage, err := client.GetServerUsage(startDate, endDate)
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.EqualsInt("ServerUsage.Length", 2, len(usage))
+			expect.EqualsString("ServerUsage[0].ServerName", "Production Web Server", usage[0].ServerName)
+			expect.EqualsInt("ServerUsage[0].CPUHours.Length", 2, len(usage[0].CPUHours))
+			expect.IsTrue("ServerUsage[0].CPUHours.Total", usage[0].CPUHours.Total() == 96)
+			expect.EqualsString("ServerUsage[1].ServerID", "0f1d6a3e-2b7c-4c2e-a5a1-3f9d8d6e7b41", usage[1].ServerID)
+			expect.IsTrue("ServerUsage[1].NetworkOutGB.Total", usage[1].NetworkOutGB.Total() == 0.5)
+		},
+		Respond: testRespondOK(getServerUsageTestResponse),
+	})
+}
+
+/*
+ * Test responses.
+ */
+
+const enableServerMonitoringTestResponse = `
+	{
+		"operation": "ENABLE_SERVER_MONITORING",
+		"responseCode": "OK",
+		"message": "Monitoring has been enabled for Server with Id 5a32d6e4-9707-4813-a269-56ab4d989f4d.",
+		"info": [],
+		"warning": [],
+		"error": [],
+		"requestId": "au1_20170302T050112345-0500_2f7b6c4d-1a2b-4c3d-8e9f-0a1b2c3d4e5f"
+	}
+`
+
+const getServerMonitoringDataTestResponse = `Server Id,Server Name,Timestamp,CPU (%),Memory (%),Disk Read (KB/s),Disk Write (KB/s),Network In (Kb/s),Network Out (Kb/s)
+5a32d6e4-9707-4813-a269-56ab4d989f4d,Production Web Server,2017-03-01 00:10:00,35,50,10,20,100,
+5a32d6e4-9707-4813-a269-56ab4d989f4d,Production Web Server,2017-03-01 00:00:00,12.5,40,5,10,80,90
+5a32d6e4-9707-4813-a269-56ab4d989f4d,Production Web Server,2017-03-01 00:20:00,80,60,15,30,120,130
+`
+
+const getServerUsageTestResponse = `Date,Server Id,Server Name,CPU Hours,RAM Hours,Storage Hours,Network In (GB),Network Out (GB)
+2017-03-01,5a32d6e4-9707-4813-a269-56ab4d989f4d,Production Web Server,48,96,1200,1.5,2.5
+2017-03-01,0f1d6a3e-2b7c-4c2e-a5a1-3f9d8d6e7b41,Production Database Server,96,384,2400,0.25,0.25
+2017-03-02,5a32d6e4-9707-4813-a269-56ab4d989f4d,Production Web Server,48,96,1200,1.5,2.5
+2017-03-02,0f1d6a3e-2b7c-4c2e-a5a1-3f9d8d6e7b41,Production Database Server,96,384,2400,0.25,0.25
+`
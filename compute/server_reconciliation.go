@@ -0,0 +1,579 @@
+package compute
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// Well-known server reconciliation actions.
+const (
+	// ServerReconciliationActionReconfigure represents a change to a server's CPU and / or memory configuration.
+	ServerReconciliationActionReconfigure = "RECONFIGURE_SERVER"
+
+	// ServerReconciliationActionAddDisk represents the addition of a disk to a server.
+	ServerReconciliationActionAddDisk = "ADD_DISK"
+
+	// ServerReconciliationActionResizeDisk represents the expansion of one of a server's disks.
+	ServerReconciliationActionResizeDisk = "RESIZE_DISK"
+
+	// ServerReconciliationActionChangeDiskSpeed represents a change to the speed of one of a server's disks.
+	ServerReconciliationActionChangeDiskSpeed = "CHANGE_DISK_SPEED"
+
+	// ServerReconciliationActionRemoveDisk represents the removal of a disk from a server.
+	ServerReconciliationActionRemoveDisk = "REMOVE_DISK"
+
+	// ServerReconciliationActionAddNetworkAdapter represents the addition of a network adapter to a server.
+	ServerReconciliationActionAddNetworkAdapter = "ADD_NIC"
+
+	// ServerReconciliationActionRemoveNetworkAdapter represents the removal of a network adapter from a server.
+	ServerReconciliationActionRemoveNetworkAdapter = "REMOVE_NIC"
+
+	// ServerReconciliationActionStart represents starting a server.
+	ServerReconciliationActionStart = "START_SERVER"
+
+	// ServerReconciliationActionShutdown represents (gracefully) shutting down a server.
+	ServerReconciliationActionShutdown = "SHUTDOWN_SERVER"
+)
+
+// ServerSpecification represents the desired configuration for an existing server.
+//
+// Fields that are nil are not reconciled (i.e. the server's existing configuration is retained).
+type ServerSpecification struct {
+	// The desired number of CPUs.
+	CPUCount *int
+
+	// The desired number of CPU cores per socket.
+	CPUCoresPerSocket *int
+
+	// The desired CPU speed (ServerCPUSpeedStandard or ServerCPUSpeedHighPerformance).
+	CPUSpeed *string
+
+	// The desired memory size, in gigabytes.
+	MemoryGB *int
+
+	// The desired disks (identified by SCSI unit Id).
+	//
+	// If nil, disks are not reconciled.
+	Disks []ServerDiskSpecification
+
+	// Should disks that are not listed in Disks be removed from the server?
+	//
+	// If false, planning fails when the server has a disk that is not listed in Disks.
+	// The OS disk (SCSI unit 0) is never removed.
+	RemoveUnlistedDisks bool
+
+	// The desired additional network adapters (the primary network adapter cannot be reconciled).
+	//
+	// If nil, network adapters are not reconciled; if empty, all additional network adapters will be removed.
+	AdditionalNetworkAdapters []ServerNetworkAdapterSpecification
+
+	// Should the server be running?
+	Started *bool
+}
+
+// ServerDiskSpecification represents the desired configuration for a server disk.
+type ServerDiskSpecification struct {
+	// The disk's SCSI unit Id.
+	SCSIUnitID int
+
+	// The disk size, in gigabytes.
+	SizeGB int
+
+	// The disk speed (ServerDiskSpeedStandard, ServerDiskSpeedHighPerformance, or ServerDiskSpeedEconomy).
+	Speed string
+}
+
+// ServerNetworkAdapterSpecification represents the desired configuration for a server network adapter.
+//
+// Exactly one of VLANID / PrivateIPv4Address must be specified.
+type ServerNetworkAdapterSpecification struct {
+	// The Id of the VLAN that the network adapter should be attached to.
+	VLANID string
+
+	// The network adapter's private IPv4 address.
+	PrivateIPv4Address string
+}
+
+// ServerReconciliationStep represents a single operation in a ServerReconciliationPlan.
+type ServerReconciliationStep struct {
+	// The action performed by the step (e.g. ServerReconciliationActionAddDisk).
+	Action string
+
+	// A human-readable description of the step.
+	Description string
+
+	// Does the step require the server to be shut down?
+	RequiresPowerCycle bool
+
+	// The desired CPU count (for ServerReconciliationActionReconfigure).
+	CPUCount *int
+
+	// The desired CPU cores per socket (for ServerReconciliationActionReconfigure).
+	CPUCoresPerSocket *int
+
+	// The desired CPU speed (for ServerReconciliationActionReconfigure).
+	CPUSpeed *string
+
+	// The desired memory size, in gigabytes (for ServerReconciliationActionReconfigure).
+	MemoryGB *int
+
+	// The Id of the target disk (for ServerReconciliationActionResizeDisk, ServerReconciliationActionChangeDiskSpeed, and ServerReconciliationActionRemoveDisk).
+	DiskID string
+
+	// The disk's SCSI unit Id (for ServerReconciliationActionAddDisk and ServerReconciliationActionResizeDisk).
+	SCSIUnitID int
+
+	// The disk size, in gigabytes (for ServerReconciliationActionAddDisk and ServerReconciliationActionResizeDisk).
+	SizeGB int
+
+	// The disk speed (for ServerReconciliationActionAddDisk and ServerReconciliationActionChangeDiskSpeed).
+	Speed string
+
+	// The Id of the target network adapter (for ServerReconciliationActionRemoveNetworkAdapter).
+	NetworkAdapterID string
+
+	// The Id of the VLAN to attach the network adapter to (for ServerReconciliationActionAddNetworkAdapter).
+	VLANID string
+
+	// The network adapter's private IPv4 address (for ServerReconciliationActionAddNetworkAdapter).
+	PrivateIPv4Address string
+}
+
+// ServerReconciliationPlan represents an ordered list of operations that, when applied, will bring a server into line with a ServerSpecification.
+type ServerReconciliationPlan struct {
+	// The Id of the target server.
+	ServerID string
+
+	// The name of the target server.
+	ServerName string
+
+	// The steps in the plan (in the order that they will be applied).
+	Steps []ServerReconciliationStep
+}
+
+// IsEmpty determines whether the plan contains no steps (i.e. the server already matches its specification).
+func (plan *ServerReconciliationPlan) IsEmpty() bool {
+	return len(plan.Steps) == 0
+}
+
+// RequiresPowerCycle determines whether any of the plan's steps require the server to be shut down.
+func (plan *ServerReconciliationPlan) RequiresPowerCycle() bool {
+	for _, step := range plan.Steps {
+		if step.RequiresPowerCycle {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ServerReconciliationError is an error representing the failure of a step in a ServerReconciliationPlan.
+type ServerReconciliationError struct {
+	// The plan being applied.
+	Plan *ServerReconciliationPlan
+
+	// The steps that were successfully completed before the failure.
+	CompletedSteps []ServerReconciliationStep
+
+	// The step that failed.
+	FailedStep ServerReconciliationStep
+
+	// The error that caused the step to fail.
+	Cause error
+}
+
+// Error returns the error message associated with the ServerReconciliationError.
+func (reconciliationError *ServerReconciliationError) Error() string {
+	completedSteps := make([]string, len(reconciliationError.CompletedSteps))
+	for index, step := range reconciliationError.CompletedSteps {
+		completedSteps[index] = step.Description
+	}
+	if len(completedSteps) == 0 {
+		completedSteps = append(completedSteps, "none")
+	}
+
+	return fmt.Sprintf("Reconciliation of server '%s' failed at step %d of %d (%s): %s (completed steps: %s)",
+		reconciliationError.Plan.ServerName,
+		len(reconciliationError.CompletedSteps)+1,
+		len(reconciliationError.Plan.Steps),
+		reconciliationError.FailedStep.Description,
+		reconciliationError.Cause.Error(),
+		strings.Join(completedSteps, "; "),
+	)
+}
+
+var _ error = &ServerReconciliationError{}
+
+// PlanReconciliation compares the specification against the server's current configuration and creates a plan to reconcile any differences.
+//
+// Returns an error if the differences cannot be reconciled (e.g. a disk would need to shrink).
+func (spec *ServerSpecification) PlanReconciliation(server *Server) (*ServerReconciliationPlan, error) {
+	if server == nil {
+		return nil, fmt.Errorf("Cannot plan reconciliation (no server was supplied).")
+	}
+
+	var (
+		hotSteps  []ServerReconciliationStep
+		coldSteps []ServerReconciliationStep
+	)
+	addStep := func(step ServerReconciliationStep) {
+		if step.RequiresPowerCycle {
+			coldSteps = append(coldSteps, step)
+		} else {
+			hotSteps = append(hotSteps, step)
+		}
+	}
+
+	reconfigureStep, needsReconfigure := spec.planReconfiguration(server)
+	if needsReconfigure {
+		addStep(reconfigureStep)
+	}
+
+	diskSteps, err := spec.planDisks(server)
+	if err != nil {
+		return nil, err
+	}
+	for _, step := range diskSteps {
+		addStep(step)
+	}
+
+	networkAdapterSteps, err := spec.planNetworkAdapters(server)
+	if err != nil {
+		return nil, err
+	}
+	for _, step := range networkAdapterSteps {
+		addStep(step)
+	}
+
+	desiredStarted := server.Started
+	if spec.Started != nil {
+		desiredStarted = *spec.Started
+	}
+
+	plan := &ServerReconciliationPlan{
+		ServerID:   server.ID,
+		ServerName: server.Name,
+	}
+	isStarted := server.Started
+	if len(coldSteps) > 0 && isStarted {
+		plan.Steps = append(plan.Steps, newServerPowerStep(ServerReconciliationActionShutdown, server))
+		isStarted = false
+	}
+	plan.Steps = append(plan.Steps, coldSteps...)
+	plan.Steps = append(plan.Steps, hotSteps...)
+
+	if desiredStarted && !isStarted {
+		plan.Steps = append(plan.Steps, newServerPowerStep(ServerReconciliationActionStart, server))
+	} else if !desiredStarted && isStarted {
+		plan.Steps = append(plan.Steps, newServerPowerStep(ServerReconciliationActionShutdown, server))
+	}
+
+	return plan, nil
+}
+
+// Plan changes (if any) to the server's CPU and memory configuration.
+//
+// Any change to the CPU configuration (or a reduction in memory) requires the server to be shut down.
+func (spec *ServerSpecification) planReconfiguration(server *Server) (step ServerReconciliationStep, needsReconfigure bool) {
+	step = ServerReconciliationStep{
+		Action: ServerReconciliationActionReconfigure,
+	}
+
+	var changes []string
+	if spec.CPUCount != nil && *spec.CPUCount != server.CPU.Count {
+		step.CPUCount = spec.CPUCount
+		step.RequiresPowerCycle = true
+		changes = append(changes, fmt.Sprintf("CPU count %d -> %d", server.CPU.Count, *spec.CPUCount))
+	}
+	if spec.CPUCoresPerSocket != nil && *spec.CPUCoresPerSocket != server.CPU.CoresPerSocket {
+		step.CPUCoresPerSocket = spec.CPUCoresPerSocket
+		step.RequiresPowerCycle = true
+		changes = append(changes, fmt.Sprintf("CPU cores per socket %d -> %d", server.CPU.CoresPerSocket, *spec.CPUCoresPerSocket))
+	}
+	if spec.CPUSpeed != nil && *spec.CPUSpeed != server.CPU.Speed {
+		step.CPUSpeed = spec.CPUSpeed
+		step.RequiresPowerCycle = true
+		changes = append(changes, fmt.Sprintf("CPU speed %s -> %s", server.CPU.Speed, *spec.CPUSpeed))
+	}
+	if spec.MemoryGB != nil && *spec.MemoryGB != server.MemoryGB {
+		step.MemoryGB = spec.MemoryGB
+		if *spec.MemoryGB < server.MemoryGB {
+			step.RequiresPowerCycle = true
+		}
+		changes = append(changes, fmt.Sprintf("memory %dGB -> %dGB", server.MemoryGB, *spec.MemoryGB))
+	}
+
+	if len(changes) == 0 {
+		return step, false
+	}
+	step.Description = fmt.Sprintf("Reconfigure server '%s' (%s)", server.Name, strings.Join(changes, ", "))
+
+	return step, true
+}
+
+// Plan changes (if any) to the server's disks.
+func (spec *ServerSpecification) planDisks(server *Server) (steps []ServerReconciliationStep, err error) {
+	if spec.Disks == nil {
+		return
+	}
+
+	existingDisks := make(map[int]VirtualMachineDisk)
+	for _, disk := range server.Disks {
+		existingDisks[disk.SCSIUnitID] = disk
+	}
+
+	desiredSCSIUnitIDs := make(map[int]bool)
+	for _, desiredDisk := range spec.Disks {
+		desiredSCSIUnitIDs[desiredDisk.SCSIUnitID] = true
+
+		existingDisk, exists := existingDisks[desiredDisk.SCSIUnitID]
+		if !exists {
+			steps = append(steps, ServerReconciliationStep{
+				Action:      ServerReconciliationActionAddDisk,
+				Description: fmt.Sprintf("Add %dGB %s disk with SCSI unit Id %d to server '%s'", desiredDisk.SizeGB, desiredDisk.Speed, desiredDisk.SCSIUnitID, server.Name),
+				SCSIUnitID:  desiredDisk.SCSIUnitID,
+				SizeGB:      desiredDisk.SizeGB,
+				Speed:       desiredDisk.Speed,
+			})
+
+			continue
+		}
+
+		if desiredDisk.SizeGB < existingDisk.SizeGB {
+			return nil, fmt.Errorf("Cannot reduce size of disk with SCSI unit Id %d on server '%s' from %dGB to %dGB (disks can only be expanded).",
+				desiredDisk.SCSIUnitID, server.Name, existingDisk.SizeGB, desiredDisk.SizeGB,
+			)
+		}
+		if desiredDisk.SizeGB > existingDisk.SizeGB {
+			if existingDisk.ID == nil {
+				return nil, fmt.Errorf("Cannot resize disk with SCSI unit Id %d on server '%s' (disk Id is not available).", desiredDisk.SCSIUnitID, server.Name)
+			}
+
+			steps = append(steps, ServerReconciliationStep{
+				Action:      ServerReconciliationActionResizeDisk,
+				Description: fmt.Sprintf("Resize disk with SCSI unit Id %d on server '%s' from %dGB to %dGB", desiredDisk.SCSIUnitID, server.Name, existingDisk.SizeGB, desiredDisk.SizeGB),
+				DiskID:      *existingDisk.ID,
+				SCSIUnitID:  desiredDisk.SCSIUnitID,
+				SizeGB:      desiredDisk.SizeGB,
+			})
+		}
+		if len(desiredDisk.Speed) > 0 && desiredDisk.Speed != existingDisk.Speed {
+			if existingDisk.ID == nil {
+				return nil, fmt.Errorf("Cannot change speed of disk with SCSI unit Id %d on server '%s' (disk Id is not available).", desiredDisk.SCSIUnitID, server.Name)
+			}
+
+			steps = append(steps, ServerReconciliationStep{
+				Action:      ServerReconciliationActionChangeDiskSpeed,
+				Description: fmt.Sprintf("Change speed of disk with SCSI unit Id %d on server '%s' from %s to %s", desiredDisk.SCSIUnitID, server.Name, existingDisk.Speed, desiredDisk.Speed),
+				DiskID:      *existingDisk.ID,
+				SCSIUnitID:  desiredDisk.SCSIUnitID,
+				Speed:       desiredDisk.Speed,
+			})
+		}
+	}
+
+	// Disks are removed before any other disk changes are made (note that steps requiring a power cycle, such as CPU changes, are still applied first).
+	var removalSteps []ServerReconciliationStep
+	for _, existingDisk := range server.Disks {
+		if desiredSCSIUnitIDs[existingDisk.SCSIUnitID] {
+			continue
+		}
+		if existingDisk.SCSIUnitID == 0 {
+			return nil, fmt.Errorf("Cannot remove disk with SCSI unit Id 0 from server '%s' (the OS disk cannot be removed).", server.Name)
+		}
+		if !spec.RemoveUnlistedDisks {
+			return nil, fmt.Errorf("Cannot remove disk with SCSI unit Id %d from server '%s' (RemoveUnlistedDisks is not set).", existingDisk.SCSIUnitID, server.Name)
+		}
+		if existingDisk.ID == nil {
+			return nil, fmt.Errorf("Cannot remove disk with SCSI unit Id %d from server '%s' (disk Id is not available).", existingDisk.SCSIUnitID, server.Name)
+		}
+
+		removalSteps = append(removalSteps, ServerReconciliationStep{
+			Action:      ServerReconciliationActionRemoveDisk,
+			Description: fmt.Sprintf("Remove %dGB disk with SCSI unit Id %d from server '%s'", existingDisk.SizeGB, existingDisk.SCSIUnitID, server.Name),
+			DiskID:      *existingDisk.ID,
+			SCSIUnitID:  existingDisk.SCSIUnitID,
+			SizeGB:      existingDisk.SizeGB,
+		})
+	}
+	steps = append(removalSteps, steps...)
+
+	return
+}
+
+// Plan changes (if any) to the server's additional network adapters.
+//
+// Network adapters are removed before new ones are added (so that their IP addresses become available).
+func (spec *ServerSpecification) planNetworkAdapters(server *Server) (steps []ServerReconciliationStep, err error) {
+	if spec.AdditionalNetworkAdapters == nil {
+		return
+	}
+
+	existingAdapters := server.Network.AdditionalNetworkAdapters
+	matchedAdapters := make([]bool, len(existingAdapters))
+
+	var unmatchedSpecs []ServerNetworkAdapterSpecification
+	for _, adapterSpec := range spec.AdditionalNetworkAdapters {
+		if len(adapterSpec.VLANID) == 0 && len(adapterSpec.PrivateIPv4Address) == 0 {
+			return nil, fmt.Errorf("Invalid network adapter specification for server '%s' (must specify either VLAN Id or private IPv4 address).", server.Name)
+		}
+
+		matchIndex := -1
+		for index, adapter := range existingAdapters {
+			if matchedAdapters[index] {
+				continue
+			}
+
+			if len(adapterSpec.PrivateIPv4Address) > 0 {
+				if adapter.PrivateIPv4Address != nil && *adapter.PrivateIPv4Address == adapterSpec.PrivateIPv4Address {
+					matchIndex = index
+
+					break
+				}
+			} else if adapter.VLANID != nil && *adapter.VLANID == adapterSpec.VLANID {
+				matchIndex = index
+
+				break
+			}
+		}
+
+		if matchIndex == -1 {
+			unmatchedSpecs = append(unmatchedSpecs, adapterSpec)
+		} else {
+			matchedAdapters[matchIndex] = true
+		}
+	}
+
+	for index, adapter := range existingAdapters {
+		if matchedAdapters[index] {
+			continue
+		}
+
+		steps = append(steps, ServerReconciliationStep{
+			Action:             ServerReconciliationActionRemoveNetworkAdapter,
+			Description:        fmt.Sprintf("Remove network adapter '%s' (%s) from server '%s'", adapter.GetID(), describeNetworkAdapter(adapter.VLANID, adapter.PrivateIPv4Address), server.Name),
+			RequiresPowerCycle: true,
+			NetworkAdapterID:   adapter.GetID(),
+		})
+	}
+
+	for _, adapterSpec := range unmatchedSpecs {
+		steps = append(steps, ServerReconciliationStep{
+			Action:             ServerReconciliationActionAddNetworkAdapter,
+			Description:        fmt.Sprintf("Add network adapter (%s) to server '%s'", describeNetworkAdapter(&adapterSpec.VLANID, &adapterSpec.PrivateIPv4Address), server.Name),
+			VLANID:             adapterSpec.VLANID,
+			PrivateIPv4Address: adapterSpec.PrivateIPv4Address,
+		})
+	}
+
+	return
+}
+
+// Create a plan step that starts or shuts down a server.
+func newServerPowerStep(action string, server *Server) ServerReconciliationStep {
+	description := fmt.Sprintf("Start server '%s'", server.Name)
+	if action == ServerReconciliationActionShutdown {
+		description = fmt.Sprintf("Shut down server '%s'", server.Name)
+	}
+
+	return ServerReconciliationStep{
+		Action:      action,
+		Description: description,
+	}
+}
+
+// Describe a network adapter by its VLAN Id and / or private IPv4 address.
+func describeNetworkAdapter(vlanID *string, privateIPv4Address *string) string {
+	var details []string
+	if vlanID != nil && len(*vlanID) > 0 {
+		details = append(details, fmt.Sprintf("VLAN '%s'", *vlanID))
+	}
+	if privateIPv4Address != nil && len(*privateIPv4Address) > 0 {
+		details = append(details, fmt.Sprintf("IPv4 address '%s'", *privateIPv4Address))
+	}
+
+	return strings.Join(details, ", ")
+}
+
+// PlanServerReconciliation retrieves the specified server and creates a plan to reconcile it with the specification.
+func (client *Client) PlanServerReconciliation(serverID string, spec ServerSpecification) (*ServerReconciliationPlan, error) {
+	server, err := client.GetServer(serverID)
+	if err != nil {
+		return nil, err
+	}
+	if server == nil {
+		return nil, fmt.Errorf("No server was found with Id '%s'.", serverID)
+	}
+
+	return spec.PlanReconciliation(server)
+}
+
+// ApplyServerReconciliationPlan applies each step in the plan (in order), waiting for the server to return to the normal state between steps.
+// stepTimeout is the maximum length of time to wait for each step to complete.
+//
+// Stops at the first step that fails, returning a *ServerReconciliationError describing the failed step (and which steps had already completed).
+func (client *Client) ApplyServerReconciliationPlan(plan *ServerReconciliationPlan, stepTimeout time.Duration) error {
+	var completedSteps []ServerReconciliationStep
+	for _, step := range plan.Steps {
+		log.Printf("%s...", step.Description)
+
+		err := client.applyServerReconciliationStep(plan.ServerID, step, stepTimeout)
+		if err != nil {
+			return &ServerReconciliationError{
+				Plan:           plan,
+				CompletedSteps: completedSteps,
+				FailedStep:     step,
+				Cause:          err,
+			}
+		}
+
+		completedSteps = append(completedSteps, step)
+	}
+
+	return nil
+}
+
+// Apply a single reconciliation step and wait for it to complete.
+func (client *Client) applyServerReconciliationStep(serverID string, step ServerReconciliationStep, timeout time.Duration) error {
+	var err error
+	switch step.Action {
+	case ServerReconciliationActionReconfigure:
+		err = client.ReconfigureServer(serverID, step.MemoryGB, step.CPUCount, step.CPUCoresPerSocket, step.CPUSpeed)
+
+	case ServerReconciliationActionAddDisk:
+		_, err = client.AddDiskToServer(serverID, step.SCSIUnitID, step.SizeGB, step.Speed)
+
+	case ServerReconciliationActionResizeDisk:
+		err = client.ResizeServerDisk(step.DiskID, step.SizeGB)
+
+	case ServerReconciliationActionChangeDiskSpeed:
+		err = client.ChangeServerDiskSpeed(step.DiskID, step.Speed)
+
+	case ServerReconciliationActionRemoveDisk:
+		err = client.RemoveDiskFromServer(step.DiskID)
+
+	case ServerReconciliationActionAddNetworkAdapter:
+		_, err = client.AddNicToServer(serverID, step.PrivateIPv4Address, step.VLANID)
+
+	case ServerReconciliationActionRemoveNetworkAdapter:
+		err = client.RemoveNicFromServer(step.NetworkAdapterID)
+
+	case ServerReconciliationActionStart:
+		err = client.StartServer(serverID)
+
+	case ServerReconciliationActionShutdown:
+		err = client.ShutdownServer(serverID)
+
+	default:
+		err = fmt.Errorf("Unrecognised server reconciliation action '%s'.", step.Action)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = client.WaitForChange(ResourceTypeServer, serverID, step.Description, timeout)
+
+	return err
+}
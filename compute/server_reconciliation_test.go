@@ -0,0 +1,239 @@
+package compute
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// Plan server reconciliation (no changes required).
+func TestServerSpecification_PlanReconciliation_NoChanges(test *testing.T) {
+	expect := expect(test)
+
+	server := createServerForReconciliationTest()
+	spec := ServerSpecification{
+		CPUCount: intToPtr(2),
+		MemoryGB: intToPtr(4),
+		Disks: []ServerDiskSpecification{
+			ServerDiskSpecification{SCSIUnitID: 0, SizeGB: 50, Speed: ServerDiskSpeedStandard},
+		},
+		AdditionalNetworkAdapters: []ServerNetworkAdapterSpecification{
+			ServerNetworkAdapterSpecification{VLANID: "e0b4d43c-c648-11e4-b33a-72802a5322b2"},
+		},
+	}
+
+	plan, err := spec.PlanReconciliation(server)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	expect.IsTrue("Plan.IsEmpty", plan.IsEmpty())
+}
+
+// Plan server reconciliation (changes requiring a power cycle).
+func TestServerSpecification_PlanReconciliation_PowerCycle(test *testing.T) {
+	expect := expect(test)
+
+	server := createServerForReconciliationTest()
+	spec := ServerSpecification{
+		CPUCount: intToPtr(4),
+		MemoryGB: intToPtr(8),
+		Disks: []ServerDiskSpecification{
+			ServerDiskSpecification{SCSIUnitID: 0, SizeGB: 60, Speed: ServerDiskSpeedStandard},
+			ServerDiskSpecification{SCSIUnitID: 1, SizeGB: 20, Speed: ServerDiskSpeedEconomy},
+		},
+		AdditionalNetworkAdapters: []ServerNetworkAdapterSpecification{
+			ServerNetworkAdapterSpecification{PrivateIPv4Address: "10.0.5.20"},
+		},
+	}
+
+	plan, err := spec.PlanReconciliation(server)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	expect.IsTrue("Plan.RequiresPowerCycle", plan.RequiresPowerCycle())
+	expect.EqualsInt("Plan.Steps size", 7, len(plan.Steps))
+
+	expectedActions := []string{
+		ServerReconciliationActionShutdown,
+		ServerReconciliationActionReconfigure,
+		ServerReconciliationActionRemoveNetworkAdapter,
+		ServerReconciliationActionResizeDisk,
+		ServerReconciliationActionAddDisk,
+		ServerReconciliationActionAddNetworkAdapter,
+		ServerReconciliationActionStart,
+	}
+	for index, expectedAction := range expectedActions {
+		expect.EqualsString("Plan.Steps.Action", expectedAction, plan.Steps[index].Action)
+	}
+
+	expect.EqualsString("Plan.Steps[2].NetworkAdapterID", "a6d6a7bb-5d3c-4a74-a42b-6c5e4d3b2a1f", plan.Steps[2].NetworkAdapterID)
+	expect.EqualsString("Plan.Steps[3].DiskID", "c2e1f199-116e-4dbc-9960-68720b832b0a", plan.Steps[3].DiskID)
+	expect.EqualsInt("Plan.Steps[3].SizeGB", 60, plan.Steps[3].SizeGB)
+}
+
+// Plan server reconciliation (disk cannot be shrunk).
+func TestServerSpecification_PlanReconciliation_ShrinkDisk(test *testing.T) {
+	server := createServerForReconciliationTest()
+	spec := ServerSpecification{
+		Disks: []ServerDiskSpecification{
+			ServerDiskSpecification{SCSIUnitID: 0, SizeGB: 40},
+		},
+	}
+
+	_, err := spec.PlanReconciliation(server)
+	if err == nil {
+		test.Fatal("Expected an error when shrinking a disk.")
+	}
+}
+
+// Removing a disk and changing the speed of a disk.
+func TestServerSpecification_PlanReconciliation_RemoveDiskAndChangeSpeed(test *testing.T) {
+	expect := expect(test)
+
+	server := createServerForReconciliationTest()
+	server.Disks = append(server.Disks, VirtualMachineDisk{
+		ID:         stringToPtr("0f1d6a3e-2b7c-4c2e-a5a1-3f9d8d6e7b41"),
+		SCSIUnitID: 1,
+		SizeGB:     20,
+		Speed:      ServerDiskSpeedStandard,
+	})
+	spec := ServerSpecification{
+		Disks: []ServerDiskSpecification{
+			ServerDiskSpecification{SCSIUnitID: 0, SizeGB: 50, Speed: ServerDiskSpeedHighPerformance},
+		},
+		RemoveUnlistedDisks: true,
+	}
+
+	plan, err := spec.PlanReconciliation(server)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	expect.EqualsInt("Plan.Steps.Length", 2, len(plan.Steps))
+	expect.EqualsString("Plan.Steps[0].Action", ServerReconciliationActionRemoveDisk, plan.Steps[0].Action)
+	expect.EqualsString("Plan.Steps[0].DiskID", "0f1d6a3e-2b7c-4c2e-a5a1-3f9d8d6e7b41", plan.Steps[0].DiskID)
+	expect.EqualsString("Plan.Steps[1].Action", ServerReconciliationActionChangeDiskSpeed, plan.Steps[1].Action)
+	expect.EqualsString("Plan.Steps[1].DiskID", "c2e1f199-116e-4dbc-9960-68720b832b0a", plan.Steps[1].DiskID)
+	expect.EqualsString("Plan.Steps[1].Speed", ServerDiskSpeedHighPerformance, plan.Steps[1].Speed)
+	expect.IsFalse("Plan.RequiresPowerCycle", plan.RequiresPowerCycle())
+}
+
+// Plan server reconciliation (disk not listed, and RemoveUnlistedDisks not set).
+func TestServerSpecification_PlanReconciliation_UnlistedDisk(test *testing.T) {
+	expect := expect(test)
+
+	server := createServerForReconciliationTest()
+	server.Disks = append(server.Disks, VirtualMachineDisk{
+		ID:         stringToPtr("0f1d6a3e-2b7c-4c2e-a5a1-3f9d8d6e7b41"),
+		SCSIUnitID: 1,
+		SizeGB:     20,
+		Speed:      ServerDiskSpeedStandard,
+	})
+	spec := ServerSpecification{
+		Disks: []ServerDiskSpecification{
+			ServerDiskSpecification{SCSIUnitID: 0, SizeGB: 50, Speed: ServerDiskSpeedStandard},
+		},
+	}
+
+	_, err := spec.PlanReconciliation(server)
+	expect.NotNil("PlanReconciliation error", err)
+	expect.IsTrue("Error message mentions RemoveUnlistedDisks", strings.Contains(err.Error(), "RemoveUnlistedDisks"))
+}
+
+// Plan server reconciliation (OS disk not listed; it must never be removed).
+func TestServerSpecification_PlanReconciliation_OSDiskNotListed(test *testing.T) {
+	expect := expect(test)
+
+	server := createServerForReconciliationTest()
+	spec := ServerSpecification{
+		Disks: []ServerDiskSpecification{
+			ServerDiskSpecification{SCSIUnitID: 1, SizeGB: 20, Speed: ServerDiskSpeedStandard},
+		},
+		RemoveUnlistedDisks: true,
+	}
+
+	plan, err := spec.PlanReconciliation(server)
+	expect.NotNil("PlanReconciliation error", err)
+	expect.IsTrue("Error message mentions OS disk", strings.Contains(err.Error(), "OS disk"))
+	expect.IsTrue("Plan is nil", plan == nil)
+}
+
+// Apply server reconciliation plan (first step fails).
+func TestClient_ApplyServerReconciliationPlan_Failure(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			plan := &ServerReconciliationPlan{
+				ServerID:   "5a32d6e4-9707-4813-a269-56ab4d989f4d",
+				ServerName: "Production Web Server",
+				Steps: []ServerReconciliationStep{
+					newServerPowerStep(ServerReconciliationActionShutdown, createServerForReconciliationTest()),
+					newServerPowerStep(ServerReconciliationActionStart, createServerForReconciliationTest()),
+				},
+			}
+
+			err := client.ApplyServerReconciliationPlan(plan, 1*time.Minute)
+			expect.NotNil("ApplyServerReconciliationPlan error", err)
+
+			reconciliationError, ok := err.(*ServerReconciliationError)
+			expect.IsTrue("Error is ServerReconciliationError", ok)
+			expect.EqualsInt("ServerReconciliationError.CompletedSteps size", 0, len(reconciliationError.CompletedSteps))
+			expect.EqualsString("ServerReconciliationError.FailedStep.Action", ServerReconciliationActionShutdown, reconciliationError.FailedStep.Action)
+			expect.IsTrue("Error message mentions step", strings.Contains(err.Error(), "step 1 of 2"))
+		},
+		Respond: testRespond(400, resourceBusyTestResponse),
+	})
+}
+
+func createServerForReconciliationTest() *Server {
+	return &Server{
+		ID:       "5a32d6e4-9707-4813-a269-56ab4d989f4d",
+		Name:     "Production Web Server",
+		CPU:      VirtualMachineCPU{Count: 2, CoresPerSocket: 1, Speed: ServerCPUSpeedStandard},
+		MemoryGB: 4,
+		Disks: []VirtualMachineDisk{
+			VirtualMachineDisk{
+				ID:         stringToPtr("c2e1f199-116e-4dbc-9960-68720b832b0a"),
+				SCSIUnitID: 0,
+				SizeGB:     50,
+				Speed:      ServerDiskSpeedStandard,
+			},
+		},
+		Network: VirtualMachineNetwork{
+			PrimaryAdapter: VirtualMachineNetworkAdapter{
+				ID:                 stringToPtr("5e869800-df7b-4626-bcbf-8643b8be11fd"),
+				VLANID:             stringToPtr("bc529e20-dc6f-42ba-be20-0ffe44d1993f"),
+				PrivateIPv4Address: stringToPtr("10.0.4.8"),
+			},
+			AdditionalNetworkAdapters: []VirtualMachineNetworkAdapter{
+				VirtualMachineNetworkAdapter{
+					ID:                 stringToPtr("a6d6a7bb-5d3c-4a74-a42b-6c5e4d3b2a1f"),
+					VLANID:             stringToPtr("e0b4d43c-c648-11e4-b33a-72802a5322b2"),
+					PrivateIPv4Address: stringToPtr("172.16.0.14"),
+				},
+			},
+		},
+		State:    ResourceStatusNormal,
+		Deployed: true,
+		Started:  true,
+	}
+}
+
+/*
+ * Test responses.
+ */
+
+const resourceBusyTestResponse = `
+	{
+		"operation": "SHUTDOWN_SERVER",
+		"responseCode": "RESOURCE_BUSY",
+		"message": "Server 'Production Web Server' is currently busy.",
+		"info": [],
+		"warning": [],
+		"error": [],
+		"requestId": "na9_20160321T074626030-0400_7e9fffe7-190b-46f2-9107-9d52fe57d0ad"
+	}
+`
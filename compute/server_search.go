@@ -0,0 +1,195 @@
+package compute
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ServerQuery represents criteria used to find servers.
+//
+// All criteria are optional; empty / nil criteria are ignored.
+type ServerQuery struct {
+	// The server name (exact match).
+	Name string
+
+	// A pattern for the server name (e.g. "web-*"); "*" matches any sequence of characters.
+	NamePattern string
+
+	// The Id of the datacenter where the server is located.
+	DatacenterID string
+
+	// The Id of the network domain that the server is attached to.
+	NetworkDomainID string
+
+	// The Id of a VLAN that the server is attached to.
+	VLANID string
+
+	// A private IPv4 address assigned to one of the server's network adapters.
+	PrivateIPv4Address string
+
+	// An IPv6 address assigned to one of the server's network adapters.
+	PrivateIPv6Address string
+
+	// The Id of the image from which the server was deployed.
+	SourceImageID string
+
+	// The server's state (e.g. ResourceStatusNormal).
+	State string
+
+	// Is the server started?
+	Started *bool
+
+	// Is the server deployed?
+	Deployed *bool
+
+	// A tag applied to the server (if the tag's value is empty, any value will match).
+	//
+	// Tags cannot be filtered by the CloudControl API, so this criterion is only honoured by SearchServers.
+	Tag *Tag
+}
+
+// Convert the query to URL query parameters.
+func (query *ServerQuery) toQueryParameters() string {
+	if query == nil {
+		return ""
+	}
+
+	parameters := url.Values{}
+	addParameter := func(name string, value string) {
+		if len(value) > 0 {
+			parameters.Set(name, value)
+		}
+	}
+	addFlagParameter := func(name string, value *bool) {
+		if value != nil {
+			parameters.Set(name, strconv.FormatBool(*value))
+		}
+	}
+
+	addParameter("name", query.Name)
+	addParameter("name.LIKE", query.NamePattern)
+	addParameter("datacenterId", query.DatacenterID)
+	addParameter("networkDomainId", query.NetworkDomainID)
+	addParameter("vlanId", query.VLANID)
+	addParameter("privateIpv4", query.PrivateIPv4Address)
+	addParameter("ipv6", query.PrivateIPv6Address)
+	addParameter("sourceImageId", query.SourceImageID)
+	addParameter("state", query.State)
+	addFlagParameter("started", query.Started)
+	addFlagParameter("deployed", query.Deployed)
+
+	return parameters.Encode()
+}
+
+// ListServers retrieves a page of servers (across the entire organisation) that match the specified query.
+// If query is nil, all servers are listed.
+func (client *Client) ListServers(query *ServerQuery, paging *Paging) (servers *Servers, err error) {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return nil, err
+	}
+
+	requestURI := fmt.Sprintf("%s/server/server?%s", organizationID, paging.EnsurePaging().toQueryParameters())
+	queryParameters := query.toQueryParameters()
+	if len(queryParameters) > 0 {
+		requestURI += "&" + queryParameters
+	}
+
+	request, err := client.newRequestV22(requestURI, http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode != http.StatusOK {
+		var apiResponse *APIResponseV2
+
+		apiResponse, err = readAPIResponseAsJSON(responseBody, statusCode)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, apiResponse.ToError("Request to list servers failed with status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
+	}
+
+	servers = &Servers{}
+	err = json.Unmarshal(responseBody, servers)
+
+	return servers, err
+}
+
+// ForEachServer calls the specified action for each server (across all pages of results) that matches the specified query.
+// Iteration stops if the action returns false or an error.
+func (client *Client) ForEachServer(query *ServerQuery, action func(server *Server) (bool, error)) error {
+	paging := DefaultPaging()
+	for {
+		page, err := client.ListServers(query, paging)
+		if err != nil {
+			return err
+		}
+
+		for index := range page.Items {
+			shouldContinue, err := action(&page.Items[index])
+			if err != nil {
+				return err
+			}
+			if !shouldContinue {
+				return nil
+			}
+		}
+
+		if page.IsLastPage() {
+			return nil
+		}
+
+		paging.Next()
+	}
+}
+
+// ListAllServers retrieves all servers (across all pages of results) that match the specified query.
+func (client *Client) ListAllServers(query *ServerQuery) (servers []Server, err error) {
+	err = client.ForEachServer(query, func(server *Server) (bool, error) {
+		servers = append(servers, *server)
+
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return servers, nil
+}
+
+// SearchServers retrieves all servers that match the specified query, including criteria (such as Tag) that must be evaluated client-side.
+func (client *Client) SearchServers(query ServerQuery) (servers []Server, err error) {
+	var taggedServerIDs map[string]bool
+	if query.Tag != nil {
+		taggedServerIDs, err = client.listAssetIDsWithTag(AssetTypeServer, *query.Tag)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(taggedServerIDs) == 0 {
+			return nil, nil
+		}
+	}
+
+	err = client.ForEachServer(&query, func(server *Server) (bool, error) {
+		if taggedServerIDs == nil || taggedServerIDs[server.ID] {
+			servers = append(servers, *server)
+		}
+
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return servers, nil
+}
@@ -0,0 +1,114 @@
+package compute
+
+import (
+	"net/http"
+	"testing"
+)
+
+// List servers matching a query (successful).
+func TestClient_ListServers_Success(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			servers, err := client.ListServers(&ServerQuery{
+				NamePattern:   "Production*",
+				DatacenterID:  "AU9",
+				SourceImageID: "02250336-de2b-4e99-ab96-78511b7f8f4b",
+				Started:       boolToPtr(false),
+			}, nil)
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.NotNil("Servers", servers)
+			expect.EqualsInt("Servers.Items.Length", 2, len(servers.Items))
+			expect.IsTrue("Servers.IsLastPage", servers.IsLastPage())
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			expect.EqualsString("Request.URL.Path", "/caas/2.2/my-organization-id/server/server", request.URL.Path)
+
+			query := request.URL.Query()
+			expect.EqualsString("Query.pageNumber", "1", query.Get("pageNumber"))
+			expect.EqualsString("Query.pageSize", "50", query.Get("pageSize"))
+			expect.EqualsString("Query.name.LIKE", "Production*", query.Get("name.LIKE"))
+			expect.EqualsString("Query.datacenterId", "AU9", query.Get("datacenterId"))
+			expect.EqualsString("Query.sourceImageId", "02250336-de2b-4e99-ab96-78511b7f8f4b", query.Get("sourceImageId"))
+			expect.EqualsString("Query.started", "false", query.Get("started"))
+			expect.EqualsString("Query.deployed", "", query.Get("deployed"))
+
+			return http.StatusOK, findNetworkAdapterListServersTestResponse
+		},
+	})
+}
+
+// Search servers by tag (successful).
+func TestClient_SearchServers_Tag(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			servers, err := client.SearchServers(ServerQuery{
+				State: ResourceStatusNormal,
+				Tag: &Tag{
+					Name:  "role",
+					Value: "database",
+				},
+			})
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.EqualsInt("Servers.Length", 1, len(servers))
+			expect.EqualsString("Servers[0].Name", "Production Database Server", servers[0].Name)
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			query := request.URL.Query()
+
+			switch request.URL.Path {
+			case "/caas/2.2/my-organization-id/tag/tag":
+				expect.EqualsString("Query.assetType", AssetTypeServer, query.Get("assetType"))
+				expect.EqualsString("Query.tagKeyName", "role", query.Get("tagKeyName"))
+				expect.EqualsString("Query.value", "database", query.Get("value"))
+
+				return http.StatusOK, searchServersListTagsTestResponse
+
+			case "/caas/2.2/my-organization-id/server/server":
+				expect.EqualsString("Query.state", ResourceStatusNormal, query.Get("state"))
+
+				return http.StatusOK, findNetworkAdapterListServersTestResponse
+
+			default:
+				test.Fatalf("Unexpected request to '%s'.", request.URL.Path)
+
+				return http.StatusNotFound, ""
+			}
+		},
+	})
+}
+
+/*
+ * Test responses.
+ */
+
+const searchServersListTagsTestResponse = `
+	{
+		"tag": [
+			{
+				"assetType": "SERVER",
+				"assetId": "0f1d6a3e-2b7c-4c2e-a5a1-3f9d8d6e7b41",
+				"assetName": "Production Database Server",
+				"datacenterId": "AU9",
+				"tagKeyId": "d6a5c1b3-4a4b-4c9e-8c1b-7f0d3e2a1b0c",
+				"tagKeyName": "role",
+				"value": "database",
+				"valueRequired": false,
+				"displayOnReport": true
+			}
+		],
+		"pageNumber": 1,
+		"pageCount": 1,
+		"totalCount": 1,
+		"pageSize": 50
+	}
+`
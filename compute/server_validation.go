@@ -0,0 +1,314 @@
+package compute
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	// The minimum length for a server's administrator password.
+	minAdministratorPasswordLength = 8
+
+	// The maximum length for a server's administrator password.
+	maxAdministratorPasswordLength = 64
+
+	// The number of character classes (upper-case, lower-case, digit, special) that a server's administrator password must contain.
+	minAdministratorPasswordCharacterClasses = 3
+
+	// The maximum number of CPUs that can be allocated to a server.
+	maxServerCPUCount = 32
+
+	// The highest SCSI unit Id that can be assigned to a server disk.
+	maxSCSIUnitID = 15
+
+	// The SCSI unit Id reserved for the SCSI controller itself.
+	reservedSCSIUnitID = 7
+)
+
+// ValidationError is an error representing one or more problems detected by client-side validation.
+type ValidationError struct {
+	// A description of the entity that failed validation.
+	Target string
+
+	// Messages describing each problem that was detected.
+	Messages []string
+}
+
+// Error returns the error message associated with the ValidationError.
+func (validationError *ValidationError) Error() string {
+	return fmt.Sprintf("%s is invalid: %s",
+		validationError.Target,
+		strings.Join(validationError.Messages, "; "),
+	)
+}
+
+var _ error = &ValidationError{}
+
+// addMessage adds a validation message to the ValidationError.
+func (validationError *ValidationError) addMessage(messageOrFormat string, formatArgs ...interface{}) {
+	validationError.Messages = append(validationError.Messages,
+		fmt.Sprintf(messageOrFormat, formatArgs...),
+	)
+}
+
+// toError returns the ValidationError, or nil if no problems were detected.
+func (validationError *ValidationError) toError() error {
+	if len(validationError.Messages) == 0 {
+		return nil
+	}
+
+	return validationError
+}
+
+// Validate performs client-side validation of the ServerDeploymentConfiguration.
+//
+// This catches common mistakes before calling DeployServer (which would otherwise fail with INVALID_INPUT_DATA).
+// Returns a *ValidationError if any problems are detected.
+func (config *ServerDeploymentConfiguration) Validate() error {
+	validationError := &ValidationError{
+		Target: fmt.Sprintf("Deployment configuration for server '%s'", config.Name),
+	}
+
+	if len(config.Name) == 0 {
+		validationError.addMessage("server name must be specified")
+	}
+	if len(config.ImageID) == 0 {
+		validationError.addMessage("image Id must be specified")
+	}
+
+	validateAdministratorPassword(config.AdministratorPassword, validationError)
+	validateServerCPU(config.CPU, validationError)
+	validateServerDisks(config.Disks, validationError)
+
+	validateServerNetworkAdapter("primary network adapter", config.Network.PrimaryAdapter, validationError)
+	for index, adapter := range config.Network.AdditionalNetworkAdapters {
+		validateServerNetworkAdapter(
+			fmt.Sprintf("additional network adapter %d", index+1),
+			adapter,
+			validationError,
+		)
+	}
+
+	return validationError.toError()
+}
+
+// ValidateServerDeploymentConfiguration performs client-side validation of a ServerDeploymentConfiguration.
+//
+// In addition to the checks performed by ServerDeploymentConfiguration.Validate, the VLANs in the target network domain are retrieved
+// to verify that each network adapter's private IPv4 address (if specified) falls within a VLAN's IPv4 range and is not reserved by that VLAN.
+//
+// Returns a *ValidationError if any problems are detected.
+func (client *Client) ValidateServerDeploymentConfiguration(config ServerDeploymentConfiguration) error {
+	err := config.Validate()
+	if err != nil {
+		return err
+	}
+
+	validationError := &ValidationError{
+		Target: fmt.Sprintf("Deployment configuration for server '%s'", config.Name),
+	}
+
+	adapters := append(
+		[]VirtualMachineNetworkAdapter{config.Network.PrimaryAdapter},
+		config.Network.AdditionalNetworkAdapters...,
+	)
+
+	var (
+		vlans       []VLAN
+		vlansLoaded bool
+	)
+	for index, adapter := range adapters {
+		if adapter.PrivateIPv4Address == nil || len(*adapter.PrivateIPv4Address) == 0 {
+			continue
+		}
+
+		if len(config.Network.NetworkDomainID) == 0 {
+			validationError.addMessage("network domain Id must be specified")
+
+			break
+		}
+
+		if !vlansLoaded {
+			vlans, err = client.listAllVLANs(config.Network.NetworkDomainID)
+			if err != nil {
+				return err
+			}
+			vlansLoaded = true
+		}
+
+		adapterDescription := "primary network adapter"
+		if index > 0 {
+			adapterDescription = fmt.Sprintf("additional network adapter %d", index)
+		}
+
+		address := *adapter.PrivateIPv4Address
+		vlan := findVLANForIPv4Address(vlans, address)
+		if vlan == nil {
+			validationError.addMessage("%s: private IPv4 address '%s' does not fall within the IPv4 range of any VLAN in network domain '%s'",
+				adapterDescription, address, config.Network.NetworkDomainID,
+			)
+
+			continue
+		}
+
+		problem := validateIPv4AddressForVLAN(address, vlan)
+		if problem != "" {
+			validationError.addMessage("%s: %s", adapterDescription, problem)
+		}
+	}
+
+	return validationError.toError()
+}
+
+// Validate the administrator password for a server.
+func validateAdministratorPassword(password string, validationError *ValidationError) {
+	if len(password) == 0 {
+		validationError.addMessage("administrator password must be specified")
+
+		return
+	}
+
+	if len(password) < minAdministratorPasswordLength || len(password) > maxAdministratorPasswordLength {
+		validationError.addMessage("administrator password must be between %d and %d characters long",
+			minAdministratorPasswordLength, maxAdministratorPasswordLength,
+		)
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSpecial bool
+	for _, character := range password {
+		switch {
+		case unicode.IsUpper(character):
+			hasUpper = true
+		case unicode.IsLower(character):
+			hasLower = true
+		case unicode.IsDigit(character):
+			hasDigit = true
+		default:
+			hasSpecial = true
+		}
+	}
+
+	characterClassCount := 0
+	for _, hasCharacterClass := range []bool{hasUpper, hasLower, hasDigit, hasSpecial} {
+		if hasCharacterClass {
+			characterClassCount++
+		}
+	}
+	if characterClassCount < minAdministratorPasswordCharacterClasses {
+		validationError.addMessage("administrator password must contain characters from at least %d of the following: upper-case letters, lower-case letters, digits, special characters",
+			minAdministratorPasswordCharacterClasses,
+		)
+	}
+}
+
+// Validate the CPU configuration for a server.
+//
+// A Count of 0 means "use the image default".
+func validateServerCPU(cpu VirtualMachineCPU, validationError *ValidationError) {
+	if cpu.Count < 0 || cpu.Count > maxServerCPUCount {
+		validationError.addMessage("CPU count must be between 1 and %d (was %d)", maxServerCPUCount, cpu.Count)
+	}
+
+	if cpu.CoresPerSocket < 0 {
+		validationError.addMessage("CPU cores per socket cannot be negative (was %d)", cpu.CoresPerSocket)
+	} else if cpu.CoresPerSocket > 0 {
+		if cpu.Count == 0 {
+			validationError.addMessage("CPU count must be specified when CPU cores per socket is specified")
+		} else if cpu.Count%cpu.CoresPerSocket != 0 {
+			validationError.addMessage("CPU count (%d) must be a multiple of CPU cores per socket (%d)", cpu.Count, cpu.CoresPerSocket)
+		}
+	}
+
+	switch cpu.Speed {
+	case "", ServerCPUSpeedStandard, ServerCPUSpeedHighPerformance:
+		break
+	default:
+		validationError.addMessage("'%s' is not a valid CPU speed (must be '%s' or '%s')",
+			cpu.Speed, ServerCPUSpeedStandard, ServerCPUSpeedHighPerformance,
+		)
+	}
+}
+
+// Validate the disk configuration for a server.
+func validateServerDisks(disks []VirtualMachineDisk, validationError *ValidationError) {
+	seenSCSIUnitIDs := make(map[int]bool)
+	for _, disk := range disks {
+		if disk.SCSIUnitID < 0 || disk.SCSIUnitID > maxSCSIUnitID || disk.SCSIUnitID == reservedSCSIUnitID {
+			validationError.addMessage("SCSI unit Id %d is out of range (must be between 0 and %d, excluding %d)",
+				disk.SCSIUnitID, maxSCSIUnitID, reservedSCSIUnitID,
+			)
+		} else if seenSCSIUnitIDs[disk.SCSIUnitID] {
+			validationError.addMessage("SCSI unit Id %d is used by more than one disk", disk.SCSIUnitID)
+		}
+		seenSCSIUnitIDs[disk.SCSIUnitID] = true
+
+		if !isValidDiskSpeed(disk.Speed) {
+			validationError.addMessage("disk with SCSI unit Id %d has invalid speed '%s' (must be '%s', '%s', or '%s')",
+				disk.SCSIUnitID, disk.Speed, ServerDiskSpeedStandard, ServerDiskSpeedHighPerformance, ServerDiskSpeedEconomy,
+			)
+		}
+	}
+}
+
+// Determine whether the specified disk speed is valid.
+func isValidDiskSpeed(speed string) bool {
+	switch speed {
+	case ServerDiskSpeedStandard, ServerDiskSpeedHighPerformance, ServerDiskSpeedEconomy:
+		return true
+	default:
+		return false
+	}
+}
+
+// Validate the configuration for a server's network adapter.
+func validateServerNetworkAdapter(adapterDescription string, adapter VirtualMachineNetworkAdapter, validationError *ValidationError) {
+	hasVLANID := adapter.VLANID != nil && len(*adapter.VLANID) > 0
+	hasIPv4Address := adapter.PrivateIPv4Address != nil && len(*adapter.PrivateIPv4Address) > 0
+
+	if hasVLANID == hasIPv4Address {
+		validationError.addMessage("%s: exactly one of VLAN Id or private IPv4 address must be specified", adapterDescription)
+	}
+
+	if hasIPv4Address {
+		_, err := parseIPv4Address(*adapter.PrivateIPv4Address)
+		if err != nil {
+			validationError.addMessage("%s: %s", adapterDescription, err.Error())
+		}
+	}
+}
+
+// Find the VLAN (if any) whose IPv4 range contains the specified address.
+func findVLANForIPv4Address(vlans []VLAN, address string) *VLAN {
+	for index := range vlans {
+		if vlans[index].IPv4Range.Contains(address) {
+			return &vlans[index]
+		}
+	}
+
+	return nil
+}
+
+// Verify that the specified IPv4 address can be assigned to a server on the specified VLAN.
+//
+// Returns a description of the problem, or an empty string if the address is valid.
+func validateIPv4AddressForVLAN(address string, vlan *VLAN) string {
+	if !vlan.IPv4Range.Contains(address) {
+		return fmt.Sprintf("private IPv4 address '%s' does not fall within the IPv4 range (%s) of VLAN '%s'",
+			address, vlan.IPv4Range.ToDisplayString(), vlan.Name,
+		)
+	}
+
+	ip, _ := parseIPv4Address(address)
+	if gatewayIP, err := parseIPv4Address(vlan.IPv4GatewayAddress); err == nil && ip.Equal(gatewayIP) {
+		return fmt.Sprintf("private IPv4 address '%s' is the gateway address of VLAN '%s'", address, vlan.Name)
+	}
+
+	networkAddress, _ := vlan.IPv4Range.GetNetworkAddress()
+	broadcastAddress, _ := vlan.IPv4Range.GetBroadcastAddress()
+	if ip.String() == networkAddress || ip.String() == broadcastAddress {
+		return fmt.Sprintf("private IPv4 address '%s' is the network or broadcast address of VLAN '%s'", address, vlan.Name)
+	}
+
+	return ""
+}
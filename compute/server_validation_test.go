@@ -0,0 +1,115 @@
+package compute
+
+import (
+	"strings"
+	"testing"
+)
+
+// Validate server deployment configuration (valid).
+func TestServerDeploymentConfiguration_Validate_Success(test *testing.T) {
+	config := createValidServerDeploymentConfiguration()
+
+	err := config.Validate()
+	if err != nil {
+		test.Fatal(err)
+	}
+}
+
+// Validate server deployment configuration (invalid password, disks, CPU, and network adapters).
+func TestServerDeploymentConfiguration_Validate_Failure(test *testing.T) {
+	expect := expect(test)
+
+	config := createValidServerDeploymentConfiguration()
+	config.AdministratorPassword = "password"
+	config.CPU = VirtualMachineCPU{
+		Count:          3,
+		CoresPerSocket: 2,
+	}
+	config.Disks = []VirtualMachineDisk{
+		VirtualMachineDisk{SCSIUnitID: 0, Speed: ServerDiskSpeedStandard},
+		VirtualMachineDisk{SCSIUnitID: 0, Speed: ServerDiskSpeedStandard},
+		VirtualMachineDisk{SCSIUnitID: 7, Speed: "SUPERFAST"},
+	}
+	config.Network.PrimaryAdapter.PrivateIPv4Address = stringToPtr("10.0.3.12")
+
+	err := config.Validate()
+	expect.NotNil("Validate error", err)
+
+	validationError, ok := err.(*ValidationError)
+	expect.IsTrue("Validate error is a ValidationError", ok)
+	expect.EqualsInt("ValidationError.Messages size", 6, len(validationError.Messages))
+
+	expect.IsTrue("Password complexity message", strings.Contains(validationError.Messages[0], "administrator password"))
+	expect.IsTrue("CPU message", strings.Contains(validationError.Messages[1], "multiple of CPU cores per socket"))
+	expect.IsTrue("Duplicate SCSI unit message", strings.Contains(validationError.Messages[2], "more than one disk"))
+	expect.IsTrue("SCSI unit range message", strings.Contains(validationError.Messages[3], "out of range"))
+	expect.IsTrue("Disk speed message", strings.Contains(validationError.Messages[4], "invalid speed 'SUPERFAST'"))
+	expect.IsTrue("Network adapter message", strings.Contains(validationError.Messages[5], "exactly one of VLAN Id or private IPv4 address"))
+}
+
+// Validate server deployment configuration against network domain VLANs (valid).
+func TestClient_ValidateServerDeploymentConfiguration_Success(test *testing.T) {
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			config := createValidServerDeploymentConfiguration()
+			config.Network.PrimaryAdapter.VLANID = nil
+			config.Network.PrimaryAdapter.PrivateIPv4Address = stringToPtr("10.0.3.12")
+
+			err := client.ValidateServerDeploymentConfiguration(config)
+			if err != nil {
+				test.Fatal(err)
+			}
+		},
+		Respond: testRespondOK(listVLANsTestResponse),
+	})
+}
+
+// Validate server deployment configuration against network domain VLANs (address is VLAN gateway / outside VLAN).
+func TestClient_ValidateServerDeploymentConfiguration_Failure(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			config := createValidServerDeploymentConfiguration()
+			config.Network.PrimaryAdapter.VLANID = nil
+			config.Network.PrimaryAdapter.PrivateIPv4Address = stringToPtr("10.0.3.1")
+			config.Network.AdditionalNetworkAdapters = []VirtualMachineNetworkAdapter{
+				VirtualMachineNetworkAdapter{
+					PrivateIPv4Address: stringToPtr("10.0.4.12"),
+				},
+			}
+
+			err := client.ValidateServerDeploymentConfiguration(config)
+			expect.NotNil("ValidateServerDeploymentConfiguration error", err)
+
+			validationError := err.(*ValidationError)
+			expect.EqualsInt("ValidationError.Messages size", 2, len(validationError.Messages))
+			expect.IsTrue("Gateway message", strings.Contains(validationError.Messages[0], "gateway address"))
+			expect.IsTrue("Range message", strings.Contains(validationError.Messages[1], "does not fall within the IPv4 range"))
+		},
+		Respond: testRespondOK(listVLANsTestResponse),
+	})
+}
+
+func createValidServerDeploymentConfiguration() ServerDeploymentConfiguration {
+	return ServerDeploymentConfiguration{
+		Name:                  "Production FTPS Server",
+		ImageID:               "02250336-de2b-4e99-ab96-78511b7f8f4b",
+		AdministratorPassword: "P$$ssWwrrdGoDd!",
+		CPU: VirtualMachineCPU{
+			Count:          4,
+			CoresPerSocket: 2,
+			Speed:          ServerCPUSpeedStandard,
+		},
+		Disks: []VirtualMachineDisk{
+			VirtualMachineDisk{SCSIUnitID: 0, SizeGB: 10, Speed: ServerDiskSpeedStandard},
+			VirtualMachineDisk{SCSIUnitID: 1, SizeGB: 20, Speed: ServerDiskSpeedHighPerformance},
+		},
+		Network: VirtualMachineNetwork{
+			NetworkDomainID: "484174a2-ae74-4658-9e56-50fc90e086cf",
+			PrimaryAdapter: VirtualMachineNetworkAdapter{
+				VLANID: stringToPtr("0e56433f-d808-4669-821d-812769517ff8"),
+			},
+		},
+	}
+}
@@ -2,11 +2,39 @@ package compute
 
 import (
 	"encoding/json"
-	"encoding/xml"
 	"fmt"
 	"net/http"
 )
 
+const (
+	// ServerDiskSpeedStandard represents the standard speed for server disks.
+	ServerDiskSpeedStandard = "STANDARD"
+
+	// ServerDiskSpeedHighPerformance represents the high-performance speed for server disks.
+	ServerDiskSpeedHighPerformance = "HIGHPERFORMANCE"
+
+	// ServerDiskSpeedEconomy represents the economy speed for server disks.
+	ServerDiskSpeedEconomy = "ECONOMY"
+
+	// ServerCPUSpeedStandard represents the standard speed for server CPUs.
+	ServerCPUSpeedStandard = "STANDARD"
+
+	// ServerCPUSpeedHighPerformance represents the high-performance speed for server CPUs.
+	ServerCPUSpeedHighPerformance = "HIGHPERFORMANCE"
+
+	// SCSIAdapterTypeLSILogicParallel represents an LSI Logic Parallel SCSI controller.
+	SCSIAdapterTypeLSILogicParallel = "LSI_LOGIC_PARALLEL"
+
+	// SCSIAdapterTypeLSILogicSAS represents an LSI Logic SAS SCSI controller.
+	SCSIAdapterTypeLSILogicSAS = "LSI_LOGIC_SAS"
+
+	// SCSIAdapterTypeVMWareParavirtual represents a VMWare Paravirtual SCSI controller.
+	SCSIAdapterTypeVMWareParavirtual = "VMWARE_PARAVIRTUAL"
+
+	// SCSIAdapterTypeBusLogicParallel represents a BusLogic Parallel SCSI controller.
+	SCSIAdapterTypeBusLogicParallel = "BUS_LOGIC"
+)
+
 // Server represents a virtual machine.
 type Server struct {
 	ID              string                `json:"id"`
@@ -21,6 +49,8 @@ type Server struct {
 	State           string                `json:"state"`
 	Deployed        bool                  `json:"deployed"`
 	Started         bool                  `json:"started"`
+	Backup          *ServerBackupSummary  `json:"backup,omitempty"`
+	Monitoring      *ServerMonitoring     `json:"monitoring,omitempty"`
 }
 
 // GetID returns the server's Id.
@@ -67,6 +97,51 @@ type Servers struct {
 	PagedResult
 }
 
+// GetNextAvailableSCSIUnitID determines the lowest SCSI unit Id that is not already used by one of the server's disks.
+// Returns an error if all SCSI unit Ids are in use.
+func (server *Server) GetNextAvailableSCSIUnitID() (int, error) {
+	usedSCSIUnitIDs := make(map[int]bool)
+	for _, disk := range server.Disks {
+		usedSCSIUnitIDs[disk.SCSIUnitID] = true
+	}
+
+	for scsiUnitID := 0; scsiUnitID <= maxSCSIUnitID; scsiUnitID++ {
+		if scsiUnitID == reservedSCSIUnitID {
+			continue
+		}
+
+		if !usedSCSIUnitIDs[scsiUnitID] {
+			return scsiUnitID, nil
+		}
+	}
+
+	return -1, fmt.Errorf("All SCSI unit Ids on server '%s' are in use.", server.Name)
+}
+
+// SCSIController represents a SCSI controller in a virtual machine.
+type SCSIController struct {
+	// The controller Id.
+	ID string `json:"id"`
+
+	// The controller's adapter type (e.g. SCSIAdapterTypeLSILogicParallel).
+	AdapterType string `json:"adapterType"`
+
+	// The controller's SCSI bus number.
+	BusNumber int `json:"busNumber"`
+
+	// The controller's device key.
+	Key int `json:"key"`
+
+	// The disks attached to the controller.
+	Disks []VirtualMachineDisk `json:"disk"`
+
+	// The controller's current state.
+	State string `json:"state"`
+}
+
+// SCSIControllers represents the SCSI controllers in a virtual machine.
+type SCSIControllers []SCSIController
+
 // ServerSummary respresents summary information for a server.
 type ServerSummary struct {
 	ID          string `json:"id"`
@@ -142,11 +217,49 @@ type addNicConfiguration struct {
 
 // resizeServerDisk represents the request body when resizing a server disk.
 type resizeServerDisk struct {
-	// The XML name for the "resizeServerDisk" data contract
-	XMLName xml.Name `xml:"http://oec.api.opsource.net/schemas/server ChangeDiskSize"`
+	// The disk Id.
+	DiskID string `json:"id"`
 
 	// The new disk size, in gigabytes.
-	NewSizeGB int `xml:"newSizeGb"`
+	NewSizeGB int `json:"newSizeGb"`
+}
+
+// changeServerDiskSpeed represents the request body when changing the speed of a server disk.
+type changeServerDiskSpeed struct {
+	// The disk Id.
+	DiskID string `json:"id"`
+
+	// The new disk speed.
+	Speed string `json:"speed"`
+}
+
+// removeServerDisk represents the request body when removing a disk from a server.
+type removeServerDisk struct {
+	// The disk Id.
+	DiskID string `json:"id"`
+}
+
+// addSCSIController represents the request body when adding a SCSI controller to a server.
+type addSCSIController struct {
+	// The server Id.
+	ServerID string `json:"serverId"`
+
+	// The controller's adapter type.
+	AdapterType string `json:"adapterType"`
+
+	// The controller's SCSI bus number (optional).
+	BusNumber *int `json:"busNumber,omitempty"`
+}
+
+// removeSCSIController represents the request body when removing a SCSI controller from a server.
+type removeSCSIController struct {
+	// The controller Id.
+	ID string `json:"id"`
+}
+
+// serverSCSIControllers represents the SCSI controller information returned for a server (v2.4 API).
+type serverSCSIControllers struct {
+	SCSIControllers SCSIControllers `json:"scsiController"`
 }
 
 // ApplyOSImage applies the specified OS image (and its default values for CPU, memory, and disks) to the ServerDeploymentConfiguration.
@@ -248,58 +361,17 @@ func (client *Client) GetServer(id string) (server *Server, err error) {
 }
 
 // ListServersInNetworkDomain retrieves a page of servers in the specified network domain.
-func (client *Client) ListServersInNetworkDomain(networkDomainID string, paging *Paging) (servers Servers, err error) {
-	if paging == nil {
-		paging = &Paging{
-			PageNumber: 1,
-		}
-	}
-	paging.ensureValidPageSize()
-
-	var organizationID string
-	organizationID, err = client.getOrganizationID()
-	if err != nil {
-		return
-	}
-
-	requestURI := fmt.Sprintf("%s/server/server?networkDomainId=%s&pageNumber=%d&pageSize=%d",
-		organizationID,
-		networkDomainID,
-		paging.PageNumber,
-		paging.PageSize,
-	)
-
-	var request *http.Request
-	request, err = client.newRequestV22(requestURI, http.MethodGet, nil)
-	if err != nil {
-		return
-	}
-
-	var (
-		responseBody []byte
-		statusCode   int
-	)
-	responseBody, statusCode, err = client.executeRequest(request)
-	if err != nil {
-		return
-	}
-
-	if statusCode != http.StatusOK {
-		var apiResponse *APIResponseV2
-		apiResponse, err = readAPIResponseAsJSON(responseBody, statusCode)
-		if err != nil {
-			return
-		}
-
-		err = apiResponse.ToError("Request to retrieve Server failed with status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
-
-		return
-	}
-
-	servers = Servers{}
-	err = json.Unmarshal(responseBody, &servers)
+func (client *Client) ListServersInNetworkDomain(networkDomainID string, paging *Paging) (servers *Servers, err error) {
+	return client.ListServers(&ServerQuery{
+		NetworkDomainID: networkDomainID,
+	}, paging)
+}
 
-	return
+// listAllServersInNetworkDomain retrieves all servers in the specified network domain (across all pages of results).
+func (client *Client) listAllServersInNetworkDomain(networkDomainID string) (servers []Server, err error) {
+	return client.ListAllServers(&ServerQuery{
+		NetworkDomainID: networkDomainID,
+	})
 }
 
 // DeployServer deploys a new virtual machine.
@@ -370,25 +442,217 @@ func (client *Client) AddDiskToServer(serverID string, scsiUnitID int, sizeGB in
 	return apiResponse.FieldMessages[0].Message, nil
 }
 
-// ResizeServerDisk requests resizing of a server disk.
-func (client *Client) ResizeServerDisk(serverID string, diskID string, newSizeGB int) (response *APIResponseV1, err error) {
+// AddDiskToServerAtNextSCSIUnit adds a disk to an existing server, using the lowest SCSI unit Id not already used by one of the server's disks.
+func (client *Client) AddDiskToServerAtNextSCSIUnit(serverID string, sizeGB int, speed string) (diskID string, scsiUnitID int, err error) {
+	server, err := client.GetServer(serverID)
+	if err != nil {
+		return "", -1, err
+	}
+	if server == nil {
+		return "", -1, fmt.Errorf("No server was found with Id '%s'.", serverID)
+	}
+
+	scsiUnitID, err = server.GetNextAvailableSCSIUnitID()
+	if err != nil {
+		return "", -1, err
+	}
+
+	diskID, err = client.AddDiskToServer(serverID, scsiUnitID, sizeGB, speed)
+	if err != nil {
+		return "", -1, err
+	}
+
+	return diskID, scsiUnitID, nil
+}
+
+// ResizeServerDisk requests resizing (expansion) of a server disk.
+func (client *Client) ResizeServerDisk(diskID string, newSizeGB int) error {
 	organizationID, err := client.getOrganizationID()
 	if err != nil {
-		return
+		return err
 	}
 
-	requestURI := fmt.Sprintf("%s/server/%s/disk/%s/changeSize", organizationID, serverID, diskID)
-	request, err := client.newRequestV1(requestURI, http.MethodPost, &resizeServerDisk{
+	requestURI := fmt.Sprintf("%s/server/expandDisk", organizationID)
+	request, err := client.newRequestV24(requestURI, http.MethodPost, &resizeServerDisk{
+		DiskID:    diskID,
 		NewSizeGB: newSizeGB,
 	})
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
-		return
+		return err
+	}
+
+	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
+	if err != nil {
+		return err
+	}
+
+	if apiResponse.ResponseCode != ResponseCodeInProgress {
+		return apiResponse.ToError("Request to resize disk '%s' failed with status code %d (%s): %s", diskID, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+	}
+
+	return nil
+}
+
+// ChangeServerDiskSpeed requests a change to the speed of a server disk.
+// speed must be one of ServerDiskSpeedStandard, ServerDiskSpeedHighPerformance, or ServerDiskSpeedEconomy.
+func (client *Client) ChangeServerDiskSpeed(diskID string, speed string) error {
+	if !isValidDiskSpeed(speed) {
+		return fmt.Errorf("'%s' is not a valid disk speed (must be '%s', '%s', or '%s').", speed, ServerDiskSpeedStandard, ServerDiskSpeedHighPerformance, ServerDiskSpeedEconomy)
+	}
+
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return err
+	}
+
+	requestURI := fmt.Sprintf("%s/server/changeDiskSpeed", organizationID)
+	request, err := client.newRequestV24(requestURI, http.MethodPost, &changeServerDiskSpeed{
+		DiskID: diskID,
+		Speed:  speed,
+	})
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return err
+	}
+
+	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
+	if err != nil {
+		return err
+	}
+
+	if apiResponse.ResponseCode != ResponseCodeInProgress {
+		return apiResponse.ToError("Request to change speed of disk '%s' failed with status code %d (%s): %s", diskID, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+	}
+
+	return nil
+}
+
+// RemoveDiskFromServer removes a disk from its server.
+func (client *Client) RemoveDiskFromServer(diskID string) error {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return err
+	}
+
+	requestURI := fmt.Sprintf("%s/server/removeDisk", organizationID)
+	request, err := client.newRequestV22(requestURI, http.MethodPost, &removeServerDisk{diskID})
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return err
+	}
+
+	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
+	if err != nil {
+		return err
+	}
+
+	if apiResponse.ResponseCode != ResponseCodeInProgress {
+		return apiResponse.ToError("Request to remove disk '%s' failed with status code %d (%s): %s", diskID, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+	}
+
+	return nil
+}
+
+// ListServerSCSIControllers retrieves the SCSI controllers (and their attached disks) for the specified server.
+// Returns nil if no server is found with the specified Id.
+func (client *Client) ListServerSCSIControllers(serverID string) (controllers SCSIControllers, err error) {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return nil, err
+	}
+
+	requestURI := fmt.Sprintf("%s/server/server/%s", organizationID, serverID)
+	request, err := client.newRequestV24(requestURI, http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode != http.StatusOK {
+		var apiResponse *APIResponseV2
+
+		apiResponse, err = readAPIResponseAsJSON(responseBody, statusCode)
+		if err != nil {
+			return nil, err
+		}
+
+		if apiResponse.ResponseCode == ResponseCodeResourceNotFound {
+			return nil, nil // Not an error, but was not found.
+		}
+
+		return nil, apiResponse.ToError("Request to retrieve SCSI controllers for server '%s' failed with status code %d (%s): %s", serverID, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+	}
+
+	server := &serverSCSIControllers{}
+	err = json.Unmarshal(responseBody, server)
+	if err != nil {
+		return nil, err
+	}
+
+	return server.SCSIControllers, nil
+}
+
+// AddSCSIControllerToServer adds a SCSI controller to an existing server.
+// adapterType is the controller's adapter type (e.g. SCSIAdapterTypeLSILogicParallel).
+// busNumber is the controller's SCSI bus number (if nil, the next available bus number will be used).
+func (client *Client) AddSCSIControllerToServer(serverID string, adapterType string, busNumber *int) error {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return err
+	}
+
+	requestURI := fmt.Sprintf("%s/server/addScsiController", organizationID)
+	request, err := client.newRequestV24(requestURI, http.MethodPost, &addSCSIController{
+		ServerID:    serverID,
+		AdapterType: adapterType,
+		BusNumber:   busNumber,
+	})
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return err
 	}
 
-	response, err = readAPIResponseV1(responseBody, statusCode)
+	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
+	if err != nil {
+		return err
+	}
+
+	if apiResponse.ResponseCode != ResponseCodeInProgress {
+		return apiResponse.ToError("Request to add SCSI controller to server '%s' failed with status code %d (%s): %s", serverID, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+	}
+
+	return nil
+}
+
+// RemoveSCSIControllerFromServer removes a SCSI controller from its server.
+// The controller must not have any attached disks.
+func (client *Client) RemoveSCSIControllerFromServer(controllerID string) error {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return err
+	}
+
+	requestURI := fmt.Sprintf("%s/server/removeScsiController", organizationID)
+	request, err := client.newRequestV24(requestURI, http.MethodPost, &removeSCSIController{controllerID})
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return err
+	}
 
-	return
+	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
+	if err != nil {
+		return err
+	}
+
+	if apiResponse.ResponseCode != ResponseCodeInProgress {
+		return apiResponse.ToError("Request to remove SCSI controller '%s' failed with status code %d (%s): %s", controllerID, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+	}
+
+	return nil
 }
 
 // DeleteServer deletes an existing Server.
@@ -113,19 +113,19 @@ func TestClient_ResizeServerDisk_Success(test *testing.T) {
 	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
 		expect.EqualsString(
 			"Request.URL",
-			"/oec/0.9/dummy-organization-id/server/7b62aae5-bdbe-4595-b58d-c78f95db2a7f/disk/92b1819e-6f91-4abe-88c7-607841959f90/changeSize",
+			"/caas/2.4/dummy-organization-id/server/expandDisk",
 			request.URL.Path,
 		)
 
 		requestBody := &resizeServerDisk{}
-		err := readRequestBodyAsXML(request, requestBody)
+		err := readRequestBodyAsJSON(request, requestBody)
 		if err != nil {
 			test.Fatal(err.Error())
 		}
 
 		verifyResizeServerDiskRequest(test, requestBody)
 
-		writer.Header().Set("Content-Type", "application/xml")
+		writer.Header().Set("Content-Type", "application/json")
 		writer.WriteHeader(http.StatusOK)
 
 		fmt.Fprintln(writer, resizeServerDiskTestResponse)
@@ -138,12 +138,232 @@ func TestClient_ResizeServerDisk_Success(test *testing.T) {
 		OrganizationID: "dummy-organization-id",
 	})
 
-	response, err := client.ResizeServerDisk("7b62aae5-bdbe-4595-b58d-c78f95db2a7f", "92b1819e-6f91-4abe-88c7-607841959f90", 23)
+	err := client.ResizeServerDisk("92b1819e-6f91-4abe-88c7-607841959f90", 23)
 	if err != nil {
 		test.Fatal(err)
 	}
+}
+
+// Resize server disk (failure).
+func TestClient_ResizeServerDisk_Failure(test *testing.T) {
+	expect := expect(test)
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusBadRequest)
+
+		fmt.Fprint(writer, resizeServerDiskFailureTestResponse)
+	}))
+	defer testServer.Close()
 
-	verifyResizeServerDiskTestResponse(test, response)
+	client := NewClient("au1", "user1", "password")
+	client.setBaseAddress(testServer.URL)
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	err := client.ResizeServerDisk("92b1819e-6f91-4abe-88c7-607841959f90", 10)
+	if err == nil {
+		test.Fatal("ResizeServerDisk should have failed.")
+	}
+
+	apiError, ok := err.(*APIError)
+	if !ok {
+		test.Fatalf("Expected *APIError but received %T.", err)
+	}
+	expect.EqualsString("APIError.Response.ResponseCode", ResponseCodeInvalidInputData, apiError.Response.GetResponseCode())
+}
+
+// Change server disk speed (successful).
+func TestClient_ChangeServerDiskSpeed_Success(test *testing.T) {
+	expect := expect(test)
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		expect.EqualsString("Request.URL", "/caas/2.4/dummy-organization-id/server/changeDiskSpeed", request.URL.Path)
+
+		requestBody, err := readRequestBodyAsString(request)
+		if err != nil {
+			test.Fatal(err.Error())
+		}
+
+		expect.EqualsString("Request.Body",
+			`{"id":"92b1819e-6f91-4abe-88c7-607841959f90","speed":"HIGHPERFORMANCE"}`,
+			requestBody,
+		)
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(writer, changeServerDiskSpeedTestResponse)
+	}))
+	defer testServer.Close()
+
+	client := NewClient("au1", "user1", "password")
+	client.setBaseAddress(testServer.URL)
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	err := client.ChangeServerDiskSpeed("92b1819e-6f91-4abe-88c7-607841959f90", ServerDiskSpeedHighPerformance)
+	if err != nil {
+		test.Fatal(err)
+	}
+}
+
+// Change server disk speed (invalid speed; no request is made).
+func TestClient_ChangeServerDiskSpeed_InvalidSpeed(test *testing.T) {
+	client := NewClient("au1", "user1", "password")
+	client.setBaseAddress("http://127.0.0.1:1")
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	err := client.ChangeServerDiskSpeed("92b1819e-6f91-4abe-88c7-607841959f90", "LUDICROUS")
+	if err == nil {
+		test.Fatal("ChangeServerDiskSpeed should have failed.")
+	}
+}
+
+// Remove server disk (successful).
+func TestClient_RemoveDiskFromServer_Success(test *testing.T) {
+	expect := expect(test)
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		expect.EqualsString("Request.URL", "/caas/2.2/dummy-organization-id/server/removeDisk", request.URL.Path)
+
+		requestBody, err := readRequestBodyAsString(request)
+		if err != nil {
+			test.Fatal(err.Error())
+		}
+
+		expect.EqualsString("Request.Body",
+			`{"id":"92b1819e-6f91-4abe-88c7-607841959f90"}`,
+			requestBody,
+		)
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(writer, removeDiskFromServerTestResponse)
+	}))
+	defer testServer.Close()
+
+	client := NewClient("au1", "user1", "password")
+	client.setBaseAddress(testServer.URL)
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	err := client.RemoveDiskFromServer("92b1819e-6f91-4abe-88c7-607841959f90")
+	if err != nil {
+		test.Fatal(err)
+	}
+}
+
+// List server SCSI controllers (successful).
+func TestClient_ListServerSCSIControllers_Success(test *testing.T) {
+	expect := expect(test)
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		expect.EqualsString("Request.URL", "/caas/2.4/dummy-organization-id/server/server/7b62aae5-bdbe-4595-b58d-c78f95db2a7f", request.URL.Path)
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(writer, listServerSCSIControllersTestResponse)
+	}))
+	defer testServer.Close()
+
+	client := NewClient("au1", "user1", "password")
+	client.setBaseAddress(testServer.URL)
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	controllers, err := client.ListServerSCSIControllers("7b62aae5-bdbe-4595-b58d-c78f95db2a7f")
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	expect.EqualsInt("SCSIControllers.Length", 1, len(controllers))
+	controller := controllers[0]
+	expect.EqualsString("SCSIController.ID", "f1d9e41e-3a17-4bcd-9b7f-0b1a35ec0f12", controller.ID)
+	expect.EqualsString("SCSIController.AdapterType", SCSIAdapterTypeLSILogicParallel, controller.AdapterType)
+	expect.EqualsInt("SCSIController.BusNumber", 0, controller.BusNumber)
+	expect.EqualsInt("SCSIController.Disks.Length", 2, len(controller.Disks))
+	expect.EqualsInt("SCSIController.Disks[1].SCSIUnitID", 1, controller.Disks[1].SCSIUnitID)
+	expect.EqualsString("SCSIController.Disks[1].Speed", ServerDiskSpeedEconomy, controller.Disks[1].Speed)
+}
+
+// Add SCSI controller to server (successful).
+func TestClient_AddSCSIControllerToServer_Success(test *testing.T) {
+	expect := expect(test)
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		expect.EqualsString("Request.URL", "/caas/2.4/dummy-organization-id/server/addScsiController", request.URL.Path)
+
+		requestBody, err := readRequestBodyAsString(request)
+		if err != nil {
+			test.Fatal(err.Error())
+		}
+
+		expect.EqualsString("Request.Body",
+			`{"serverId":"7b62aae5-bdbe-4595-b58d-c78f95db2a7f","adapterType":"VMWARE_PARAVIRTUAL","busNumber":1}`,
+			requestBody,
+		)
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(writer, addSCSIControllerToServerTestResponse)
+	}))
+	defer testServer.Close()
+
+	client := NewClient("au1", "user1", "password")
+	client.setBaseAddress(testServer.URL)
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	err := client.AddSCSIControllerToServer("7b62aae5-bdbe-4595-b58d-c78f95db2a7f", SCSIAdapterTypeVMWareParavirtual, intToPtr(1))
+	if err != nil {
+		test.Fatal(err)
+	}
+}
+
+// Determine next available SCSI unit Id.
+func TestServer_GetNextAvailableSCSIUnitID(test *testing.T) {
+	expect := expect(test)
+
+	server := &Server{Name: "server1"}
+	for scsiUnitID := 0; scsiUnitID < 9; scsiUnitID++ {
+		if scsiUnitID == 1 || scsiUnitID == 7 {
+			continue
+		}
+
+		server.Disks = append(server.Disks, VirtualMachineDisk{SCSIUnitID: scsiUnitID})
+	}
+
+	scsiUnitID, err := server.GetNextAvailableSCSIUnitID()
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.EqualsInt("SCSIUnitID", 1, scsiUnitID)
+
+	server.Disks = append(server.Disks, VirtualMachineDisk{SCSIUnitID: 1})
+	scsiUnitID, err = server.GetNextAvailableSCSIUnitID()
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.EqualsInt("SCSIUnitID", 9, scsiUnitID) // 7 is reserved for the controller.
+
+	for scsiUnitID := 9; scsiUnitID <= 15; scsiUnitID++ {
+		server.Disks = append(server.Disks, VirtualMachineDisk{SCSIUnitID: scsiUnitID})
+	}
+	_, err = server.GetNextAvailableSCSIUnitID()
+	if err == nil {
+		test.Fatal("GetNextAvailableSCSIUnitID should have failed (all SCSI unit Ids are in use).")
+	}
 }
 
 // Add Nic (successful).
@@ -346,16 +566,18 @@ func verifyAddNicToServerTestRequest(test *testing.T, request *addNicConfigurati
 }
 
 const resizeServerDiskTestRequest = `
-	<ChangeDiskSize xmlns="http://oec.api.opsource.net/schemas/server">
-		<newSizeGb>23</newSizeGb>
-	</ChangeDiskSize>
+	{
+		"id": "92b1819e-6f91-4abe-88c7-607841959f90",
+		"newSizeGb": 23
+	}
 `
 
 func verifyResizeServerDiskRequest(test *testing.T, request *resizeServerDisk) {
 	expect := expect(test)
 
-	expect.NotNil("ReconfigureServer", request)
-	expect.EqualsInt("ReconfigureServer.NewSizeGB", 23, request.NewSizeGB)
+	expect.NotNil("ResizeServerDisk", request)
+	expect.EqualsString("ResizeServerDisk.DiskID", "92b1819e-6f91-4abe-88c7-607841959f90", request.DiskID)
+	expect.EqualsInt("ResizeServerDisk.NewSizeGB", 23, request.NewSizeGB)
 }
 
 const notifyServerIPAddressChangeTestRequest = `
@@ -485,6 +707,11 @@ func verifyGetServerTestResponse(test *testing.T, server *Server) {
 	expect.EqualsString("Server.Name", "Production Web Server", server.Name)
 	// TODO: Verify the rest of these fields.
 	expect.EqualsString("Server.State", ResourceStatusPendingChange, server.State)
+
+	expect.IsTrue("Server.IsBackupEnabled", server.IsBackupEnabled())
+	expect.EqualsString("Server.Backup.ServicePlan", BackupServicePlanAdvanced, server.Backup.ServicePlan)
+	expect.IsTrue("Server.IsMonitoringEnabled", server.IsMonitoringEnabled())
+	expect.EqualsString("Server.Monitoring.ServicePlan", ServerMonitoringPlanEssentials, server.Monitoring.ServicePlan)
 }
 
 const deployServerTestResponse = `
@@ -548,23 +775,96 @@ func verifyAddDiskToServerTestResponse(test *testing.T, response *APIResponseV2)
 }
 
 const resizeServerDiskTestResponse = `
-	<Status>
-		<operation>Change Server Disk Size</operation>
-		<result>SUCCESS</result>
-		<resultDetail>Server 'Change Server Disk Size' Issued</resultDetail>
-		<resultCode>RESULT_0</resultCode>
-	</Status>
+	{
+		"operation": "EXPAND_DISK",
+		"responseCode": "IN_PROGRESS",
+		"message": "Request to expand Disk (Id:92b1819e-6f91-4abe-88c7-607841959f90) to 23 GB has been accepted and is being processed.",
+		"info": [],
+		"warning": [],
+		"error": [],
+		"requestId": "au1_20170302T033422751-0500_0be3b8f4-5c20-49cb-9a21-f0ff8f7bc3a5"
+	}
 `
 
-func verifyResizeServerDiskTestResponse(test *testing.T, response *APIResponseV1) {
-	expect := expect(test)
+const resizeServerDiskFailureTestResponse = `
+	{
+		"operation": "EXPAND_DISK",
+		"responseCode": "INVALID_INPUT_DATA",
+		"message": "The new disk size must be greater than the current disk size.",
+		"info": [],
+		"warning": [],
+		"error": [],
+		"requestId": "au1_20170302T033422751-0500_1d07d4d0-c1c5-4a52-b5d5-bd2b0e6da1c4"
+	}
+`
 
-	expect.NotNil("APIResponse", response)
-	expect.EqualsString("Response.Operation", "Change Server Disk Size", response.Operation)
-	expect.EqualsString("Response.ResponseCode", ResultSuccess, response.Result)
-	expect.EqualsString("Response.Message", "Server 'Change Server Disk Size' Issued", response.Message)
-	expect.EqualsString("Response.ResultCode", "RESULT_0", response.ResultCode)
-}
+const changeServerDiskSpeedTestResponse = `
+	{
+		"operation": "CHANGE_DISK_SPEED",
+		"responseCode": "IN_PROGRESS",
+		"message": "Request to change the speed of Disk (Id:92b1819e-6f91-4abe-88c7-607841959f90) has been accepted and is being processed.",
+		"info": [],
+		"warning": [],
+		"error": [],
+		"requestId": "au1_20170302T033422751-0500_5b0a6a48-86e8-4f25-8c43-1b6c0aa9e1f1"
+	}
+`
+
+const removeDiskFromServerTestResponse = `
+	{
+		"operation": "REMOVE_DISK",
+		"responseCode": "IN_PROGRESS",
+		"message": "Request to remove Disk (Id:92b1819e-6f91-4abe-88c7-607841959f90) has been accepted and is being processed.",
+		"info": [],
+		"warning": [],
+		"error": [],
+		"requestId": "au1_20170302T033422751-0500_78cbb7d5-f2ed-4d1c-9e19-b1e6a2c61d0e"
+	}
+`
+
+const addSCSIControllerToServerTestResponse = `
+	{
+		"operation": "ADD_SCSI_CONTROLLER",
+		"responseCode": "IN_PROGRESS",
+		"message": "Request to add SCSI Controller to Server (Id:7b62aae5-bdbe-4595-b58d-c78f95db2a7f) has been accepted and is being processed.",
+		"info": [],
+		"warning": [],
+		"error": [],
+		"requestId": "au1_20170302T033422751-0500_3c0b8e7f-1f52-4f2e-bf2a-6cdb6b7a4b63"
+	}
+`
+
+const listServerSCSIControllersTestResponse = `
+	{
+		"id": "7b62aae5-bdbe-4595-b58d-c78f95db2a7f",
+		"name": "Production Web Server",
+		"scsiController": [
+			{
+				"id": "f1d9e41e-3a17-4bcd-9b7f-0b1a35ec0f12",
+				"adapterType": "LSI_LOGIC_PARALLEL",
+				"key": 1000,
+				"busNumber": 0,
+				"state": "NORMAL",
+				"disk": [
+					{
+						"id": "92b1819e-6f91-4abe-88c7-607841959f90",
+						"scsiId": 0,
+						"sizeGb": 10,
+						"speed": "STANDARD",
+						"state": "NORMAL"
+					},
+					{
+						"id": "3b5d4d5e-3c4a-4b83-9a3f-9a7f5f0e2c01",
+						"scsiId": 1,
+						"sizeGb": 20,
+						"speed": "ECONOMY",
+						"state": "NORMAL"
+					}
+				]
+			}
+		]
+	}
+`
 
 const deleteServerTestResponse = `
 	{
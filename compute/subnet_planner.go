@@ -0,0 +1,247 @@
+package compute
+
+import "fmt"
+
+// SubnetPlanner proposes private IPv4 ranges for new VLANs (within a supernet) that do not overlap with existing VLANs.
+type SubnetPlanner struct {
+	// The network from which VLAN ranges are allocated.
+	Supernet IPv4Range
+
+	// The ranges already allocated (keyed by the Id or name of the VLAN that uses them).
+	AllocatedRanges map[string]IPv4Range
+}
+
+// NewSubnetPlanner creates a new SubnetPlanner that allocates ranges from the specified supernet.
+// vlans are the existing VLANs whose ranges must not be allocated.
+func NewSubnetPlanner(supernet IPv4Range, vlans []VLAN) (*SubnetPlanner, error) {
+	ipNet, err := supernet.ToIPNet()
+	if err != nil {
+		return nil, err
+	}
+
+	planner := &SubnetPlanner{
+		Supernet: IPv4Range{
+			BaseAddress: ipNet.IP.String(),
+			PrefixSize:  supernet.PrefixSize,
+		},
+		AllocatedRanges: make(map[string]IPv4Range),
+	}
+	for _, vlan := range vlans {
+		planner.AllocatedRanges[vlan.ID] = vlan.IPv4Range
+	}
+
+	return planner, nil
+}
+
+// CheckRange determines whether the specified range can be allocated (i.e. it is a valid VLAN range, lies within the supernet, and does not overlap with any allocated range).
+func (planner *SubnetPlanner) CheckRange(ipv4Range IPv4Range) error {
+	return planner.checkRange(ipv4Range, "")
+}
+
+// Allocate checks that the specified range can be allocated and, if so, records it as allocated to the specified VLAN (Id or name).
+func (planner *SubnetPlanner) Allocate(vlanID string, ipv4Range IPv4Range) error {
+	err := planner.CheckRange(ipv4Range)
+	if err != nil {
+		return err
+	}
+
+	planner.AllocatedRanges[vlanID] = ipv4Range
+
+	return nil
+}
+
+// ProposeRange proposes the lowest range of the specified prefix size that can be allocated.
+// The range is not recorded as allocated; call Allocate to do that.
+func (planner *SubnetPlanner) ProposeRange(prefixSize int) (IPv4Range, error) {
+	ranges, err := planner.ProposeRanges(prefixSize, 1)
+	if err != nil {
+		return IPv4Range{}, err
+	}
+
+	return ranges[0], nil
+}
+
+// ProposeRanges proposes the specified number of non-overlapping ranges (of the specified prefix size) that can be allocated.
+// The ranges are not recorded as allocated; call Allocate to do that.
+func (planner *SubnetPlanner) ProposeRanges(prefixSize int, count int) ([]IPv4Range, error) {
+	err := validateVLANIPv4PrefixSize(prefixSize)
+	if err != nil {
+		return nil, err
+	}
+	if prefixSize < planner.Supernet.PrefixSize {
+		return nil, fmt.Errorf("Cannot allocate a /%d range from supernet %s.", prefixSize, planner.Supernet.ToDisplayString())
+	}
+
+	supernetBaseAddress, err := parseIPv4Address(planner.Supernet.BaseAddress)
+	if err != nil {
+		return nil, err
+	}
+	firstAddress := uint64(ipv4ToUint32(supernetBaseAddress))
+	lastAddress := firstAddress + (uint64(1) << uint(32-planner.Supernet.PrefixSize)) - 1
+	rangeSize := uint64(1) << uint(32-prefixSize)
+
+	var proposedRanges []IPv4Range
+	for baseAddress := firstAddress; baseAddress+rangeSize-1 <= lastAddress && len(proposedRanges) < count; baseAddress += rangeSize {
+		candidate := IPv4Range{
+			BaseAddress: uint32ToIPv4(uint32(baseAddress)).String(),
+			PrefixSize:  prefixSize,
+		}
+		if planner.findOverlap(candidate, "") != "" || overlapsAny(candidate, proposedRanges) {
+			continue
+		}
+
+		proposedRanges = append(proposedRanges, candidate)
+	}
+
+	if len(proposedRanges) < count {
+		return nil, fmt.Errorf("Supernet %s does not have space for %d more /%d range(s).", planner.Supernet.ToDisplayString(), count, prefixSize)
+	}
+
+	return proposedRanges, nil
+}
+
+// CheckExpansion determines whether the specified VLAN's range can be expanded to the specified prefix size without overlapping any other allocated range.
+func (planner *SubnetPlanner) CheckExpansion(vlan *VLAN, newPrefixSize int) error {
+	if newPrefixSize >= vlan.IPv4Range.PrefixSize {
+		return fmt.Errorf("Cannot expand VLAN '%s' from /%d to /%d (the new prefix size must be smaller than the current one).", vlan.ID, vlan.IPv4Range.PrefixSize, newPrefixSize)
+	}
+
+	expandedRange := IPv4Range{
+		BaseAddress: vlan.IPv4Range.BaseAddress,
+		PrefixSize:  newPrefixSize,
+	}
+	expandedNetworkAddress, err := expandedRange.GetNetworkAddress()
+	if err != nil {
+		return err
+	}
+	if expandedNetworkAddress != vlan.IPv4Range.BaseAddress {
+		return fmt.Errorf("Cannot expand VLAN '%s' to /%d (its base address, %s, would not be the base address of the expanded network).", vlan.ID, newPrefixSize, vlan.IPv4Range.BaseAddress)
+	}
+
+	return planner.checkRange(expandedRange, vlan.ID)
+}
+
+// Check whether the specified range can be allocated (ignoring the range allocated to the specified VLAN, if any).
+func (planner *SubnetPlanner) checkRange(ipv4Range IPv4Range, ignoreVLANID string) error {
+	err := validateVLANIPv4PrefixSize(ipv4Range.PrefixSize)
+	if err != nil {
+		return err
+	}
+
+	ipNet, err := ipv4Range.ToIPNet()
+	if err != nil {
+		return err
+	}
+	if ipNet.IP.String() != ipv4Range.BaseAddress {
+		return fmt.Errorf("%s is not a valid network (base address should be %s).", ipv4Range.ToDisplayString(), ipNet.IP.String())
+	}
+
+	if ipv4Range.PrefixSize < planner.Supernet.PrefixSize || !planner.Supernet.Contains(ipv4Range.BaseAddress) {
+		return fmt.Errorf("Network %s does not lie within supernet %s.", ipv4Range.ToDisplayString(), planner.Supernet.ToDisplayString())
+	}
+
+	overlappingVLANID := planner.findOverlap(ipv4Range, ignoreVLANID)
+	if overlappingVLANID != "" {
+		return fmt.Errorf("Network %s overlaps with network %s (used by VLAN '%s').",
+			ipv4Range.ToDisplayString(),
+			planner.AllocatedRanges[overlappingVLANID].ToDisplayString(),
+			overlappingVLANID,
+		)
+	}
+
+	return nil
+}
+
+// Find the Id of the VLAN (if any) whose allocated range overlaps with the specified range.
+//
+// If more than one allocated range overlaps, the one with the lowest base address is used (so the result does not depend on map iteration order).
+func (planner *SubnetPlanner) findOverlap(ipv4Range IPv4Range, ignoreVLANID string) string {
+	var overlappingVLANID string
+	var overlappingBaseAddress uint32
+	for vlanID, allocatedRange := range planner.AllocatedRanges {
+		if vlanID == ignoreVLANID || !allocatedRange.Overlaps(ipv4Range) {
+			continue
+		}
+
+		var baseAddress uint32
+		baseIP, err := parseIPv4Address(allocatedRange.BaseAddress)
+		if err == nil {
+			baseAddress = ipv4ToUint32(baseIP)
+		}
+		if overlappingVLANID == "" || baseAddress < overlappingBaseAddress || (baseAddress == overlappingBaseAddress && vlanID < overlappingVLANID) {
+			overlappingVLANID = vlanID
+			overlappingBaseAddress = baseAddress
+		}
+	}
+
+	return overlappingVLANID
+}
+
+// Determine whether the specified range overlaps with any of the specified ranges.
+func overlapsAny(ipv4Range IPv4Range, ranges []IPv4Range) bool {
+	for _, otherRange := range ranges {
+		if otherRange.Overlaps(ipv4Range) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Ensure that the specified prefix size is valid for a VLAN.
+func validateVLANIPv4PrefixSize(prefixSize int) error {
+	if prefixSize < MinVLANIPv4PrefixSize || prefixSize > MaxVLANIPv4PrefixSize {
+		return fmt.Errorf("Invalid VLAN IPv4 prefix size %d (must be between %d and %d).", prefixSize, MinVLANIPv4PrefixSize, MaxVLANIPv4PrefixSize)
+	}
+
+	return nil
+}
+
+// NewSubnetPlannerForNetworkDomain creates a new SubnetPlanner that allocates ranges from the specified supernet, taking into account all VLANs in the specified network domain.
+func (client *Client) NewSubnetPlannerForNetworkDomain(networkDomainID string, supernet IPv4Range) (*SubnetPlanner, error) {
+	vlans, err := client.listAllVLANs(networkDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSubnetPlanner(supernet, vlans)
+}
+
+// DeployVLANInSupernet deploys a new VLAN into a network domain, using the lowest range (of the specified prefix size) within the supernet that does not overlap with the network domain's existing VLANs.
+func (client *Client) DeployVLANInSupernet(networkDomainID string, name string, description string, supernet IPv4Range, ipv4PrefixSize int) (vlanID string, ipv4Range IPv4Range, err error) {
+	planner, err := client.NewSubnetPlannerForNetworkDomain(networkDomainID, supernet)
+	if err != nil {
+		return "", IPv4Range{}, err
+	}
+
+	ipv4Range, err = planner.ProposeRange(ipv4PrefixSize)
+	if err != nil {
+		return "", IPv4Range{}, err
+	}
+
+	vlanID, err = client.DeployVLAN(networkDomainID, name, description, ipv4Range.BaseAddress, ipv4Range.PrefixSize)
+	if err != nil {
+		return "", IPv4Range{}, err
+	}
+
+	return vlanID, ipv4Range, nil
+}
+
+// ExpandVLANInNetworkDomain expands the specified VLAN's private IPv4 network, first checking that the expanded network will not overlap with any other VLAN in the same network domain.
+func (client *Client) ExpandVLANInNetworkDomain(vlan *VLAN, ipv4PrefixSize int) error {
+	vlans, err := client.listAllVLANs(vlan.NetworkDomain.ID)
+	if err != nil {
+		return err
+	}
+
+	planner, err := NewSubnetPlanner(IPv4Range{BaseAddress: "0.0.0.0", PrefixSize: 0}, vlans)
+	if err != nil {
+		return err
+	}
+	err = planner.CheckExpansion(vlan, ipv4PrefixSize)
+	if err != nil {
+		return err
+	}
+
+	return client.ExpandVLAN(vlan.ID, ipv4PrefixSize)
+}
@@ -0,0 +1,146 @@
+package compute
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// Propose ranges that do not overlap with existing VLANs.
+func TestSubnetPlanner_ProposeRanges(test *testing.T) {
+	expect := expect(test)
+
+	planner, err := NewSubnetPlanner(IPv4Range{BaseAddress: "10.0.0.0", PrefixSize: 16}, testPlannerVLANs())
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	ranges, err := planner.ProposeRanges(24, 3)
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.EqualsInt("Ranges.Length", 3, len(ranges))
+	expect.EqualsString("Ranges[0]", "10.0.2.0/24", ranges[0].ToDisplayString())
+	expect.EqualsString("Ranges[1]", "10.0.3.0/24", ranges[1].ToDisplayString())
+	expect.EqualsString("Ranges[2]", "10.0.5.0/24", ranges[2].ToDisplayString())
+
+	proposedRange, err := planner.ProposeRange(22)
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.EqualsString("ProposedRange", "10.0.8.0/22", proposedRange.ToDisplayString())
+
+	err = planner.Allocate("new-vlan", proposedRange)
+	if err != nil {
+		test.Fatal(err)
+	}
+	proposedRange, err = planner.ProposeRange(22)
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.EqualsString("ProposedRange (after allocation)", "10.0.12.0/22", proposedRange.ToDisplayString())
+
+	_, err = planner.ProposeRange(28)
+	expect.NotNil("ProposeRange error (prefix too large)", err)
+}
+
+// Propose ranges from a supernet with no free space.
+func TestSubnetPlanner_ProposeRanges_Exhausted(test *testing.T) {
+	planner, err := NewSubnetPlanner(IPv4Range{BaseAddress: "10.0.0.0", PrefixSize: 23}, testPlannerVLANs())
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	_, err = planner.ProposeRange(24)
+	expect(test).NotNil("ProposeRange error", err)
+}
+
+// Check ranges for overlaps.
+func TestSubnetPlanner_CheckRange(test *testing.T) {
+	expect := expect(test)
+
+	planner, err := NewSubnetPlanner(IPv4Range{BaseAddress: "10.0.0.0", PrefixSize: 16}, testPlannerVLANs())
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	err = planner.CheckRange(IPv4Range{BaseAddress: "10.0.2.0", PrefixSize: 23})
+	expect.IsTrue("CheckRange(10.0.2.0/23) succeeds", err == nil)
+
+	err = planner.CheckRange(IPv4Range{BaseAddress: "10.0.4.0", PrefixSize: 22})
+	expect.NotNil("CheckRange(10.0.4.0/22) error", err)
+	expect.IsTrue("CheckRange(10.0.4.0/22) error identifies VLAN", strings.Contains(err.Error(), "vlan-2"))
+
+	err = planner.CheckRange(IPv4Range{BaseAddress: "10.0.6.1", PrefixSize: 24})
+	expect.NotNil("CheckRange(10.0.6.1/24) error", err)
+
+	err = planner.CheckRange(IPv4Range{BaseAddress: "10.1.0.0", PrefixSize: 24})
+	expect.NotNil("CheckRange(10.1.0.0/24) error", err)
+}
+
+// Check VLAN expansion for overlaps.
+func TestSubnetPlanner_CheckExpansion(test *testing.T) {
+	expect := expect(test)
+
+	vlans := testPlannerVLANs()
+	planner, err := NewSubnetPlanner(IPv4Range{BaseAddress: "10.0.0.0", PrefixSize: 16}, vlans)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	// vlan-2 (10.0.4.0/24) can grow to 10.0.4.0/23, but not 10.0.4.0/22 (which would overlap with vlan-3).
+	err = planner.CheckExpansion(&vlans[1], 23)
+	expect.IsTrue("CheckExpansion(vlan-2, 23) succeeds", err == nil)
+
+	err = planner.CheckExpansion(&vlans[1], 22)
+	expect.NotNil("CheckExpansion(vlan-2, 22) error", err)
+
+	err = planner.CheckExpansion(&vlans[1], 24)
+	expect.NotNil("CheckExpansion(vlan-2, 24) error", err)
+
+	// vlan-3 (10.0.7.0/24) cannot grow to /23, because its base address would change.
+	err = planner.CheckExpansion(&vlans[2], 23)
+	expect.NotNil("CheckExpansion(vlan-3, 23) error", err)
+}
+
+// Deploy a VLAN in the first free range of a supernet.
+func TestClient_DeployVLANInSupernet_Success(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			vlanID, ipv4Range, err := client.DeployVLANInSupernet("484174a2-ae74-4658-9e56-50fc90e086cf", "Production VLAN", "For hosting our Production Cloud Servers",
+				IPv4Range{BaseAddress: "10.0.0.0", PrefixSize: 16}, 22,
+			)
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.EqualsString("VLANID", "0e56433f-d808-4669-821d-812769517ff8", vlanID)
+			expect.EqualsString("IPv4Range", "10.0.4.0/22", ipv4Range.ToDisplayString())
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			if strings.HasSuffix(request.URL.Path, "/network/vlan") {
+				return http.StatusOK, listVLANsTestResponse
+			}
+
+			requestBody := &DeployVLAN{}
+			err := readRequestBodyAsJSON(request, requestBody)
+			if err != nil {
+				test.Fatal(err)
+			}
+			expect.EqualsString("DeployVLAN.IPv4BaseAddress", "10.0.4.0", requestBody.IPv4BaseAddress)
+			expect.EqualsInt("DeployVLAN.IPv4PrefixSize", 22, requestBody.IPv4PrefixSize)
+
+			return http.StatusOK, deployVLANTestResponse
+		},
+	})
+}
+
+func testPlannerVLANs() []VLAN {
+	return []VLAN{
+		{ID: "vlan-1", IPv4Range: IPv4Range{BaseAddress: "10.0.0.0", PrefixSize: 23}},
+		{ID: "vlan-2", IPv4Range: IPv4Range{BaseAddress: "10.0.4.0", PrefixSize: 24}},
+		{ID: "vlan-3", IPv4Range: IPv4Range{BaseAddress: "10.0.7.0", PrefixSize: 24}},
+	}
+}
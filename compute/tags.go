@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 )
 
 // Tag represents a tag applied to an asset.
@@ -124,6 +125,29 @@ func (client *Client) GetAssetTags(assetID string, assetType string, paging *Pag
 	return tags, err
 }
 
+// getAllAssetTags retrieves all tags applied to the specified asset (across all pages of results).
+func (client *Client) getAllAssetTags(assetID string, assetType string) (tags []Tag, err error) {
+	paging := DefaultPaging()
+	for {
+		var page *TagDetails
+		page, err = client.GetAssetTags(assetID, assetType, paging)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tagDetail := range page.Items {
+			tags = append(tags, tagDetail.ToTag())
+		}
+		if page.IsLastPage() {
+			break
+		}
+
+		paging.Next()
+	}
+
+	return tags, nil
+}
+
 // ApplyAssetTags applies the specified tags to an asset.
 func (client *Client) ApplyAssetTags(assetID string, assetType string, tags ...Tag) (response *APIResponseV2, err error) {
 	organizationID, err := client.getOrganizationID()
@@ -312,3 +336,70 @@ func (client *Client) DeleteTagKey(id string) error {
 
 	return nil
 }
+
+// listAssetIDsWithTag retrieves the Ids of all assets of the specified type that have the specified tag applied (across all pages of results).
+// If the tag's value is empty, assets with any value for the tag are included.
+func (client *Client) listAssetIDsWithTag(assetType string, tag Tag) (assetIDs map[string]bool, err error) {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("assetType", assetType)
+	query.Set("tagKeyName", tag.Name)
+	if len(tag.Value) > 0 {
+		query.Set("value", tag.Value)
+	}
+
+	assetIDs = make(map[string]bool)
+	paging := DefaultPaging()
+	for {
+		requestURI := fmt.Sprintf("%s/tag/tag?%s&%s",
+			organizationID, query.Encode(), paging.toQueryParameters(),
+		)
+		var request *http.Request
+		request, err = client.newRequestV22(requestURI, http.MethodGet, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			responseBody []byte
+			statusCode   int
+		)
+		responseBody, statusCode, err = client.executeRequest(request)
+		if err != nil {
+			return nil, err
+		}
+
+		if statusCode != http.StatusOK {
+			var apiResponse *APIResponseV2
+
+			apiResponse, err = readAPIResponseAsJSON(responseBody, statusCode)
+			if err != nil {
+				return nil, err
+			}
+
+			return nil, apiResponse.ToError("Request to list assets with tag '%s' failed with status code %d (%s): %s", tag.Name, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+		}
+
+		page := &TagDetails{}
+		err = json.Unmarshal(responseBody, page)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tagDetail := range page.Items {
+			assetIDs[tagDetail.AssetID] = true
+		}
+
+		if page.IsLastPage() {
+			break
+		}
+
+		paging.Next()
+	}
+
+	return assetIDs, nil
+}
@@ -16,6 +16,10 @@ func intToPtr(value int) *int {
 	return &value
 }
 
+func boolToPtr(value bool) *bool {
+	return &value
+}
+
 // Get the request body, replacing it with a copy of the original
 func getRequestBody(request *http.Request) (requestBody []byte, err error) {
 	if request.Body != nil {
@@ -215,6 +215,27 @@ func (client *Client) ListVIPNodesInNetworkDomain(networkDomainID string, paging
 	return nodes, nil
 }
 
+// listAllVIPNodesInNetworkDomain retrieves all VIP nodes in the specified network domain (across all pages of results).
+func (client *Client) listAllVIPNodesInNetworkDomain(networkDomainID string) (nodes []VIPNode, err error) {
+	paging := DefaultPaging()
+	for {
+		var page *VIPNodes
+		page, err = client.ListVIPNodesInNetworkDomain(networkDomainID, paging)
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, page.Items...)
+		if page.IsLastPage() {
+			break
+		}
+
+		paging.Next()
+	}
+
+	return nodes, nil
+}
+
 // GetVIPNode retrieves the VIP node with the specified Id.
 // Returns nil if no VIP node is found with the specified Id.
 func (client *Client) GetVIPNode(id string) (node *VIPNode, err error) {
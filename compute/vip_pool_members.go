@@ -20,6 +20,37 @@ type VIPPoolMember struct {
 	CreateTime      string           `json:"createTime"`
 }
 
+// GetID returns the pool member's Id.
+func (member *VIPPoolMember) GetID() string {
+	return member.ID
+}
+
+// GetResourceType returns the pool member's resource type.
+func (member *VIPPoolMember) GetResourceType() ResourceType {
+	return ResourceTypeVIPPoolMember
+}
+
+// GetName returns the pool member's name (the name of its node, and its port, if any).
+func (member *VIPPoolMember) GetName() string {
+	if member.Port == nil {
+		return member.Node.Name
+	}
+
+	return fmt.Sprintf("%s:%d", member.Node.Name, *member.Port)
+}
+
+// GetState returns the pool member's current state.
+func (member *VIPPoolMember) GetState() string {
+	return member.State
+}
+
+// IsDeleted determines whether the pool member has been deleted (is nil).
+func (member *VIPPoolMember) IsDeleted() bool {
+	return member == nil
+}
+
+var _ Resource = &VIPPoolMember{}
+
 // VIPPoolMembers represents a page of VIPPoolMember results.
 type VIPPoolMembers struct {
 	Items []VIPPoolMember `json:"poolMember"`
@@ -88,6 +119,27 @@ func (client *Client) ListVIPPoolMembers(poolID string, paging *Paging) (members
 	return members, nil
 }
 
+// listAllVIPPoolMembers retrieves all members of the specified VIP pool (across all pages of results).
+func (client *Client) listAllVIPPoolMembers(poolID string) (members []VIPPoolMember, err error) {
+	paging := DefaultPaging()
+	for {
+		var page *VIPPoolMembers
+		page, err = client.ListVIPPoolMembers(poolID, paging)
+		if err != nil {
+			return nil, err
+		}
+
+		members = append(members, page.Items...)
+		if page.IsLastPage() {
+			break
+		}
+
+		paging.Next()
+	}
+
+	return members, nil
+}
+
 // ListVIPPoolMembershipsInNetworkDomain retrieves a list of all VIP pool memberships of the specified network domain.
 func (client *Client) ListVIPPoolMembershipsInNetworkDomain(networkDomainID string, paging *Paging) (members *VIPPoolMembers, err error) {
 	organizationID, err := client.getOrganizationID()
@@ -130,6 +182,27 @@ func (client *Client) ListVIPPoolMembershipsInNetworkDomain(networkDomainID stri
 	return members, nil
 }
 
+// listAllVIPPoolMembershipsInNetworkDomain retrieves all VIP pool memberships in the specified network domain (across all pages of results).
+func (client *Client) listAllVIPPoolMembershipsInNetworkDomain(networkDomainID string) (members []VIPPoolMember, err error) {
+	paging := DefaultPaging()
+	for {
+		var page *VIPPoolMembers
+		page, err = client.ListVIPPoolMembershipsInNetworkDomain(networkDomainID, paging)
+		if err != nil {
+			return nil, err
+		}
+
+		members = append(members, page.Items...)
+		if page.IsLastPage() {
+			break
+		}
+
+		paging.Next()
+	}
+
+	return members, nil
+}
+
 // GetVIPPoolMember retrieves the VIP pool member with the specified Id.
 // Returns nil if no VIP pool member is found with the specified Id.
 func (client *Client) GetVIPPoolMember(id string) (member *VIPPoolMember, err error) {
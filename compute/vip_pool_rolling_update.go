@@ -0,0 +1,226 @@
+package compute
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// VIPPoolRollingUpdateOptions represents the options for a rolling update of a VIP pool's members.
+type VIPPoolRollingUpdateOptions struct {
+	// The Id of the VIP pool whose members will be updated.
+	PoolID string
+
+	// The number of members to take out of service at a time (if not specified, 1).
+	BatchSize int
+
+	// The maximum number of the pool's members that may be unavailable (i.e. not enabled, or not in the NORMAL state) at any time, including the current batch (if not specified, BatchSize).
+	//
+	// Before each batch is taken out of service, the rolling update is aborted if this would leave more members unavailable than this.
+	MaxUnavailable int
+
+	// Force members offline (VIPNodeStatusForcedOffline, which drops existing connections), rather than disabling them (VIPNodeStatusDisabled, which allows existing connections to complete)?
+	ForceOffline bool
+
+	// The amount of time to wait for each change to a member's status to be applied.
+	Timeout time.Duration
+}
+
+// VIPPoolMemberUpdater is a function that performs work (e.g. patching) on a batch of VIP pool members while they are out of service.
+type VIPPoolMemberUpdater func(members []VIPPoolMember) error
+
+// VIPPoolRollingUpdateResult represents the result of a rolling update of a VIP pool's members.
+type VIPPoolRollingUpdateResult struct {
+	// The VIP pool Id.
+	PoolID string
+
+	// The number of batches that were successfully updated.
+	CompletedBatches int
+
+	// The members that were successfully updated (and returned to service).
+	UpdatedMembers []VIPPoolMember
+
+	// The members that were not updated because they were not enabled when the rolling update started.
+	SkippedMembers []VIPPoolMember
+}
+
+// VIPPoolRollingUpdateError is an error representing the failure of a batch in a rolling update of a VIP pool's members.
+type VIPPoolRollingUpdateError struct {
+	// The VIP pool Id.
+	PoolID string
+
+	// The (1-based) number of the batch that failed.
+	Batch int
+
+	// The members in the batch that failed.
+	Members []VIPPoolMember
+
+	// A description of the step that failed.
+	FailedStep string
+
+	// The error that caused the batch to fail.
+	Cause error
+}
+
+// Error returns the error message associated with the VIPPoolRollingUpdateError.
+func (updateError *VIPPoolRollingUpdateError) Error() string {
+	return fmt.Sprintf("Rolling update of VIP pool '%s' failed at step '%s' of batch %d: %s",
+		updateError.PoolID,
+		updateError.FailedStep,
+		updateError.Batch,
+		updateError.Cause.Error(),
+	)
+}
+
+var _ error = &VIPPoolRollingUpdateError{}
+
+// RollingUpdateVIPPoolMembers takes the members of a VIP pool out of service in batches, invokes update for each batch, and then returns the batch to service.
+//
+// Only members that are enabled when the rolling update starts are updated. For each batch, the members are disabled (or forced offline), update is called,
+// and the members are re-enabled; RollingUpdateVIPPoolMembers waits for each member to return to the NORMAL state before moving on.
+//
+// The rolling update is aborted (with a *VIPPoolRollingUpdateError) if taking the next batch out of service would leave more than MaxUnavailable members unavailable,
+// or if any step fails. If update fails, the members in that batch are left out of service (since they may not be in a fit state to receive traffic).
+func (client *Client) RollingUpdateVIPPoolMembers(options VIPPoolRollingUpdateOptions, update VIPPoolMemberUpdater) (*VIPPoolRollingUpdateResult, error) {
+	if options.PoolID == "" {
+		return nil, fmt.Errorf("A VIP pool must be specified for the rolling update.")
+	}
+	if update == nil {
+		return nil, fmt.Errorf("An update function must be specified for the rolling update of VIP pool '%s'.", options.PoolID)
+	}
+	if options.BatchSize == 0 {
+		options.BatchSize = 1
+	}
+	if options.MaxUnavailable == 0 {
+		options.MaxUnavailable = options.BatchSize
+	}
+	if options.BatchSize < 0 {
+		return nil, fmt.Errorf("Invalid batch size %d for the rolling update of VIP pool '%s'.", options.BatchSize, options.PoolID)
+	}
+	if options.MaxUnavailable < options.BatchSize {
+		return nil, fmt.Errorf("The maximum number of unavailable members (%d) for the rolling update of VIP pool '%s' cannot be less than the batch size (%d).", options.MaxUnavailable, options.PoolID, options.BatchSize)
+	}
+
+	outOfServiceStatus := VIPNodeStatusDisabled
+	if options.ForceOffline {
+		outOfServiceStatus = VIPNodeStatusForcedOffline
+	}
+
+	members, err := client.listAllVIPPoolMembers(options.PoolID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &VIPPoolRollingUpdateResult{
+		PoolID: options.PoolID,
+	}
+	var membersToUpdate []VIPPoolMember
+	for _, member := range members {
+		if member.Status == VIPNodeStatusEnabled {
+			membersToUpdate = append(membersToUpdate, member)
+		} else {
+			log.Printf("Skipping VIP pool member '%s' ('%s') because its status is '%s'.", member.ID, member.GetName(), member.Status)
+			result.SkippedMembers = append(result.SkippedMembers, member)
+		}
+	}
+
+	for batchStart := 0; batchStart < len(membersToUpdate); batchStart += options.BatchSize {
+		batchEnd := batchStart + options.BatchSize
+		if batchEnd > len(membersToUpdate) {
+			batchEnd = len(membersToUpdate)
+		}
+		batch := membersToUpdate[batchStart:batchEnd]
+		batchNumber := result.CompletedBatches + 1
+
+		batchError := func(failedStep string, cause error) error {
+			return &VIPPoolRollingUpdateError{
+				PoolID:     options.PoolID,
+				Batch:      batchNumber,
+				Members:    batch,
+				FailedStep: failedStep,
+				Cause:      cause,
+			}
+		}
+
+		log.Printf("Starting batch %d of rolling update for VIP pool '%s' (%d member(s))...", batchNumber, options.PoolID, len(batch))
+
+		err = client.checkVIPPoolAvailability(options.PoolID, len(batch), options.MaxUnavailable)
+		if err != nil {
+			return result, batchError("Check availability", err)
+		}
+
+		err = client.setVIPPoolMemberStatuses(batch, outOfServiceStatus, options.Timeout)
+		if err != nil {
+			return result, batchError("Take members out of service", err)
+		}
+
+		err = update(batch)
+		if err != nil {
+			return result, batchError("Update members", err)
+		}
+
+		err = client.setVIPPoolMemberStatuses(batch, VIPNodeStatusEnabled, options.Timeout)
+		if err != nil {
+			return result, batchError("Return members to service", err)
+		}
+
+		result.CompletedBatches++
+		result.UpdatedMembers = append(result.UpdatedMembers, batch...)
+	}
+
+	return result, nil
+}
+
+// Ensure that taking the specified number of additional members out of service will not leave more than maxUnavailable of the pool's members unavailable.
+func (client *Client) checkVIPPoolAvailability(poolID string, batchSize int, maxUnavailable int) error {
+	members, err := client.listAllVIPPoolMembers(poolID)
+	if err != nil {
+		return err
+	}
+
+	unavailable := 0
+	for _, member := range members {
+		if member.Status != VIPNodeStatusEnabled || member.State != ResourceStatusNormal {
+			unavailable++
+		}
+	}
+	if unavailable+batchSize > maxUnavailable {
+		return fmt.Errorf("%d of the %d members of VIP pool '%s' are already unavailable; taking %d more out of service would exceed the maximum of %d unavailable member(s).",
+			unavailable, len(members), poolID, batchSize, maxUnavailable,
+		)
+	}
+
+	return nil
+}
+
+// Update the status of each of the specified VIP pool members, and wait for the changes to be applied.
+func (client *Client) setVIPPoolMemberStatuses(members []VIPPoolMember, status string, timeout time.Duration) error {
+	for _, member := range members {
+		log.Printf("Set status of VIP pool member '%s' ('%s') to '%s'...", member.ID, member.GetName(), status)
+
+		err := client.EditVIPPoolMember(member.ID, status)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, member := range members {
+		updatedMember, err := client.GetVIPPoolMember(member.ID)
+		if err != nil {
+			return err
+		}
+		if updatedMember == nil {
+			return fmt.Errorf("No VIP pool member was found with Id '%s'.", member.ID)
+		}
+		if updatedMember.State == ResourceStatusNormal {
+			continue
+		}
+
+		_, err = client.WaitForEdit(ResourceTypeVIPPoolMember, member.ID, timeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,235 @@
+package compute
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// Rolling update of VIP pool members (3 members, in batches of 2).
+func TestClient_RollingUpdateVIPPoolMembers_Success(test *testing.T) {
+	expect := expect(test)
+
+	pool := newTestRollingUpdatePool(VIPNodeStatusEnabled, VIPNodeStatusEnabled, VIPNodeStatusEnabled)
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			var batches []string
+			result, err := client.RollingUpdateVIPPoolMembers(VIPPoolRollingUpdateOptions{
+				PoolID:    "4d360b1f-bc2c-4ab7-9884-1f03ba2768f7",
+				BatchSize: 2,
+			}, func(members []VIPPoolMember) error {
+				var memberIDs []string
+				for _, member := range members {
+					expect.EqualsString("Status of member "+member.ID+" during update", VIPNodeStatusDisabled, pool.statuses[member.ID])
+					memberIDs = append(memberIDs, member.ID)
+				}
+				batches = append(batches, strings.Join(memberIDs, ","))
+
+				return nil
+			})
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.EqualsInt("Result.CompletedBatches", 2, result.CompletedBatches)
+			expect.EqualsInt("Result.UpdatedMembers.Length", 3, len(result.UpdatedMembers))
+			expect.EqualsInt("Result.SkippedMembers.Length", 0, len(result.SkippedMembers))
+			expect.EqualsString("Batches", "member-1,member-2;member-3", strings.Join(batches, ";"))
+
+			for _, memberID := range []string{"member-1", "member-2", "member-3"} {
+				expect.EqualsString("Final status of member "+memberID, VIPNodeStatusEnabled, pool.statuses[memberID])
+			}
+		},
+		Respond: pool.respond,
+	})
+}
+
+// Rolling update of VIP pool members (forced offline; disabled members are skipped but count as unavailable).
+func TestClient_RollingUpdateVIPPoolMembers_ForceOffline(test *testing.T) {
+	expect := expect(test)
+
+	pool := newTestRollingUpdatePool(VIPNodeStatusEnabled, VIPNodeStatusDisabled, VIPNodeStatusEnabled)
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			result, err := client.RollingUpdateVIPPoolMembers(VIPPoolRollingUpdateOptions{
+				PoolID:         "4d360b1f-bc2c-4ab7-9884-1f03ba2768f7",
+				MaxUnavailable: 2,
+				ForceOffline:   true,
+			}, func(members []VIPPoolMember) error {
+				expect.EqualsString("Status during update", VIPNodeStatusForcedOffline, pool.statuses[members[0].ID])
+
+				return nil
+			})
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.EqualsInt("Result.CompletedBatches", 2, result.CompletedBatches)
+			expect.EqualsInt("Result.SkippedMembers.Length", 1, len(result.SkippedMembers))
+			expect.EqualsString("Result.SkippedMembers[0].ID", "member-2", result.SkippedMembers[0].ID)
+			expect.EqualsString("Final status of member-2", VIPNodeStatusDisabled, pool.statuses["member-2"])
+		},
+		Respond: pool.respond,
+	})
+}
+
+// Rolling update of VIP pool members (aborted because too many members are already unavailable).
+func TestClient_RollingUpdateVIPPoolMembers_TooManyUnavailable(test *testing.T) {
+	expect := expect(test)
+
+	pool := newTestRollingUpdatePool(VIPNodeStatusEnabled, VIPNodeStatusDisabled, VIPNodeStatusEnabled)
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			var updates int
+			result, err := client.RollingUpdateVIPPoolMembers(VIPPoolRollingUpdateOptions{
+				PoolID: "4d360b1f-bc2c-4ab7-9884-1f03ba2768f7",
+			}, func(members []VIPPoolMember) error {
+				updates++
+
+				return nil
+			})
+			expect.NotNil("RollingUpdateVIPPoolMembers error", err)
+
+			updateError, ok := err.(*VIPPoolRollingUpdateError)
+			expect.IsTrue("Error is VIPPoolRollingUpdateError", ok)
+			expect.EqualsInt("VIPPoolRollingUpdateError.Batch", 1, updateError.Batch)
+			expect.EqualsString("VIPPoolRollingUpdateError.FailedStep", "Check availability", updateError.FailedStep)
+
+			expect.EqualsInt("Result.CompletedBatches", 0, result.CompletedBatches)
+			expect.EqualsInt("Updates", 0, updates)
+			expect.EqualsInt("Members edited", 0, pool.edits)
+		},
+		Respond: pool.respond,
+	})
+}
+
+// Rolling update of VIP pool members (update fails, so the batch is left out of service).
+func TestClient_RollingUpdateVIPPoolMembers_UpdateFailed(test *testing.T) {
+	expect := expect(test)
+
+	pool := newTestRollingUpdatePool(VIPNodeStatusEnabled, VIPNodeStatusEnabled, VIPNodeStatusEnabled)
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			result, err := client.RollingUpdateVIPPoolMembers(VIPPoolRollingUpdateOptions{
+				PoolID: "4d360b1f-bc2c-4ab7-9884-1f03ba2768f7",
+			}, func(members []VIPPoolMember) error {
+				if members[0].ID == "member-2" {
+					return fmt.Errorf("Patch failed.")
+				}
+
+				return nil
+			})
+			expect.NotNil("RollingUpdateVIPPoolMembers error", err)
+
+			updateError, ok := err.(*VIPPoolRollingUpdateError)
+			expect.IsTrue("Error is VIPPoolRollingUpdateError", ok)
+			expect.EqualsInt("VIPPoolRollingUpdateError.Batch", 2, updateError.Batch)
+			expect.EqualsString("VIPPoolRollingUpdateError.FailedStep", "Update members", updateError.FailedStep)
+			expect.IsTrue("Error message mentions cause", strings.Contains(err.Error(), "Patch failed."))
+
+			expect.EqualsInt("Result.CompletedBatches", 1, result.CompletedBatches)
+			expect.EqualsString("Final status of member-1", VIPNodeStatusEnabled, pool.statuses["member-1"])
+			expect.EqualsString("Final status of member-2", VIPNodeStatusDisabled, pool.statuses["member-2"])
+			expect.EqualsString("Final status of member-3", VIPNodeStatusEnabled, pool.statuses["member-3"])
+		},
+		Respond: pool.respond,
+	})
+}
+
+// Rolling update of VIP pool members (invalid options).
+func TestClient_RollingUpdateVIPPoolMembers_InvalidOptions(test *testing.T) {
+	expect := expect(test)
+
+	client := NewClient("au1", "user1", "password")
+	_, err := client.RollingUpdateVIPPoolMembers(VIPPoolRollingUpdateOptions{
+		PoolID:         "4d360b1f-bc2c-4ab7-9884-1f03ba2768f7",
+		BatchSize:      2,
+		MaxUnavailable: 1,
+	}, func(members []VIPPoolMember) error {
+		return nil
+	})
+	expect.NotNil("RollingUpdateVIPPoolMembers error", err)
+}
+
+// A fake VIP pool whose members' statuses can be edited (edits are applied immediately).
+type testRollingUpdatePool struct {
+	memberIDs []string
+	statuses  map[string]string
+	edits     int
+}
+
+func newTestRollingUpdatePool(statuses ...string) *testRollingUpdatePool {
+	pool := &testRollingUpdatePool{
+		statuses: make(map[string]string),
+	}
+	for index, status := range statuses {
+		memberID := fmt.Sprintf("member-%d", index+1)
+		pool.memberIDs = append(pool.memberIDs, memberID)
+		pool.statuses[memberID] = status
+	}
+
+	return pool
+}
+
+func (pool *testRollingUpdatePool) memberJSON(memberID string) string {
+	return fmt.Sprintf(`{ "id": "%s", "pool": { "id": "4d360b1f-bc2c-4ab7-9884-1f03ba2768f7" }, "node": { "id": "node-%s", "name": "Node %s" }, "port": 80, "status": "%s", "state": "NORMAL" }`,
+		memberID, memberID, memberID, pool.statuses[memberID],
+	)
+}
+
+func (pool *testRollingUpdatePool) respond(test *testing.T, request *http.Request) (int, string) {
+	switch {
+	case strings.HasSuffix(request.URL.Path, "/networkDomainVip/poolMember"):
+		expect(test).EqualsString("Request.Query.poolId", "4d360b1f-bc2c-4ab7-9884-1f03ba2768f7", request.URL.Query().Get("poolId"))
+
+		members := make([]string, len(pool.memberIDs))
+		for index, memberID := range pool.memberIDs {
+			members[index] = pool.memberJSON(memberID)
+		}
+
+		return http.StatusOK, fmt.Sprintf(`{ "poolMember": [ %s ], "pageNumber": 1, "pageCount": %d, "totalCount": %d, "pageSize": 50 }`,
+			strings.Join(members, ", "), len(members), len(members),
+		)
+	case strings.HasSuffix(request.URL.Path, "/networkDomainVip/editPoolMember"):
+		requestBody := &bytes.Buffer{}
+		_, err := requestBody.ReadFrom(request.Body)
+		if err != nil {
+			test.Fatal(err)
+		}
+		edit := &editPoolMember{}
+		err = json.Unmarshal(requestBody.Bytes(), edit)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		pool.statuses[edit.ID] = edit.Status
+		pool.edits++
+
+		return http.StatusOK, rollingUpdateEditPoolMemberTestResponse
+	}
+
+	for _, memberID := range pool.memberIDs {
+		if strings.HasSuffix(request.URL.Path, "/networkDomainVip/poolMember/"+memberID) {
+			return http.StatusOK, pool.memberJSON(memberID)
+		}
+	}
+
+	test.Fatalf("Unexpected request: %s %s", request.Method, request.URL.Path)
+
+	return http.StatusNotFound, ""
+}
+
+const rollingUpdateEditPoolMemberTestResponse = `
+	{
+		"operation": "EDIT_POOL_MEMBER",
+		"responseCode": "OK",
+		"message": "Pool Member has been edited.",
+		"info": [],
+		"warning": [],
+		"error": [],
+		"requestId": "na9_20160321T074626030-0400_7e9fffe7-190b-46f2-9107-9d52fe57d0ad"
+	}
+`
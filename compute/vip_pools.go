@@ -99,7 +99,7 @@ var _ NamedEntity = &VIPNode{}
 
 // VIPPools represents a page of VIPPool results.
 type VIPPools struct {
-	Items []VIPPool
+	Items []VIPPool `json:"pool"`
 
 	PagedResult
 }
@@ -202,6 +202,27 @@ func (client *Client) ListVIPPoolsInNetworkDomain(networkDomainID string, paging
 	return pools, nil
 }
 
+// listAllVIPPoolsInNetworkDomain retrieves all VIP pools in the specified network domain (across all pages of results).
+func (client *Client) listAllVIPPoolsInNetworkDomain(networkDomainID string) (pools []VIPPool, err error) {
+	paging := DefaultPaging()
+	for {
+		var page *VIPPools
+		page, err = client.ListVIPPoolsInNetworkDomain(networkDomainID, paging)
+		if err != nil {
+			return nil, err
+		}
+
+		pools = append(pools, page.Items...)
+		if page.IsLastPage() {
+			break
+		}
+
+		paging.Next()
+	}
+
+	return pools, nil
+}
+
 // GetVIPPool retrieves the VIP pool with the specified Id.
 // Returns nil if no VIP pool is found with the specified Id.
 func (client *Client) GetVIPPool(id string) (pool *VIPPool, err error) {
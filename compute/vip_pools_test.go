@@ -32,6 +32,27 @@ func TestClient_CreateVIPPool_Success(test *testing.T) {
 	})
 }
 
+// List VIP pools in network domain (successful).
+func TestClient_ListVIPPoolsInNetworkDomain_Success(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			pools, err := client.ListVIPPoolsInNetworkDomain("553f26b6-2a73-42c3-a78b-6116f11291d0", DefaultPaging())
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.EqualsInt("VIPPools.Items.Length", 1, len(pools.Items))
+			expect.EqualsString("VIPPools.Items[0].ID", "4d360b1f-bc2c-4ab7-9884-1f03ba2768f7", pools.Items[0].ID)
+			expect.EqualsString("VIPPools.Items[0].Name", "myDevelopmentPool.1", pools.Items[0].Name)
+			expect.EqualsString("VIPPools.Items[0].LoadBalanceMethod", LoadBalanceMethodRoundRobin, pools.Items[0].LoadBalanceMethod)
+			expect.EqualsInt("VIPPools.TotalCount", 1, pools.TotalCount)
+		},
+		Respond: testRespondOK(listVIPPoolsInNetworkDomainTestResponse),
+	})
+}
+
 /*
  * Test requests.
  */
@@ -70,6 +91,32 @@ func verifyCreateVIPPoolTestRequest(test *testing.T, request *NewVIPPoolConfigur
  * Test responses.
  */
 
+var listVIPPoolsInNetworkDomainTestResponse = `
+{
+	"pool": [
+		{
+			"id": "4d360b1f-bc2c-4ab7-9884-1f03ba2768f7",
+			"name": "myDevelopmentPool.1",
+			"description": "Pool for load balancing development application servers.",
+			"loadBalanceMethod": "ROUND_ROBIN",
+			"healthMonitor": [
+				{ "id": "01683574-d487-11e4-811f-005056806999", "name": "CCDEFAULT.Http" }
+			],
+			"serviceDownAction": "RESELECT",
+			"slowRampTime": 10,
+			"state": "NORMAL",
+			"networkDomainId": "553f26b6-2a73-42c3-a78b-6116f11291d0",
+			"datacenterId": "NA9",
+			"createTime": "2015-06-04T09:15:07.000Z"
+		}
+	],
+	"pageNumber": 1,
+	"pageCount": 1,
+	"totalCount": 1,
+	"pageSize": 250
+}
+`
+
 var createVIPPoolTestResponse = `
 {
 	"requestId": "na9_20160321T074626030-0400_7e9fffe7-190b-46f2-9107-9d52fe57d0ad",
@@ -215,6 +215,27 @@ func (client *Client) ListVirtualListenersInNetworkDomain(networkDomainID string
 	return listeners, nil
 }
 
+// listAllVirtualListenersInNetworkDomain retrieves all virtual listeners in the specified network domain (across all pages of results).
+func (client *Client) listAllVirtualListenersInNetworkDomain(networkDomainID string) (listeners []VirtualListener, err error) {
+	paging := DefaultPaging()
+	for {
+		var page *VirtualListeners
+		page, err = client.ListVirtualListenersInNetworkDomain(networkDomainID, paging)
+		if err != nil {
+			return nil, err
+		}
+
+		listeners = append(listeners, page.Items...)
+		if page.IsLastPage() {
+			break
+		}
+
+		paging.Next()
+	}
+
+	return listeners, nil
+}
+
 // GetVirtualListener retrieves the virtual listener with the specified Id.
 // Returns nil if no virtual listener is found with the specified Id.
 func (client *Client) GetVirtualListener(id string) (listener *VirtualListener, err error) {
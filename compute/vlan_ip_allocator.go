@@ -0,0 +1,239 @@
+package compute
+
+import (
+	"fmt"
+	"sort"
+)
+
+// The maximum number of attempts made by ReserveNextFreeVLANIPv4Address to reserve an address.
+const maxVLANIPv4ReservationAttempts = 5
+
+// VLANIPv4AddressUsage represents the private IPv4 addresses in use on a VLAN.
+type VLANIPv4AddressUsage struct {
+	// The VLAN's Id.
+	VLANID string
+
+	// The VLAN's IPv4 network range.
+	IPv4Range IPv4Range
+
+	// Addresses in the VLAN's IPv4 range that are in use (keyed by address; values describe what is using each address).
+	UsedAddresses map[string]string
+
+	firstAddress uint32
+	lastAddress  uint32
+}
+
+// IsFree determines whether the specified address is in the VLAN's IPv4 range, and is not in use.
+func (usage *VLANIPv4AddressUsage) IsFree(address string) bool {
+	ip, err := parseIPv4Address(address)
+	if err != nil {
+		return false
+	}
+
+	numericAddress := ipv4ToUint32(ip)
+	if numericAddress < usage.firstAddress || numericAddress > usage.lastAddress {
+		return false
+	}
+
+	_, isUsed := usage.UsedAddresses[ip.String()]
+
+	return !isUsed
+}
+
+// MarkUsed records the specified address as being in use.
+func (usage *VLANIPv4AddressUsage) MarkUsed(address string, usedBy string) {
+	ip, err := parseIPv4Address(address)
+	if err != nil {
+		return
+	}
+
+	usage.UsedAddresses[ip.String()] = usedBy
+}
+
+// GetFreeAddresses retrieves up to maxCount free addresses (in ascending order) from the VLAN's IPv4 range.
+//
+// If maxCount is 0, all free addresses are returned.
+func (usage *VLANIPv4AddressUsage) GetFreeAddresses(maxCount int) []string {
+	var freeAddresses []string
+	for numericAddress := uint64(usage.firstAddress); numericAddress <= uint64(usage.lastAddress); numericAddress++ {
+		address := uint32ToIPv4(uint32(numericAddress)).String()
+		if _, isUsed := usage.UsedAddresses[address]; isUsed {
+			continue
+		}
+
+		freeAddresses = append(freeAddresses, address)
+		if maxCount > 0 && len(freeAddresses) == maxCount {
+			break
+		}
+	}
+
+	return freeAddresses
+}
+
+// GetNextFreeAddress retrieves the lowest free address in the VLAN's IPv4 range.
+//
+// Returns an error if there are no free addresses.
+func (usage *VLANIPv4AddressUsage) GetNextFreeAddress() (string, error) {
+	freeAddresses := usage.GetFreeAddresses(1)
+	if len(freeAddresses) == 0 {
+		return "", fmt.Errorf("There are no free IPv4 addresses in network %s on VLAN '%s'.", usage.IPv4Range.ToDisplayString(), usage.VLANID)
+	}
+
+	return freeAddresses[0], nil
+}
+
+// GetUsedAddresses retrieves the addresses in use on the VLAN (in ascending order).
+func (usage *VLANIPv4AddressUsage) GetUsedAddresses() []string {
+	numericAddresses := make(numericIPv4Addresses, 0, len(usage.UsedAddresses))
+	for address := range usage.UsedAddresses {
+		ip, err := parseIPv4Address(address)
+		if err != nil {
+			continue
+		}
+
+		numericAddresses = append(numericAddresses, ipv4ToUint32(ip))
+	}
+	sort.Sort(numericAddresses)
+
+	usedAddresses := make([]string, len(numericAddresses))
+	for index, numericAddress := range numericAddresses {
+		usedAddresses[index] = uint32ToIPv4(numericAddress).String()
+	}
+
+	return usedAddresses
+}
+
+// numericIPv4Addresses is a sortable slice of numeric IPv4 addresses.
+type numericIPv4Addresses []uint32
+
+func (addresses numericIPv4Addresses) Len() int           { return len(addresses) }
+func (addresses numericIPv4Addresses) Less(i, j int) bool { return addresses[i] < addresses[j] }
+func (addresses numericIPv4Addresses) Swap(i, j int) {
+	addresses[i], addresses[j] = addresses[j], addresses[i]
+}
+
+// newVLANIPv4AddressUsage creates a new VLANIPv4AddressUsage for the specified VLAN (with its network, broadcast, and gateway addresses marked as used).
+func newVLANIPv4AddressUsage(vlan *VLAN) (*VLANIPv4AddressUsage, error) {
+	networkAddress, err := vlan.IPv4Range.GetNetworkAddress()
+	if err != nil {
+		return nil, err
+	}
+	broadcastAddress, err := vlan.IPv4Range.GetBroadcastAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	networkIP, _ := parseIPv4Address(networkAddress)
+	broadcastIP, _ := parseIPv4Address(broadcastAddress)
+
+	usage := &VLANIPv4AddressUsage{
+		VLANID:        vlan.ID,
+		IPv4Range:     vlan.IPv4Range,
+		UsedAddresses: make(map[string]string),
+		firstAddress:  ipv4ToUint32(networkIP),
+		lastAddress:   ipv4ToUint32(broadcastIP),
+	}
+	usage.MarkUsed(networkAddress, "network address")
+	usage.MarkUsed(broadcastAddress, "broadcast address")
+	if vlan.IPv4GatewayAddress != "" {
+		usage.MarkUsed(vlan.IPv4GatewayAddress, "gateway address")
+	}
+
+	return usage, nil
+}
+
+// GetVLANIPv4AddressUsage determines which private IPv4 addresses are in use on the specified VLAN.
+//
+// An address is considered to be in use if it is the VLAN's network, broadcast, or gateway address, if it has been reserved,
+// or if it is assigned to a server network adapter or VIP node.
+func (client *Client) GetVLANIPv4AddressUsage(vlan *VLAN) (*VLANIPv4AddressUsage, error) {
+	usage, err := newVLANIPv4AddressUsage(vlan)
+	if err != nil {
+		return nil, err
+	}
+
+	reservedAddresses, err := client.listAllReservedPrivateIPv4Addresses(vlan.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, reservedAddress := range reservedAddresses {
+		usage.MarkUsed(reservedAddress.Address, "reserved")
+	}
+
+	servers, err := client.ListAllServers(&ServerQuery{
+		VLANID: vlan.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, server := range servers {
+		networkAdapters := append(
+			[]VirtualMachineNetworkAdapter{server.Network.PrimaryAdapter},
+			server.Network.AdditionalNetworkAdapters...,
+		)
+		for _, networkAdapter := range networkAdapters {
+			if networkAdapter.PrivateIPv4Address == nil || !vlan.IPv4Range.Contains(*networkAdapter.PrivateIPv4Address) {
+				continue
+			}
+
+			usage.MarkUsed(*networkAdapter.PrivateIPv4Address,
+				fmt.Sprintf("server '%s' (network adapter '%s')", server.ID, networkAdapter.GetID()),
+			)
+		}
+	}
+
+	vipNodes, err := client.listAllVIPNodesInNetworkDomain(vlan.NetworkDomain.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, vipNode := range vipNodes {
+		if !vlan.IPv4Range.Contains(vipNode.IPv4Address) {
+			continue
+		}
+
+		usage.MarkUsed(vipNode.IPv4Address, fmt.Sprintf("VIP node '%s'", vipNode.ID))
+	}
+
+	return usage, nil
+}
+
+// GetNextFreeVLANIPv4Address retrieves the lowest private IPv4 address that is not in use on the specified VLAN.
+//
+// Note that the address is not reserved, so it may be taken by another deployment before it is used; see ReserveNextFreeVLANIPv4Address.
+func (client *Client) GetNextFreeVLANIPv4Address(vlan *VLAN) (string, error) {
+	usage, err := client.GetVLANIPv4AddressUsage(vlan)
+	if err != nil {
+		return "", err
+	}
+
+	return usage.GetNextFreeAddress()
+}
+
+// ReserveNextFreeVLANIPv4Address finds the lowest private IPv4 address that is not in use on the specified VLAN, and reserves it.
+//
+// If the address is taken by someone else before it can be reserved, the next free address is tried instead.
+func (client *Client) ReserveNextFreeVLANIPv4Address(vlan *VLAN, description string) (string, error) {
+	usage, err := client.GetVLANIPv4AddressUsage(vlan)
+	if err != nil {
+		return "", err
+	}
+
+	for attempt := 1; ; attempt++ {
+		address, err := usage.GetNextFreeAddress()
+		if err != nil {
+			return "", err
+		}
+
+		err = client.ReservePrivateIPv4Address(vlan.ID, address, description)
+		if err == nil {
+			return address, nil
+		}
+
+		apiError, ok := err.(*APIError)
+		if !ok || apiError.Response.GetResponseCode() != ResponseCodeIPAddressNotUnique || attempt == maxVLANIPv4ReservationAttempts {
+			return "", err
+		}
+
+		usage.MarkUsed(address, "in use (reservation failed)")
+	}
+}
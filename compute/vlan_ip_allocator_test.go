@@ -0,0 +1,162 @@
+package compute
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// Get VLAN IPv4 address usage (successful).
+func TestClient_GetVLANIPv4AddressUsage_Success(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			usage, err := client.GetVLANIPv4AddressUsage(testAllocatorVLAN())
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.EqualsInt("Usage.UsedAddresses.Length", 9, len(usage.UsedAddresses))
+			expect.EqualsString("Usage.UsedAddresses", "10.0.3.0,10.0.3.1,10.0.3.2,10.0.3.10,10.0.3.11,10.0.3.16,10.0.3.17,10.0.3.18,10.0.3.31",
+				strings.Join(usage.GetUsedAddresses(), ","),
+			)
+			expect.EqualsInt("Usage.GetFreeAddresses.Length", 23, len(usage.GetFreeAddresses(0)))
+			expect.EqualsString("Usage.GetFreeAddresses(3)", "10.0.3.3,10.0.3.4,10.0.3.5", strings.Join(usage.GetFreeAddresses(3), ","))
+
+			expect.IsFalse("Usage.IsFree(10.0.3.17)", usage.IsFree("10.0.3.17"))
+			expect.IsFalse("Usage.IsFree(10.0.4.5)", usage.IsFree("10.0.4.5"))
+			expect.IsTrue("Usage.IsFree(10.0.3.30)", usage.IsFree("10.0.3.30"))
+
+			nextFreeAddress, err := usage.GetNextFreeAddress()
+			if err != nil {
+				test.Fatal(err)
+			}
+			expect.EqualsString("Usage.GetNextFreeAddress", "10.0.3.3", nextFreeAddress)
+		},
+		Respond: testRespondToVLANIPv4AddressUsageRequest(test),
+	})
+}
+
+// Reserve next free VLAN IPv4 address (first candidate address is taken before it can be reserved).
+func TestClient_ReserveNextFreeVLANIPv4Address_Retry(test *testing.T) {
+	expect := expect(test)
+
+	reservationAttempts := 0
+	respondToUsageRequest := testRespondToVLANIPv4AddressUsageRequest(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			address, err := client.ReserveNextFreeVLANIPv4Address(testAllocatorVLAN(), "Load balancer")
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.EqualsString("ReservedAddress", "10.0.3.4", address)
+			expect.EqualsInt("ReservationAttempts", 2, reservationAttempts)
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			if !strings.HasSuffix(request.URL.Path, "/network/reservePrivateIpv4Address") {
+				return respondToUsageRequest(test, request)
+			}
+
+			reservationAttempts++
+			requestBody := &reserveIPAddress{}
+			err := readRequestBodyAsJSON(request, requestBody)
+			if err != nil {
+				test.Fatal(err)
+			}
+			expect.EqualsString("ReserveIPAddress.Description", "Load balancer", requestBody.Description)
+
+			if requestBody.IPAddress == "10.0.3.3" {
+				return http.StatusBadRequest, reservePrivateIPv4AddressNotUniqueTestResponse
+			}
+
+			return http.StatusOK, reservePrivateIPv4AddressTestResponse
+		},
+	})
+}
+
+// Get next free address from VLAN IPv4 address usage (no free addresses).
+func TestVLANIPv4AddressUsage_GetNextFreeAddress_Exhausted(test *testing.T) {
+	vlan := testAllocatorVLAN()
+	vlan.IPv4Range.PrefixSize = 30
+
+	usage, err := newVLANIPv4AddressUsage(vlan)
+	if err != nil {
+		test.Fatal(err)
+	}
+	usage.MarkUsed("10.0.3.2", "reserved")
+
+	_, err = usage.GetNextFreeAddress()
+	expect(test).NotNil("GetNextFreeAddress error", err)
+}
+
+func testAllocatorVLAN() *VLAN {
+	return &VLAN{
+		ID: "0e56433f-d808-4669-821d-812769517ff8",
+		NetworkDomain: EntityReference{
+			ID: "484174a2-ae74-4658-9e56-50fc90e086cf",
+		},
+		IPv4Range: IPv4Range{
+			BaseAddress: "10.0.3.0",
+			PrefixSize:  27,
+		},
+		IPv4GatewayAddress: "10.0.3.1",
+	}
+}
+
+func testRespondToVLANIPv4AddressUsageRequest(test *testing.T) ClientTestResponder {
+	return func(test *testing.T, request *http.Request) (int, string) {
+		switch {
+		case strings.HasSuffix(request.URL.Path, "/network/reservedPrivateIpv4Address"):
+			return http.StatusOK, listReservedPrivateIPv4AddressesTestResponse
+		case strings.HasSuffix(request.URL.Path, "/server/server"):
+			expect(test).EqualsString("Request.URL.Query.vlanId", "0e56433f-d808-4669-821d-812769517ff8", request.URL.Query().Get("vlanId"))
+
+			return http.StatusOK, findNetworkAdapterListServersTestResponse
+		case strings.HasSuffix(request.URL.Path, "/networkDomainVip/node"):
+			return http.StatusOK, allocatorListVIPNodesTestResponse
+		}
+
+		test.Fatalf("Unexpected request: %s %s", request.Method, request.URL.Path)
+
+		return http.StatusNotFound, ""
+	}
+}
+
+/*
+ * Test responses.
+ */
+
+const allocatorListVIPNodesTestResponse = `
+	{
+		"node": [
+			{
+				"id": "34de6ed6-46a4-4dae-a753-2f8d3840c6f9",
+				"name": "ProductionNode.2",
+				"ipv4Address": "10.0.3.2",
+				"state": "NORMAL"
+			},
+			{
+				"id": "9e6b496d-5261-4542-91aa-b50c7f569c54",
+				"name": "OtherVLANNode",
+				"ipv4Address": "10.0.4.5",
+				"state": "NORMAL"
+			}
+		],
+		"pageNumber": 1,
+		"pageCount": 2,
+		"totalCount": 2,
+		"pageSize": 50
+	}
+`
+
+const reservePrivateIPv4AddressNotUniqueTestResponse = `
+{
+	"operation": "RESERVE_PRIVATE_IPV4_ADDRESS",
+	"responseCode": "IP_ADDRESS_NOT_UNIQUE",
+	"message": "Private IPv4 Address 10.0.3.3 is already in use.",
+	"requestId": "na9_20160321T074626030-0400_7e9fffe7-190b-46f2-9107-9d52fe57d0ad"
+}
+`
@@ -6,6 +6,14 @@ import (
 	"net/http"
 )
 
+const (
+	// MinVLANIPv4PrefixSize is the smallest permitted private IPv4 prefix size (i.e. the largest network) for a VLAN.
+	MinVLANIPv4PrefixSize = 16
+
+	// MaxVLANIPv4PrefixSize is the largest permitted private IPv4 prefix size (i.e. the smallest network) for a VLAN.
+	MaxVLANIPv4PrefixSize = 24
+)
+
 // VLAN represents a compute VLAN.
 type VLAN struct {
 	// The VLAN Id.
@@ -117,6 +125,15 @@ type EditVLAN struct {
 	Description *string `json:"description,omitempty"`
 }
 
+// ExpandVLAN represents the request body when expanding a cloud compute VLAN.
+type ExpandVLAN struct {
+	// The ID of the VLAN to expand.
+	ID string `json:"id"`
+
+	// The new private IPv4 prefix size (i.e. netmask) for the VLAN.
+	IPv4PrefixSize int `json:"privateIpv4PrefixSize"`
+}
+
 // DeleteVLAN represents a request to delete a compute VLAN.
 type DeleteVLAN struct {
 	// The VLAN Id.
@@ -203,6 +220,27 @@ func (client *Client) ListVLANs(networkDomainID string, paging *Paging) (vlans *
 	return vlans, err
 }
 
+// listAllVLANs retrieves all VLANs in the specified network domain (across all pages of results).
+func (client *Client) listAllVLANs(networkDomainID string) (vlans []VLAN, err error) {
+	paging := DefaultPaging()
+	for {
+		var page *VLANs
+		page, err = client.ListVLANs(networkDomainID, paging)
+		if err != nil {
+			return nil, err
+		}
+
+		vlans = append(vlans, page.VLANs...)
+		if page.IsLastPage() {
+			break
+		}
+
+		paging.Next()
+	}
+
+	return vlans, nil
+}
+
 // DeployVLAN deploys a new VLAN into a network domain.
 func (client *Client) DeployVLAN(networkDomainID string, name string, description string, ipv4BaseAddress string, ipv4PrefixSize int) (vlanID string, err error) {
 	organizationID, err := client.getOrganizationID()
@@ -273,6 +311,41 @@ func (client *Client) EditVLAN(id string, name *string, description *string) (er
 	return nil
 }
 
+// ExpandVLAN expands an existing VLAN's private IPv4 network by reducing its prefix size.
+// The VLAN's base address is retained; the expanded network must not overlap with any other VLAN in the same network domain.
+// Returns an error if the operation was not successful.
+func (client *Client) ExpandVLAN(id string, ipv4PrefixSize int) (err error) {
+	if ipv4PrefixSize < MinVLANIPv4PrefixSize || ipv4PrefixSize > MaxVLANIPv4PrefixSize {
+		return fmt.Errorf("Invalid IPv4 prefix size %d for VLAN '%s' (must be between %d and %d).", ipv4PrefixSize, id, MinVLANIPv4PrefixSize, MaxVLANIPv4PrefixSize)
+	}
+
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return err
+	}
+
+	requestURI := fmt.Sprintf("%s/network/expandVlan", organizationID)
+	request, err := client.newRequestV22(requestURI, http.MethodPost, &ExpandVLAN{
+		ID:             id,
+		IPv4PrefixSize: ipv4PrefixSize,
+	})
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return err
+	}
+
+	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
+	if err != nil {
+		return err
+	}
+
+	if apiResponse.ResponseCode != ResponseCodeInProgress {
+		return apiResponse.ToError("Request to expand VLAN '%s' failed with unexpected status code %d (%s): %s", id, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+	}
+
+	return nil
+}
+
 // DeleteVLAN deletes an existing VLAN.
 // Returns an error if the operation was not successful.
 func (client *Client) DeleteVLAN(id string) (err error) {
@@ -93,6 +93,28 @@ func TestClient_DeleteVLAN_Success(test *testing.T) {
 	})
 }
 
+// Expand VLAN (successful).
+func TestClient_ExpandVLAN_Success(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			err := client.ExpandVLAN("0e56433f-d808-4669-821d-812769517ff8", 22)
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			err = client.ExpandVLAN("0e56433f-d808-4669-821d-812769517ff8", 12)
+			expect.NotNil("ExpandVLAN error (invalid prefix size)", err)
+		},
+		Respond: testValidateJSONRequestAndRespondOK(expandVLANTestResponse, &ExpandVLAN{}, func(test *testing.T, requestBody interface{}) {
+			request := requestBody.(*ExpandVLAN)
+			expect.EqualsString("ExpandVLAN.ID", "0e56433f-d808-4669-821d-812769517ff8", request.ID)
+			expect.EqualsInt("ExpandVLAN.IPv4PrefixSize", 22, request.IPv4PrefixSize)
+		}),
+	})
+}
+
 /*
  * Test requests.
  */
@@ -327,3 +349,15 @@ func verifyDeleteVLANTestResponse(test *testing.T, response *APIResponseV2) {
 	expect.EqualsString("Response.Message", "Request to VLAN (Id: 0e56433f-d808-4669-821d-812769517ff8) has been accepted and is being processed.", response.Message)
 	expect.EqualsString("Response.RequestID", "na9_20160321T074626030-0400_7e9fffe7-190b-46f2-9107-9d52fe57d0ad", response.RequestID)
 }
+
+var expandVLANTestResponse = `
+	{
+		"operation": "EXPAND_VLAN",
+		"responseCode": "IN_PROGRESS",
+		"message": "Request to expand VLAN (Id:0e56433f-d808-4669-821d-812769517ff8) has been accepted and is being processed.",
+		"info": [],
+		"warning": [],
+		"error": [],
+		"requestId": "na9_20160321T074626030-0400_7e9fffe7-190b-46f2-9107-9d52fe57d0ad"
+	}
+`